@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Prefixes of the Connect config keys that override the Kafka clients of a connector.
+const (
+	producerOverridePrefix = "producer.override."
+	consumerOverridePrefix = "consumer.override."
+)
+
+// commonClientProperties are the properties shared by the Kafka producer and consumer.
+var commonClientProperties = []string{
+	"bootstrap.servers", "client.dns.lookup", "client.id", "connections.max.idle.ms",
+	"interceptor.classes", "metadata.max.age.ms", "metric.reporters", "metrics.num.samples",
+	"metrics.recording.level", "metrics.sample.window.ms", "receive.buffer.bytes",
+	"reconnect.backoff.max.ms", "reconnect.backoff.ms", "request.timeout.ms", "retry.backoff.max.ms",
+	"retry.backoff.ms", "security.protocol", "security.providers", "send.buffer.bytes",
+	"socket.connection.setup.timeout.max.ms", "socket.connection.setup.timeout.ms",
+}
+
+// producerProperties are the Kafka producer properties, besides commonClientProperties.
+var producerProperties = clientProperties(
+	"acks", "batch.size", "buffer.memory", "compression.type", "compression.gzip.level",
+	"compression.lz4.level", "compression.zstd.level", "delivery.timeout.ms", "enable.idempotence",
+	"key.serializer", "linger.ms", "max.block.ms", "max.in.flight.requests.per.connection",
+	"max.request.size", "metadata.max.idle.ms", "partitioner.adaptive.partitioning.enable",
+	"partitioner.availability.timeout.ms", "partitioner.class", "partitioner.ignore.keys", "retries",
+	"transaction.timeout.ms", "transactional.id", "value.serializer",
+)
+
+// consumerProperties are the Kafka consumer properties, besides commonClientProperties.
+var consumerProperties = clientProperties(
+	"allow.auto.create.topics", "auto.commit.interval.ms", "auto.offset.reset", "check.crcs",
+	"client.rack", "default.api.timeout.ms", "enable.auto.commit", "exclude.internal.topics",
+	"fetch.max.bytes", "fetch.max.wait.ms", "fetch.min.bytes", "group.id", "group.instance.id",
+	"group.protocol", "heartbeat.interval.ms", "isolation.level", "key.deserializer",
+	"max.partition.fetch.bytes", "max.poll.interval.ms", "max.poll.records",
+	"partition.assignment.strategy", "session.timeout.ms", "value.deserializer",
+)
+
+// clientPropertyPrefixes are the prefixes of the SSL and SASL properties of both clients.
+var clientPropertyPrefixes = []string{"sasl.", "ssl."}
+
+// clientProperties returns a set of the given properties and commonClientProperties.
+func clientProperties(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names)+len(commonClientProperties))
+	for _, name := range append(names, commonClientProperties...) {
+		set[name] = true
+	}
+	return set
+}
+
+// isClientProperty reports whether key is one of properties or an SSL or SASL property.
+func isClientProperty(key string, properties map[string]bool) bool {
+	if properties[key] {
+		return true
+	}
+	for _, prefix := range clientPropertyPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientOverrideConfigKeys expands Spec.ProducerOverrides and Spec.ConsumerOverrides into
+// prefixed Connect config keys.
+func (r *DebeziumConnector) ClientOverrideConfigKeys() map[string]string {
+	config := map[string]string{}
+	for k, v := range r.Spec.ProducerOverrides {
+		config[producerOverridePrefix+k] = v
+	}
+	for k, v := range r.Spec.ConsumerOverrides {
+		config[consumerOverridePrefix+k] = v
+	}
+	return config
+}
+
+// validateClientOverrides rejects override keys that are not Kafka client properties, and raw
+// config keys that contradict the overrides.
+func (r *DebeziumConnector) validateClientOverrides(config map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateClientOverrideKeys(field.NewPath("spec").Child("producerOverrides"),
+		r.Spec.ProducerOverrides, producerProperties, "producer")...)
+	allErrs = append(allErrs, validateClientOverrideKeys(field.NewPath("spec").Child("consumerOverrides"),
+		r.Spec.ConsumerOverrides, consumerProperties, "consumer")...)
+
+	expanded := r.ClientOverrideConfigKeys()
+	keys := make([]string, 0, len(expanded))
+	for key := range expanded {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if v, ok := config[key]; ok && v != expanded[key] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), v,
+				"conflicts with the client overrides, which set it to \""+expanded[key]+"\""))
+		}
+	}
+	return allErrs
+}
+
+// validateClientOverrideKeys rejects the keys of overrides that are not properties of the client.
+func validateClientOverrideKeys(path *field.Path, overrides map[string]string, properties map[string]bool, client string) field.ErrorList {
+	var allErrs field.ErrorList
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !isClientProperty(key, properties) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, "is not a Kafka "+client+" property"))
+		}
+	}
+	return allErrs
+}
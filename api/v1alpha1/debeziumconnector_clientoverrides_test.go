@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client overrides", func() {
+	ctx := context.Background()
+
+	It("expands into prefixed config keys", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ProducerOverrides = map[string]string{"compression.type": "zstd", "sasl.jaas.config": "jaas"}
+		dbc.Spec.ConsumerOverrides = map[string]string{"max.poll.records": "500"}
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("producer.override.compression.type", "zstd"))
+		Expect(config).To(HaveKeyWithValue("producer.override.sasl.jaas.config", "jaas"))
+		Expect(config).To(HaveKeyWithValue("consumer.override.max.poll.records", "500"))
+		Expect(dbc.validateClientOverrides(config)).To(BeEmpty())
+	})
+
+	It("lets raw config set keys not covered by the overrides", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ProducerOverrides = map[string]string{"linger.ms": "10"}
+		dbc.Spec.Config["admin.override.request.timeout.ms"] = "30000"
+		config, _ := dbc.DesiredConfig()
+		Expect(config).To(HaveKeyWithValue("admin.override.request.timeout.ms", "30000"))
+		Expect(dbc.validateClientOverrides(config)).To(BeEmpty())
+	})
+
+	It("rejects keys that are not properties of the client", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ProducerOverrides = map[string]string{"max.poll.records": "500", "producer.override.acks": "all"}
+		dbc.Spec.ConsumerOverrides = map[string]string{"linger.ms": "10", "ssl.": "x"}
+		config, _ := dbc.DesiredConfig()
+		errs := dbc.validateClientOverrides(config)
+		Expect(errs).To(HaveLen(4))
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring(`spec.producerOverrides[max.poll.records]: Invalid value: "max.poll.records": is not a Kafka producer property`))
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring(`spec.consumerOverrides[linger.ms]: Invalid value: "linger.ms": is not a Kafka consumer property`))
+	})
+
+	It("rejects raw config keys that contradict the overrides", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ProducerOverrides = map[string]string{"acks": "all"}
+		dbc.Spec.Config["producer.override.acks"] = "1"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring(`spec.config.producer.override.acks: Invalid value: "1": conflicts with the client overrides`)))
+	})
+})
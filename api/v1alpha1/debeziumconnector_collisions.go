@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TopicPrefixKeys name the config keys that determine a connector's topic names, in order of
+// precedence; database.server.name is used by Debezium releases before 2.0.
+var TopicPrefixKeys = []string{"topic.prefix", "database.server.name"}
+
+// TopicPrefix returns the key and the trimmed value that determine the topic names of config.
+func TopicPrefix(config map[string]string) (string, string, bool) {
+	for _, key := range TopicPrefixKeys {
+		if v := strings.TrimSpace(config[key]); v != "" {
+			return key, v, true
+		}
+	}
+	return "", "", false
+}
+
+// findTopicPrefixCollisions returns an error for every other DebeziumConnector in the namespace
+// of r that writes to the same topic prefix. Values stored outside the resource are not compared.
+func (v *DebeziumConnectorValidator) findTopicPrefixCollisions(ctx context.Context, r *DebeziumConnector, config map[string]string) (field.ErrorList, error) {
+	key, prefix, ok := TopicPrefix(config)
+	if !ok || v.Client == nil {
+		return nil, nil
+	}
+
+	list := &DebeziumConnectorList{}
+	if err := v.Client.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list DebeziumConnectors: %w", err)
+	}
+	var others []string
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == r.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		otherConfig, _ := other.DesiredConfig()
+		if _, otherPrefix, ok := TopicPrefix(otherConfig); ok && otherPrefix == prefix {
+			others = append(others, other.Name)
+		}
+	}
+	sort.Strings(others)
+
+	var allErrs field.ErrorList
+	for _, name := range others {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), prefix,
+			fmt.Sprintf("topic prefix is already used by DebeziumConnector %s/%s", r.Namespace, name)))
+	}
+	return allErrs, nil
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Topic prefix collisions", func() {
+	ctx := context.Background()
+
+	var existing *DebeziumConnector
+
+	BeforeEach(func() {
+		existing = newTestConnector("http://unused")
+		existing.Name = "orders"
+		existing.Spec.Config["name"] = "orders"
+		existing.Spec.Config["topic.prefix"] = "shop"
+	})
+
+	newValidator := func(warn bool) *DebeziumConnectorValidator {
+		s := runtime.NewScheme()
+		Expect(AddToScheme(s)).To(Succeed())
+		return &DebeziumConnectorValidator{
+			Client:                     fake.NewClientBuilder().WithScheme(s).WithObjects(existing).Build(),
+			WarnOnTopicPrefixCollision: warn,
+		}
+	}
+
+	It("rejects a connector reusing another connector's topic prefix", func() {
+		dbc := newTestConnector("http://unused")
+		delete(dbc.Spec.Config, "topic.prefix")
+		dbc.Annotations = map[string]string{ConfigOverrideAnnotationPrefix + "database.server.name": "shop"}
+		_, err := newValidator(false).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("topic prefix is already used by DebeziumConnector default/orders")))
+	})
+
+	It("warns instead of rejecting when configured", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.Config["topic.prefix"] = "shop"
+		dbc.Spec.Config["database.password"] = "${vault:secret/data/inventory#password}"
+		warnings, err := newValidator(true).ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(ContainSubstring("default/orders")))
+	})
+
+	It("ignores the connector itself and other namespaces", func() {
+		v := newValidator(false)
+		errs, err := v.findTopicPrefixCollisions(ctx, existing, existing.Spec.Config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errs).To(BeEmpty())
+
+		dbc := newTestConnector("http://unused")
+		dbc.Namespace = "other"
+		errs, err = v.findTopicPrefixCollisions(ctx, dbc, map[string]string{"topic.prefix": "shop"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errs).To(BeEmpty())
+	})
+})
@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// ConfigOverrideAnnotationPrefix prefixes annotations that override a single config key,
+// e.g. "config.debezium.io/snapshot.mode: never".
+const ConfigOverrideAnnotationPrefix = "config.debezium.io/"
+
+// configKeyPattern matches legal Kafka Connect config keys.
+var configKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// nonOverridableKeys cannot be changed through annotations since they identify the connector.
+var nonOverridableKeys = map[string]bool{
+	"name":            true,
+	"connector.class": true,
+}
+
+// ConfigOverrides returns the config overrides declared through annotations, along with
+// errors for annotations that do not map to a legal, overridable config key.
+func (r *DebeziumConnector) ConfigOverrides() (map[string]string, field.ErrorList) {
+	var allErrs field.ErrorList
+	overrides := map[string]string{}
+	for annotation, value := range r.Annotations {
+		key, ok := strings.CutPrefix(annotation, ConfigOverrideAnnotationPrefix)
+		if !ok {
+			continue
+		}
+		path := field.NewPath("metadata").Child("annotations").Key(annotation)
+		switch {
+		case !configKeyPattern.MatchString(key):
+			allErrs = append(allErrs, field.Invalid(path, key, "must be a valid connector config key"))
+		case nonOverridableKeys[key]:
+			allErrs = append(allErrs, field.Forbidden(path, "config key \""+key+"\" cannot be overridden by annotation"))
+		default:
+			overrides[key] = value
+		}
+	}
+	return overrides, allErrs
+}
+
+// ConfigOverridesHash returns a hash of the config overrides declared through annotations, or
+// the empty string when there are none.
+func (r *DebeziumConnector) ConfigOverridesHash() string {
+	overrides, _ := r.ConfigOverrides()
+	if len(overrides) == 0 {
+		return ""
+	}
+	return util.ConfigHash(overrides)
+}
+
+// DesiredConfig returns the keys expanded from Spec.ErrorHandling and the client overrides,
+// overlaid by Spec.ConfigProperties, Spec.Config and then by annotation overrides. Annotations
+// take precedence over keys set in Spec.Config. With Spec.ConfigTemplating, the values are
+// evaluated as templates last.
+func (r *DebeziumConnector) DesiredConfig() (map[string]string, field.ErrorList) {
+	overrides, allErrs := r.ConfigOverrides()
+	config := r.Spec.ErrorHandling.ConfigKeys()
+	for k, v := range r.ClientOverrideConfigKeys() {
+		config[k] = v
+	}
+	if r.Spec.ConfigProperties != "" {
+		props, err := util.ParseProperties(r.Spec.ConfigProperties)
+		if err != nil {
+			// The properties may hold credentials, so only the failing line is reported.
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("configProperties"), field.OmitValueType{}, err.Error()))
+		}
+		for k, v := range props {
+			config[k] = v
+		}
+	}
+	for k, v := range r.Spec.Config {
+		config[k] = v
+	}
+	for k, v := range overrides {
+		config[k] = v
+	}
+	if r.Spec.ConfigTemplating {
+		allErrs = append(allErrs, r.expandConfigTemplates(config)...)
+	}
+	return config, allErrs
+}
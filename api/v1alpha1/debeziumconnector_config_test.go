@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DebeziumConnector desired config", func() {
+	It("merges annotation overrides over Spec.Config", func() {
+		dbc := newTestConnector("http://connect:8083")
+		dbc.Spec.Config["snapshot.mode"] = "initial"
+		dbc.Annotations = map[string]string{
+			ConfigOverrideAnnotationPrefix + "snapshot.mode": "never",
+			ConfigOverrideAnnotationPrefix + "tasks.max":     "1",
+			"unrelated.io/annotation":                        "ignored",
+		}
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("snapshot.mode", "never"))
+		Expect(config).To(HaveKeyWithValue("tasks.max", "1"))
+		Expect(config).To(HaveKeyWithValue("name", "inventory"))
+		Expect(config).NotTo(HaveKey("unrelated.io/annotation"))
+		Expect(dbc.Spec.Config).To(HaveKeyWithValue("snapshot.mode", "initial"))
+	})
+
+	It("rejects overrides of identifying or malformed keys", func() {
+		dbc := newTestConnector("http://connect:8083")
+		dbc.Annotations = map[string]string{
+			ConfigOverrideAnnotationPrefix + "name":   "other",
+			ConfigOverrideAnnotationPrefix + ".bogus": "x",
+		}
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(HaveLen(2))
+		Expect(config).To(HaveKeyWithValue("name", "inventory"))
+	})
+
+	It("merges Spec.Config over Spec.ConfigProperties", func() {
+		dbc := newTestConnector("http://connect:8083")
+		dbc.Spec.Config["snapshot.mode"] = "never"
+		dbc.Spec.ConfigProperties = "# from inventory.properties\nsnapshot.mode=initial\ntable.include.list=inventory.orders,\\\n  inventory.customers\n"
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("snapshot.mode", "never"))
+		Expect(config).To(HaveKeyWithValue("table.include.list", "inventory.orders,inventory.customers"))
+		Expect(config).To(HaveKeyWithValue("name", "inventory"))
+	})
+
+	It("reports malformed properties by line without echoing them", func() {
+		dbc := newTestConnector("http://connect:8083")
+		dbc.Spec.ConfigProperties = "database.password=s3cret\nbad=\\u00zz\n"
+
+		_, errs := dbc.DesiredConfig()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.configProperties"))
+		Expect(errs[0].Error()).To(ContainSubstring("line 2"))
+		Expect(errs[0].Error()).NotTo(ContainSubstring("s3cret"))
+	})
+})
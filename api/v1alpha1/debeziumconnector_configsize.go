@@ -0,0 +1,43 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultConfigSizeLimit is the default max.message.bytes of the Kafka Connect config topic.
+const DefaultConfigSizeLimit = 1 << 20
+
+// configSizeWarningPercent is the share of the limit above which a config is reported as
+// approaching it.
+const configSizeWarningPercent = 80
+
+// EstimateConfigSize returns the approximate size in bytes of the record Kafka Connect writes to
+// its config topic for config.
+func EstimateConfigSize(config map[string]string) int {
+	data, err := json.Marshal(map[string]interface{}{"properties": config})
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// ConfigSizeWarningThreshold returns the size above which a config approaches limit.
+func ConfigSizeWarningThreshold(limit int) int {
+	return limit / 100 * configSizeWarningPercent
+}
+
+// validateConfigSize rejects a config larger than limit and warns about one approaching it.
+func validateConfigSize(config map[string]string, limit int) (field.ErrorList, []string) {
+	size := EstimateConfigSize(config)
+	switch {
+	case size > limit:
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("config"), field.OmitValueType{},
+			fmt.Sprintf("serialized config is %d bytes, exceeding the Kafka Connect config topic limit of %d bytes", size, limit))}, nil
+	case size > ConfigSizeWarningThreshold(limit):
+		return nil, []string{fmt.Sprintf("serialized config is %d bytes, approaching the Kafka Connect config topic limit of %d bytes", size, limit)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config size", func() {
+	ctx := context.Background()
+
+	// configOfSize returns a config whose serialized size is exactly size bytes.
+	configOfSize := func(size int) map[string]string {
+		config := map[string]string{"name": "inventory", "table.include.list": ""}
+		config["table.include.list"] = strings.Repeat("t", size-EstimateConfigSize(config))
+		Expect(EstimateConfigSize(config)).To(Equal(size))
+		return config
+	}
+
+	It("estimates the size of the config topic record", func() {
+		Expect(EstimateConfigSize(map[string]string{"a": "b"})).To(Equal(len(`{"properties":{"a":"b"}}`)))
+	})
+
+	It("warns above the threshold and rejects above the limit", func() {
+		errs, warnings := validateConfigSize(configOfSize(800), 1000)
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(BeEmpty())
+
+		errs, warnings = validateConfigSize(configOfSize(801), 1000)
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(ConsistOf(ContainSubstring("801 bytes, approaching")))
+
+		errs, warnings = validateConfigSize(configOfSize(1000), 1000)
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(HaveLen(1))
+
+		errs, _ = validateConfigSize(configOfSize(1001), 1000)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("1001 bytes, exceeding"))
+	})
+
+	Context("in the webhook", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"errors":{}}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("rejects oversized configs only when a limit is set", func() {
+			dbc := newTestConnector(server.URL)
+			dbc.Spec.Config["table.include.list"] = strings.Repeat("t", 2000)
+
+			_, err := (&DebeziumConnectorValidator{ConfigSizeLimit: 1000}).ValidateCreate(ctx, dbc)
+			Expect(err).To(MatchError(ContainSubstring("exceeding the Kafka Connect config topic limit of 1000 bytes")))
+
+			_, err = (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("admits configs approaching the limit with a warning", func() {
+			dbc := newTestConnector(server.URL)
+			dbc.Spec.Config["table.include.list"] = strings.Repeat("t", 750)
+
+			warnings, err := (&DebeziumConnectorValidator{ConfigSizeLimit: 1000}).ValidateCreate(ctx, dbc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("approaching")))
+		})
+	})
+})
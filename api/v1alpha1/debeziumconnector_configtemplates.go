@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxConfigTemplateOutput bounds the value a config template may produce.
+const maxConfigTemplateOutput = 64 * 1024
+
+// configTemplateData is the data config templates are evaluated against. It only holds strings,
+// so a template cannot call into the operator.
+type configTemplateData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// configTemplateFuncs are the functions available to config templates besides the text/template
+// builtins. None of them has side effects.
+var configTemplateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"default": func(def, s string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	},
+}
+
+// errConfigTemplateOutput is returned when a template produces more than maxConfigTemplateOutput.
+var errConfigTemplateOutput = errors.New("template output exceeds 64 KiB")
+
+// limitedBuilder is a strings.Builder that fails once it holds more than maxConfigTemplateOutput.
+type limitedBuilder struct {
+	strings.Builder
+}
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxConfigTemplateOutput {
+		return 0, errConfigTemplateOutput
+	}
+	return b.Builder.Write(p)
+}
+
+// expandConfigTemplates replaces each value in config that contains a template action with the
+// result of evaluating it over the metadata of r. A template referring to a missing label or
+// annotation fails, so a typo does not silently produce an empty value.
+func (r *DebeziumConnector) expandConfigTemplates(config map[string]string) field.ErrorList {
+	data := configTemplateData{
+		Name:        r.Name,
+		Namespace:   r.Namespace,
+		Labels:      r.Labels,
+		Annotations: r.Annotations,
+	}
+	if data.Labels == nil {
+		data.Labels = map[string]string{}
+	}
+	if data.Annotations == nil {
+		data.Annotations = map[string]string{}
+	}
+	var allErrs field.ErrorList
+	for key, value := range config {
+		if !strings.Contains(value, "{{") {
+			continue
+		}
+		path := field.NewPath("spec").Child("config").Key(key)
+		tmpl, err := template.New(key).Funcs(configTemplateFuncs).Option("missingkey=error").Parse(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+			continue
+		}
+		var out limitedBuilder
+		if err := tmpl.Execute(&out, data); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+			continue
+		}
+		config[key] = out.String()
+	}
+	return allErrs
+}
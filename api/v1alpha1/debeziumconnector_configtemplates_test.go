@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DebeziumConnector config templates", func() {
+	newTemplatedConnector := func() *DebeziumConnector {
+		dbc := newTestConnector("http://connect:8083")
+		dbc.Namespace = "billing"
+		dbc.Labels = map[string]string{"team": "payments"}
+		dbc.Annotations = map[string]string{"example.com/owner": "Ops"}
+		dbc.Spec.ConfigTemplating = true
+		return dbc
+	}
+
+	It("evaluates values over the resource's metadata", func() {
+		dbc := newTemplatedConnector()
+		dbc.Spec.Config["topic.prefix"] = "{{ .Namespace }}-{{ .Name }}"
+		dbc.Spec.Config["operator.team"] = `{{ .Labels.team | upper }}`
+		dbc.Spec.Config["operator.owner"] = `{{ index .Annotations "example.com/owner" | lower }}`
+		dbc.Spec.Config["operator.tier"] = `{{ index .Labels "tier" | default "standard" }}`
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("topic.prefix", "billing-inventory"))
+		Expect(config).To(HaveKeyWithValue("operator.team", "PAYMENTS"))
+		Expect(config).To(HaveKeyWithValue("operator.owner", "ops"))
+		Expect(config).To(HaveKeyWithValue("operator.tier", "standard"))
+		Expect(dbc.Spec.Config).To(HaveKeyWithValue("topic.prefix", "{{ .Namespace }}-{{ .Name }}"))
+	})
+
+	It("leaves values alone unless enabled", func() {
+		dbc := newTemplatedConnector()
+		dbc.Spec.ConfigTemplating = false
+		dbc.Spec.Config["topic.prefix"] = "{{ .Name }}"
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("topic.prefix", "{{ .Name }}"))
+	})
+
+	It("evaluates override annotations and config properties", func() {
+		dbc := newTemplatedConnector()
+		dbc.Spec.ConfigProperties = "signal.kafka.topic={{ .Name }}-signals\n"
+		dbc.Annotations[ConfigOverrideAnnotationPrefix+"snapshot.mode"] = "{{ if eq .Namespace \"billing\" }}never{{ else }}initial{{ end }}"
+
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("signal.kafka.topic", "inventory-signals"))
+		Expect(config).To(HaveKeyWithValue("snapshot.mode", "never"))
+	})
+
+	DescribeTable("reports bad templates on their config key",
+		func(value, message string) {
+			dbc := newTemplatedConnector()
+			dbc.Spec.Config["topic.prefix"] = value
+
+			_, errs := dbc.DesiredConfig()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.config[topic.prefix]"))
+			Expect(errs[0].Detail).To(ContainSubstring(message))
+		},
+		Entry("syntax error", "{{ .Name ", "unclosed action"),
+		Entry("missing label", "{{ .Labels.tier }}", `no entry for key "tier"`),
+		Entry("unknown field", "{{ .Spec.DebeziumHost }}", "can't evaluate field Spec"),
+		Entry("unknown function", `{{ env "HOME" }}`, `function "env" not defined`),
+		Entry("runaway output", `{{ range .Labels }}{{ printf "%070000d" 0 }}{{ end }}`, "exceeds 64 KiB"),
+	)
+
+	It("rejects bad templates on admission", func() {
+		dbc := newTemplatedConnector()
+		dbc.Spec.Config["topic.prefix"] = "{{ .Labels.tier }}"
+
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(context.Background(), dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config[topic.prefix]")))
+		Expect(err).To(MatchError(ContainSubstring(`no entry for key "tier"`)))
+	})
+})
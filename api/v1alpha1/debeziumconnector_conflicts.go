@@ -0,0 +1,126 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// ConfigKeyMatch matches a config key that is set, optionally only to one of Values.
+// +kubebuilder:object:generate=false
+type ConfigKeyMatch struct {
+	// Key is the connector config key.
+	Key string `json:"key"`
+	// Values restricts the match to these values; any value matches when empty.
+	Values []string `json:"values,omitempty"`
+}
+
+// ConfigConflictRule rejects configs in which more than one of Conflicting matches.
+// +kubebuilder:object:generate=false
+type ConfigConflictRule struct {
+	// ConnectorClasses limits the rule to these connector classes; it applies to all when empty.
+	ConnectorClasses []string `json:"connectorClasses,omitempty"`
+	// Conflicting lists the mutually exclusive key matches.
+	Conflicting []ConfigKeyMatch `json:"conflicting"`
+	// Message explains the conflict to the user.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	mySQLConnectorClass     = "io.debezium.connector.mysql.MySqlConnector"
+	mariaDBConnectorClass   = "io.debezium.connector.mariadb.MariaDbConnector"
+	postgresConnectorClass  = "io.debezium.connector.postgresql.PostgresConnector"
+	sqlServerConnectorClass = "io.debezium.connector.sqlserver.SqlServerConnector"
+	oracleConnectorClass    = "io.debezium.connector.oracle.OracleConnector"
+	db2ConnectorClass       = "io.debezium.connector.db2.Db2Connector"
+	mongoDBConnectorClass   = "io.debezium.connector.mongodb.MongoDbConnector"
+)
+
+// DefaultConfigConflictRules covers conflicting options of the common Debezium connectors.
+var DefaultConfigConflictRules = []ConfigConflictRule{
+	{
+		Conflicting: []ConfigKeyMatch{{Key: "table.include.list"}, {Key: "table.exclude.list"}},
+		Message:     "use either an include list or an exclude list of tables",
+	},
+	{
+		Conflicting: []ConfigKeyMatch{{Key: "column.include.list"}, {Key: "column.exclude.list"}},
+		Message:     "use either an include list or an exclude list of columns",
+	},
+	{
+		ConnectorClasses: []string{mySQLConnectorClass, mariaDBConnectorClass, sqlServerConnectorClass, mongoDBConnectorClass},
+		Conflicting:      []ConfigKeyMatch{{Key: "database.include.list"}, {Key: "database.exclude.list"}},
+		Message:          "use either an include list or an exclude list of databases",
+	},
+	{
+		ConnectorClasses: []string{postgresConnectorClass, sqlServerConnectorClass, oracleConnectorClass, db2ConnectorClass},
+		Conflicting:      []ConfigKeyMatch{{Key: "schema.include.list"}, {Key: "schema.exclude.list"}},
+		Message:          "use either an include list or an exclude list of schemas",
+	},
+	{
+		ConnectorClasses: []string{mongoDBConnectorClass},
+		Conflicting:      []ConfigKeyMatch{{Key: "collection.include.list"}, {Key: "collection.exclude.list"}},
+		Message:          "use either an include list or an exclude list of collections",
+	},
+	{
+		ConnectorClasses: []string{mySQLConnectorClass, mariaDBConnectorClass},
+		Conflicting: []ConfigKeyMatch{
+			{Key: "snapshot.mode", Values: []string{"never"}},
+			{Key: "snapshot.locking.mode", Values: []string{"minimal", "extended", "minimal_percona"}},
+		},
+		Message: "no snapshot is taken, so a snapshot locking mode other than \"none\" has no effect",
+	},
+}
+
+// LoadConfigConflictRules reads additional conflict rules from a YAML or JSON file,
+// typically mounted from a ConfigMap.
+func LoadConfigConflictRules(path string) ([]ConfigConflictRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config conflict rules: %w", err)
+	}
+	var rules []ConfigConflictRule
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse config conflict rules %s: %w", path, err)
+	}
+	for i, rule := range rules {
+		if len(rule.Conflicting) < 2 {
+			return nil, fmt.Errorf("config conflict rule %d in %s must list at least two keys", i, path)
+		}
+	}
+	return rules, nil
+}
+
+// validateConfigConflicts returns an error for every rule the config violates.
+func validateConfigConflicts(config map[string]string, rules []ConfigConflictRule) field.ErrorList {
+	var allErrs field.ErrorList
+	connectorClass := config["connector.class"]
+	masked := util.MaskSensitiveConfig(config)
+	for _, rule := range rules {
+		if len(rule.ConnectorClasses) > 0 && !slices.Contains(rule.ConnectorClasses, connectorClass) {
+			continue
+		}
+		var matched []string
+		for _, m := range rule.Conflicting {
+			value, ok := config[m.Key]
+			if !ok || (len(m.Values) > 0 && !slices.Contains(m.Values, value)) {
+				continue
+			}
+			matched = append(matched, fmt.Sprintf("%s=%q", m.Key, masked[m.Key]))
+		}
+		if len(matched) < 2 {
+			continue
+		}
+		msg := fmt.Sprintf("conflicting config keys %s", strings.Join(matched, ", "))
+		if rule.Message != "" {
+			msg += ": " + rule.Message
+		}
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("config"), msg))
+	}
+	return allErrs
+}
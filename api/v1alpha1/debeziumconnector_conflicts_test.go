@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config conflict rules", func() {
+	ctx := context.Background()
+
+	It("rejects both a table include list and exclude list", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.Config["table.include.list"] = "inventory.orders"
+		dbc.Spec.Config["table.exclude.list"] = "inventory.audit"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`table.include.list="inventory.orders", table.exclude.list="inventory.audit"`))
+	})
+
+	It("rejects a locking mode when MySQL takes no snapshot", func() {
+		config := map[string]string{
+			"connector.class":       mySQLConnectorClass,
+			"snapshot.mode":         "never",
+			"snapshot.locking.mode": "extended",
+		}
+		Expect(validateConfigConflicts(config, DefaultConfigConflictRules)).To(HaveLen(1))
+
+		config["snapshot.locking.mode"] = "none"
+		Expect(validateConfigConflicts(config, DefaultConfigConflictRules)).To(BeEmpty())
+	})
+
+	It("applies rules only to their connector classes", func() {
+		config := map[string]string{
+			"connector.class":       postgresConnectorClass,
+			"database.include.list": "a",
+			"database.exclude.list": "b",
+		}
+		Expect(validateConfigConflicts(config, DefaultConfigConflictRules)).To(BeEmpty())
+	})
+
+	It("loads additional rules from a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rules.yaml")
+		Expect(os.WriteFile(path, []byte(`
+- conflicting:
+  - key: heartbeat.action.query
+  - key: read.only
+    values: ["true"]
+  message: read-only connectors cannot run heartbeat queries
+`), 0o600)).To(Succeed())
+
+		rules, err := LoadConfigConflictRules(path)
+		Expect(err).NotTo(HaveOccurred())
+		errs := validateConfigConflicts(map[string]string{
+			"heartbeat.action.query": "UPDATE heartbeat SET ts = now()",
+			"read.only":              "true",
+		}, rules)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Detail).To(ContainSubstring("read-only connectors cannot run heartbeat queries"))
+	})
+
+	It("rejects a rule with a single key", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "rules.yaml")
+		Expect(os.WriteFile(path, []byte("- conflicting:\n  - key: read.only\n"), 0o600)).To(Succeed())
+		_, err := LoadConfigConflictRules(path)
+		Expect(err).To(HaveOccurred())
+	})
+})
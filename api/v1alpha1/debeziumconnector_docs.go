@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// debeziumDocsBase is the root of the Debezium connector reference documentation.
+const debeziumDocsBase = "https://debezium.io/documentation/reference/stable/connectors"
+
+// connectorDocsPages maps connector classes to their reference page, which also prefixes the
+// anchors of the page's config properties.
+var connectorDocsPages = map[string]string{
+	"io.debezium.connector.mysql.MySqlConnector":         "mysql",
+	"io.debezium.connector.mariadb.MariaDbConnector":     "mariadb",
+	"io.debezium.connector.postgresql.PostgresConnector": "postgresql",
+	"io.debezium.connector.sqlserver.SqlServerConnector": "sqlserver",
+	"io.debezium.connector.oracle.OracleConnector":       "oracle",
+	"io.debezium.connector.db2.Db2Connector":             "db2",
+	"io.debezium.connector.mongodb.MongoDbConnector":     "mongodb",
+}
+
+// documentedConfigKeys are the config keys whose reference anchors follow the
+// <page>-property-<key> scheme on every connector page that documents them.
+var documentedConfigKeys = map[string]bool{
+	"database.hostname":         true,
+	"database.port":             true,
+	"database.user":             true,
+	"database.password":         true,
+	"database.dbname":           true,
+	"database.names":            true,
+	"database.server.id":        true,
+	"database.include.list":     true,
+	"database.exclude.list":     true,
+	"schema.include.list":       true,
+	"schema.exclude.list":       true,
+	"table.include.list":        true,
+	"table.exclude.list":        true,
+	"collection.include.list":   true,
+	"collection.exclude.list":   true,
+	"column.include.list":       true,
+	"column.exclude.list":       true,
+	"topic.prefix":              true,
+	"snapshot.mode":             true,
+	"snapshot.locking.mode":     true,
+	"plugin.name":               true,
+	"slot.name":                 true,
+	"publication.name":          true,
+	"mongodb.connection.string": true,
+	"schema.history.internal.kafka.bootstrap.servers": true,
+	"schema.history.internal.kafka.topic":             true,
+}
+
+// configDocsURL returns the reference documentation of a config key of connectorClass, or ""
+// when the class or key is not recognized.
+func configDocsURL(connectorClass, key string) string {
+	page, ok := connectorDocsPages[connectorClass]
+	if !ok || !documentedConfigKeys[key] {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s.html#%s-property-%s", debeziumDocsBase, page, page, strings.ReplaceAll(key, ".", "-"))
+}
+
+// withConfigDocsHint appends the documentation reference of key to a validation message.
+func withConfigDocsHint(connectorClass, key, msg string) string {
+	if url := configDocsURL(connectorClass, key); url != "" {
+		return fmt.Sprintf("%s (see %s)", msg, url)
+	}
+	return msg
+}
@@ -0,0 +1,35 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config documentation hints", func() {
+	ctx := context.Background()
+
+	It("links recognized keys of known connector classes", func() {
+		Expect(configDocsURL("io.debezium.connector.mysql.MySqlConnector", "database.hostname")).
+			To(Equal("https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-property-database-hostname"))
+		Expect(configDocsURL("io.debezium.connector.postgresql.PostgresConnector", "slot.name")).
+			To(Equal("https://debezium.io/documentation/reference/stable/connectors/postgresql.html#postgresql-property-slot-name"))
+		Expect(configDocsURL("io.debezium.connector.mysql.MySqlConnector", "tasks.max")).To(BeEmpty())
+		Expect(configDocsURL("com.example.CustomConnector", "database.hostname")).To(BeEmpty())
+	})
+
+	It("appends the hint to remote validation errors for recognized keys only", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"errors":{"database.hostname":"Unable to connect","custom.key":"Unknown value"}}`))
+		}))
+		defer server.Close()
+
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).To(MatchError(ContainSubstring("Unable to connect (see https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-property-database-hostname)")))
+		Expect(err).To(MatchError(ContainSubstring("Unknown value")))
+		Expect(err).NotTo(MatchError(ContainSubstring("custom-key")))
+	})
+})
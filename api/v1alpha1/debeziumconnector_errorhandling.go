@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Connect config keys set by ErrorHandlingSpec.
+const (
+	errorsToleranceKey          = "errors.tolerance"
+	errorsRetryTimeoutKey       = "errors.retry.timeout"
+	errorsRetryDelayMaxKey      = "errors.retry.delay.max.ms"
+	errorsLogEnableKey          = "errors.log.enable"
+	errorsLogIncludeMessagesKey = "errors.log.include.messages"
+	dlqTopicNameKey             = "errors.deadletterqueue.topic.name"
+	dlqReplicationFactorKey     = "errors.deadletterqueue.topic.replication.factor"
+	dlqContextHeadersEnableKey  = "errors.deadletterqueue.context.headers.enable"
+)
+
+// ConfigKeys expands the error handling settings into Connect config keys.
+func (e *ErrorHandlingSpec) ConfigKeys() map[string]string {
+	config := map[string]string{}
+	if e == nil {
+		return config
+	}
+	if e.Tolerance != "" {
+		config[errorsToleranceKey] = e.Tolerance
+	}
+	if e.RetryTimeoutMillis != nil {
+		config[errorsRetryTimeoutKey] = strconv.FormatInt(*e.RetryTimeoutMillis, 10)
+	}
+	if e.RetryDelayMaxMillis != nil {
+		config[errorsRetryDelayMaxKey] = strconv.FormatInt(*e.RetryDelayMaxMillis, 10)
+	}
+	if e.LogErrors {
+		config[errorsLogEnableKey] = "true"
+	}
+	if e.LogMessages {
+		config[errorsLogIncludeMessagesKey] = "true"
+	}
+	if dlq := e.DeadLetterQueue; dlq != nil {
+		config[dlqTopicNameKey] = dlq.Topic
+		if dlq.ReplicationFactor > 0 {
+			config[dlqReplicationFactorKey] = strconv.Itoa(int(dlq.ReplicationFactor))
+		}
+		if dlq.ContextHeaders {
+			config[dlqContextHeadersEnableKey] = "true"
+		}
+	}
+	return config
+}
+
+// validateErrorHandling rejects raw config keys that contradict Spec.ErrorHandling, and a
+// tolerance of "all" without a dead letter queue topic in the merged config.
+func (r *DebeziumConnector) validateErrorHandling(config map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	e := r.Spec.ErrorHandling
+	if e == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("errorHandling")
+
+	expanded := e.ConfigKeys()
+	keys := make([]string, 0, len(expanded))
+	for key := range expanded {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if v, ok := config[key]; ok && v != expanded[key] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), v,
+				"conflicts with spec.errorHandling, which sets it to \""+expanded[key]+"\""))
+		}
+	}
+
+	if e.Tolerance == "all" && config[dlqTopicNameKey] == "" {
+		allErrs = append(allErrs, field.Required(path.Child("deadLetterQueue").Child("topic"),
+			"a dead letter queue topic is required when tolerance is \"all\""))
+	}
+	return allErrs
+}
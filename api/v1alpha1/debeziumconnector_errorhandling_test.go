@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Error handling", func() {
+	ctx := context.Background()
+
+	It("expands into Connect config keys", func() {
+		retryTimeout := int64(-1)
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ErrorHandling = &ErrorHandlingSpec{
+			Tolerance:          "all",
+			RetryTimeoutMillis: &retryTimeout,
+			LogErrors:          true,
+			DeadLetterQueue:    &DeadLetterQueueSpec{Topic: "inventory-dlq", ReplicationFactor: 3, ContextHeaders: true},
+		}
+		config, errs := dbc.DesiredConfig()
+		Expect(errs).To(BeEmpty())
+		Expect(config).To(HaveKeyWithValue("errors.tolerance", "all"))
+		Expect(config).To(HaveKeyWithValue("errors.retry.timeout", "-1"))
+		Expect(config).To(HaveKeyWithValue("errors.log.enable", "true"))
+		Expect(config).To(HaveKeyWithValue("errors.deadletterqueue.topic.name", "inventory-dlq"))
+		Expect(config).To(HaveKeyWithValue("errors.deadletterqueue.topic.replication.factor", "3"))
+		Expect(config).To(HaveKeyWithValue("errors.deadletterqueue.context.headers.enable", "true"))
+		Expect(config).NotTo(HaveKey("errors.log.include.messages"))
+	})
+
+	It("requires a dead letter queue topic when tolerance is all", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ErrorHandling = &ErrorHandlingSpec{Tolerance: "all"}
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.errorHandling.deadLetterQueue.topic")))
+	})
+
+	It("accepts a dead letter queue topic set through raw config", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ErrorHandling = &ErrorHandlingSpec{Tolerance: "all"}
+		dbc.Spec.Config["errors.deadletterqueue.topic.name"] = "inventory-dlq"
+		config, _ := dbc.DesiredConfig()
+		Expect(dbc.validateErrorHandling(config)).To(BeEmpty())
+	})
+
+	It("rejects raw config keys that contradict errorHandling", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ErrorHandling = &ErrorHandlingSpec{Tolerance: "none"}
+		dbc.Spec.Config["errors.tolerance"] = "all"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring(`spec.config.errors.tolerance: Invalid value: "all": conflicts with spec.errorHandling`)))
+	})
+})
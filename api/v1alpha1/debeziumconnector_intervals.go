@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultReconcileInterval is the requeue interval after a successful reconcile when
+// Spec.ReconcileIntervalSeconds is unset.
+const DefaultReconcileInterval = 60 * time.Second
+
+// ReconcileInterval returns how long to wait before polling the connector after a successful reconcile.
+func (r *DebeziumConnector) ReconcileInterval() time.Duration {
+	if r.Spec.ReconcileIntervalSeconds > 0 {
+		return time.Duration(r.Spec.ReconcileIntervalSeconds) * time.Second
+	}
+	return DefaultReconcileInterval
+}
+
+// MaxReconcileInterval returns the interval up to which polling a stable connector backs off, or
+// zero when the interval does not grow.
+func (r *DebeziumConnector) MaxReconcileInterval() time.Duration {
+	return time.Duration(r.Spec.MaxReconcileIntervalSeconds) * time.Second
+}
+
+// RetryInterval returns how long to wait before retrying a failed reconcile, or zero when
+// failures should use the controller's exponential backoff.
+func (r *DebeziumConnector) RetryInterval() time.Duration {
+	return time.Duration(r.Spec.RetryIntervalSeconds) * time.Second
+}
+
+// validateIntervals checks that the requeue intervals are positive, that failures are not
+// retried less often than successes are polled, and that the polling backoff does not shrink the
+// interval.
+func (r *DebeziumConnector) validateIntervals() field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	if r.Spec.ReconcileIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("reconcileIntervalSeconds"), r.Spec.ReconcileIntervalSeconds, "must be positive"))
+	}
+	if r.Spec.MaxReconcileIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxReconcileIntervalSeconds"), r.Spec.MaxReconcileIntervalSeconds, "must be positive"))
+	}
+	if r.Spec.RetryIntervalSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("retryIntervalSeconds"), r.Spec.RetryIntervalSeconds, "must be positive"))
+	}
+	if len(allErrs) == 0 && r.RetryInterval() > r.ReconcileInterval() {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("retryIntervalSeconds"), r.Spec.RetryIntervalSeconds,
+			"must not exceed the reconcile interval"))
+	}
+	if len(allErrs) == 0 && r.Spec.MaxReconcileIntervalSeconds > 0 && r.MaxReconcileInterval() < r.ReconcileInterval() {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxReconcileIntervalSeconds"), r.Spec.MaxReconcileIntervalSeconds,
+			"must not be less than the reconcile interval"))
+	}
+	return allErrs
+}
+
+// ReconcileInterval returns how long to wait before polling the connectors of the set again
+// after a successful reconcile.
+func (r *DebeziumConnectorSet) ReconcileInterval() time.Duration {
+	if r.Spec.ReconcileIntervalSeconds > 0 {
+		return time.Duration(r.Spec.ReconcileIntervalSeconds) * time.Second
+	}
+	return DefaultReconcileInterval
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Requeue intervals", func() {
+	ctx := context.Background()
+
+	It("defaults the reconcile interval", func() {
+		dbc := newTestConnector("http://unused")
+		Expect(dbc.ReconcileInterval()).To(Equal(DefaultReconcileInterval))
+		Expect(dbc.RetryInterval()).To(BeZero())
+	})
+
+	It("rejects negative intervals", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.RetryIntervalSeconds = -1
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.retryIntervalSeconds")))
+	})
+
+	It("rejects a retry interval above the reconcile interval", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ReconcileIntervalSeconds = 30
+		dbc.Spec.RetryIntervalSeconds = 120
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("must not exceed the reconcile interval")))
+	})
+
+	It("rejects a max reconcile interval below the reconcile interval", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.ReconcileIntervalSeconds = 120
+		dbc.Spec.MaxReconcileIntervalSeconds = 60
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.maxReconcileIntervalSeconds")))
+	})
+})
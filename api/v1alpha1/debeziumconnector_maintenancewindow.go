@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// windowDays maps the day names of MaintenanceWindow.Days to weekdays.
+var windowDays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// parseTimeOfDay parses HH:MM into hours and minutes.
+func parseTimeOfDay(s string) (int, int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("must be a time of day as HH:MM")
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// Open reports whether the window is open at now. When it is not, it also returns the time the
+// window opens next.
+func (w *MaintenanceWindow) Open(now time.Time) (bool, time.Time, error) {
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+	}
+	startHour, startMinute, err := parseTimeOfDay(w.Start)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid start %q: %w", w.Start, err)
+	}
+	endHour, endMinute, err := parseTimeOfDay(w.End)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid end %q: %w", w.End, err)
+	}
+	days := map[time.Weekday]bool{}
+	for _, day := range w.Days {
+		weekday, ok := windowDays[day]
+		if !ok {
+			return false, time.Time{}, fmt.Errorf("invalid day %q", day)
+		}
+		days[weekday] = true
+	}
+
+	// A window that opened yesterday may still be open; the next one opens within a week.
+	local := now.In(loc)
+	for offset := -1; offset <= 7; offset++ {
+		day := local.AddDate(0, 0, offset)
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, loc)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !now.Before(start) && now.Before(end) {
+			return true, time.Time{}, nil
+		}
+		if start.After(now) {
+			return false, start, nil
+		}
+	}
+	return false, time.Time{}, fmt.Errorf("window never opens")
+}
+
+// validateMaintenanceWindow checks that the maintenance window can be scheduled.
+func (r *DebeziumConnector) validateMaintenanceWindow() field.ErrorList {
+	w := r.Spec.MaintenanceWindow
+	if w == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "maintenanceWindow")
+	for i, day := range w.Days {
+		if _, ok := windowDays[day]; !ok {
+			allErrs = append(allErrs, field.NotSupported(path.Child("days").Index(i), day,
+				[]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}))
+		}
+	}
+	if _, _, err := parseTimeOfDay(w.Start); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("start"), w.Start, err.Error()))
+	}
+	if _, _, err := parseTimeOfDay(w.End); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("end"), w.End, err.Error()))
+	} else if w.End == w.Start {
+		allErrs = append(allErrs, field.Invalid(path.Child("end"), w.End, "must differ from start"))
+	}
+	if _, err := time.LoadLocation(w.TimeZone); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), w.TimeZone, "must be an IANA time zone name"))
+	}
+	return allErrs
+}
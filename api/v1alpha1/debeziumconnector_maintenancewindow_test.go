@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Maintenance windows", func() {
+	ctx := context.Background()
+	// 2024-01-01 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	DescribeTable("schedules the window",
+		func(window MaintenanceWindow, now time.Time, open bool, next time.Time) {
+			isOpen, nextStart, err := window.Open(now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isOpen).To(Equal(open))
+			Expect(nextStart.Equal(next)).To(BeTrue(), "next start %s", nextStart)
+		},
+		Entry("inside a daily window", MaintenanceWindow{Start: "01:00", End: "05:00"}, at(1, 3, 0), true, time.Time{}),
+		Entry("at the start of a window", MaintenanceWindow{Start: "01:00", End: "05:00"}, at(1, 1, 0), true, time.Time{}),
+		Entry("at the end of a window", MaintenanceWindow{Start: "01:00", End: "05:00"}, at(1, 5, 0), false, at(2, 1, 0)),
+		Entry("before a daily window", MaintenanceWindow{Start: "01:00", End: "05:00"}, at(1, 0, 30), false, at(1, 1, 0)),
+		Entry("inside a window spanning midnight", MaintenanceWindow{Start: "22:00", End: "02:00"}, at(2, 1, 0), true, time.Time{}),
+		Entry("after a window spanning midnight", MaintenanceWindow{Start: "22:00", End: "02:00"}, at(2, 3, 0), false, at(2, 22, 0)),
+		Entry("on a day without a window", MaintenanceWindow{Days: []string{"Sat", "Sun"}, Start: "00:00", End: "23:59"}, at(3, 12, 0), false, at(6, 0, 0)),
+		Entry("on the day after a window spanning midnight", MaintenanceWindow{Days: []string{"Sun"}, Start: "23:00", End: "01:00"}, at(8, 0, 30), true, time.Time{}),
+		Entry("next week", MaintenanceWindow{Days: []string{"Mon"}, Start: "01:00", End: "02:00"}, at(1, 3, 0), false, at(8, 1, 0)),
+		Entry("in another time zone", MaintenanceWindow{Start: "02:00", End: "04:00", TimeZone: "Europe/Berlin"}, at(1, 1, 30), true, time.Time{}),
+	)
+
+	It("rejects windows that cannot be scheduled", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.MaintenanceWindow = &MaintenanceWindow{Days: []string{"Monday"}, Start: "25:00", End: "02:00", TimeZone: "Mars/Olympus"}
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.maintenanceWindow.days[0]")))
+		Expect(err).To(MatchError(ContainSubstring("spec.maintenanceWindow.start")))
+		Expect(err).To(MatchError(ContainSubstring("spec.maintenanceWindow.timeZone")))
+
+		dbc.Spec.MaintenanceWindow = &MaintenanceWindow{Start: "02:00", End: "02:00"}
+		_, err = (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("must differ from start")))
+	})
+
+	It("accepts a valid window", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.MaintenanceWindow = &MaintenanceWindow{Days: []string{"Sat", "Sun"}, Start: "22:00", End: "04:00", TimeZone: "America/New_York"}
+		Expect(dbc.validateMaintenanceWindow()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// regexListKeys are the config keys, by connector class, whose values are comma-separated lists
+// of regular expressions matched against fully qualified identifiers.
+var regexListKeys = map[string][]string{
+	mySQLConnectorClass:     {"database.include.list", "database.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	mariaDBConnectorClass:   {"database.include.list", "database.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	postgresConnectorClass:  {"schema.include.list", "schema.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	sqlServerConnectorClass: {"schema.include.list", "schema.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	oracleConnectorClass:    {"schema.include.list", "schema.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	db2ConnectorClass:       {"schema.include.list", "schema.exclude.list", "table.include.list", "table.exclude.list", "column.include.list", "column.exclude.list"},
+	mongoDBConnectorClass:   {"database.include.list", "database.exclude.list", "collection.include.list", "collection.exclude.list"},
+}
+
+// javaInvalidRegexErrors are the parse errors of Go's regexp that Java's Pattern reports as well.
+// Other errors, e.g. for lookarounds, backreferences or possessive quantifiers, are Go
+// limitations and not reported, since Debezium compiles the patterns with Java.
+var javaInvalidRegexErrors = map[syntax.ErrorCode]bool{
+	syntax.ErrMissingBracket:        true,
+	syntax.ErrMissingParen:          true,
+	syntax.ErrUnexpectedParen:       true,
+	syntax.ErrInvalidCharRange:      true,
+	syntax.ErrMissingRepeatArgument: true,
+	syntax.ErrTrailingBackslash:     true,
+	syntax.ErrInvalidRepeatSize:     true,
+}
+
+// validateRegexLists compiles every pattern of the regex-valued include and exclude lists of the
+// config's connector class and reports those that cannot be valid Java regular expressions.
+func validateRegexLists(config map[string]string) field.ErrorList {
+	keys := regexListKeys[config["connector.class"]]
+	var allErrs field.ErrorList
+	for _, key := range keys {
+		value, ok := config[key]
+		if !ok {
+			continue
+		}
+		var invalid []string
+		for _, pattern := range strings.Split(value, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			_, err := syntax.Parse(pattern, syntax.Perl)
+			var syntaxErr *syntax.Error
+			if errors.As(err, &syntaxErr) && javaInvalidRegexErrors[syntaxErr.Code] {
+				invalid = append(invalid, fmt.Sprintf("%q: %s", pattern, syntaxErr.Code))
+			}
+		}
+		if len(invalid) == 0 {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), value,
+			"invalid regular expression "+strings.Join(invalid, "; ")))
+	}
+	return allErrs
+}
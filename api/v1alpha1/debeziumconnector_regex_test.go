@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Regex list validation", func() {
+	It("accepts valid patterns, including Java-only syntax", func() {
+		Expect(validateRegexLists(map[string]string{
+			"connector.class":       mySQLConnectorClass,
+			"table.include.list":    `inventory\.orders, inventory\.customers_[0-9]+`,
+			"column.exclude.list":   `inventory\.(?!orders).*\.secret`,
+			"database.exclude.list": `a++`,
+		})).To(BeEmpty())
+	})
+
+	It("reports each malformed pattern of a key", func() {
+		errs := validateRegexLists(map[string]string{
+			"connector.class":     postgresConnectorClass,
+			"table.include.list":  `public\.(orders, public\.items, public\.[a-`,
+			"schema.exclude.list": `*`,
+		})
+		Expect(errs).To(HaveLen(2))
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring(`spec.config.table.include.list`),
+			ContainSubstring(`"public\\.(orders": missing closing )`),
+			ContainSubstring(`"public\\.[a-": missing closing ]`),
+			ContainSubstring(`spec.config.schema.exclude.list`),
+			ContainSubstring(`"*": missing argument to repetition operator`),
+		))
+	})
+
+	It("only checks the regex-valued keys of the connector class", func() {
+		Expect(validateRegexLists(map[string]string{
+			"connector.class":       postgresConnectorClass,
+			"database.include.list": `(`,
+		})).To(BeEmpty())
+		Expect(validateRegexLists(map[string]string{
+			"connector.class":    "com.example.CustomConnector",
+			"table.include.list": `(`,
+		})).To(BeEmpty())
+	})
+
+	It("rejects malformed patterns before calling the Debezium host", func() {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+			_, _ = w.Write([]byte(`{"errors":{}}`))
+		}))
+		defer server.Close()
+
+		dbc := newTestConnector(server.URL)
+		dbc.Spec.Config["table.include.list"] = `inventory\.(orders`
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(context.Background(), dbc)
+		Expect(err).To(MatchError(ContainSubstring("invalid regular expression")))
+		Expect(called).To(BeFalse())
+	})
+})
@@ -0,0 +1,130 @@
+package v1alpha1
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// bundledSchemas holds the config schemas of common Debezium connectors, used to validate
+// configs when the Debezium validate endpoint is not called.
+//
+//go:embed schemas/*.yaml
+var bundledSchemas embed.FS
+
+// connectorSchema describes the config properties of a connector class.
+type connectorSchema struct {
+	// ConnectorClass is the connector.class the schema applies to.
+	ConnectorClass string `json:"connectorClass"`
+	// Properties are the known config properties by key. Keys not listed are not checked.
+	Properties map[string]configPropertySchema `json:"properties"`
+}
+
+// configPropertySchema describes a single config property.
+type configPropertySchema struct {
+	// Type is one of string, int, long or boolean.
+	Type string `json:"type"`
+	// Required rejects configs that do not set the property.
+	Required bool `json:"required,omitempty"`
+	// Values restricts the property to these values; any value is allowed when empty.
+	Values []string `json:"values,omitempty"`
+}
+
+var (
+	offlineSchemasOnce sync.Once
+	offlineSchemas     map[string]connectorSchema
+	offlineSchemasErr  error
+)
+
+// loadOfflineSchemas parses the bundled schemas once, keyed by connector class.
+func loadOfflineSchemas() (map[string]connectorSchema, error) {
+	offlineSchemasOnce.Do(func() {
+		offlineSchemas, offlineSchemasErr = parseSchemas(bundledSchemas)
+	})
+	return offlineSchemas, offlineSchemasErr
+}
+
+// parseSchemas reads every schema file of fsys, keyed by connector class.
+func parseSchemas(fsys fs.FS) (map[string]connectorSchema, error) {
+	paths, err := fs.Glob(fsys, "schemas/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]connectorSchema, len(paths))
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		var schema connectorSchema
+		if err := yaml.UnmarshalStrict(data, &schema); err != nil {
+			return nil, fmt.Errorf("failed to parse config schema %s: %w", path, err)
+		}
+		if schema.ConnectorClass == "" {
+			return nil, fmt.Errorf("config schema %s: connectorClass is required", path)
+		}
+		schemas[schema.ConnectorClass] = schema
+	}
+	return schemas, nil
+}
+
+// validateOffline checks config against the bundled schema of connectorClass and returns the
+// errors by config key, in the form of the Debezium validate endpoint. ok is false when no
+// schema is bundled for the class.
+func validateOffline(connectorClass string, config map[string]string) (errs map[string]string, ok bool, err error) {
+	schemas, err := loadOfflineSchemas()
+	if err != nil {
+		return nil, false, err
+	}
+	schema, ok := schemas[connectorClass]
+	if !ok {
+		return nil, false, nil
+	}
+	return schema.validate(config), true, nil
+}
+
+// validate returns the errors of config by config key.
+func (s connectorSchema) validate(config map[string]string) map[string]string {
+	errs := map[string]string{}
+	for key, property := range s.Properties {
+		value, set := config[key]
+		if !set {
+			if property.Required {
+				errs[key] = fmt.Sprintf("The '%s' value is invalid: A value is required", key)
+			}
+			continue
+		}
+		if msg := property.check(value); msg != "" {
+			errs[key] = fmt.Sprintf("Invalid value %s for configuration %s: %s", value, key, msg)
+		}
+	}
+	return errs
+}
+
+// check returns why value does not match the property, or "" when it does.
+func (p configPropertySchema) check(value string) string {
+	switch p.Type {
+	case "int":
+		if _, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32); err != nil {
+			return "Not a number of type INT"
+		}
+	case "long":
+		if _, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil {
+			return "Not a number of type LONG"
+		}
+	case "boolean":
+		if v := strings.ToLower(strings.TrimSpace(value)); v != "true" && v != "false" {
+			return "Expected value to be either true or false"
+		}
+	}
+	if len(p.Values) > 0 && !slices.Contains(p.Values, strings.ToLower(strings.TrimSpace(value))) {
+		return fmt.Sprintf("Value must be one of %s", strings.Join(p.Values, ", "))
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Offline config validation", func() {
+	ctx := context.Background()
+	// An unreachable host makes any remote call fail the test.
+	const unreachableHost = "http://127.0.0.1:1"
+
+	offlineValidator := func() *DebeziumConnectorValidator {
+		return &DebeziumConnectorValidator{DisableRemoteValidation: true}
+	}
+
+	It("bundles a schema for common connector classes", func() {
+		schemas, err := loadOfflineSchemas()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schemas).To(HaveKey(mySQLConnectorClass))
+		Expect(schemas).To(HaveKey(postgresConnectorClass))
+		Expect(schemas).To(HaveKey(sqlServerConnectorClass))
+	})
+
+	It("admits a valid MySQL config without calling the Debezium host", func() {
+		dbc := newTestConnector(unreachableHost)
+		dbc.Spec.Config["database.hostname"] = "mysql"
+		dbc.Spec.Config["database.user"] = "debezium"
+		dbc.Spec.Config["database.port"] = "3306"
+		dbc.Spec.Config["snapshot.mode"] = "when_needed"
+		dbc.Spec.Config["custom.key"] = "anything"
+
+		warnings, err := offlineValidator().ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("rejects missing required keys of a MySQL config", func() {
+		_, err := offlineValidator().ValidateCreate(ctx, newTestConnector(unreachableHost))
+		Expect(err).To(MatchError(ContainSubstring("The 'database.hostname' value is invalid: A value is required")))
+		Expect(err).To(MatchError(ContainSubstring("database.user")))
+	})
+
+	It("rejects mistyped and unknown enum values of a PostgreSQL config", func() {
+		dbc := newTestConnector(unreachableHost)
+		dbc.Spec.Config["connector.class"] = postgresConnectorClass
+		dbc.Spec.Config["database.hostname"] = "postgres"
+		dbc.Spec.Config["database.user"] = "debezium"
+		dbc.Spec.Config["database.dbname"] = "inventory"
+		dbc.Spec.Config["database.port"] = "five"
+		dbc.Spec.Config["plugin.name"] = "wal3json"
+		dbc.Spec.Config["slot.drop.on.stop"] = "TRUE"
+
+		_, err := offlineValidator().ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("Invalid value five for configuration database.port: Not a number of type INT")))
+		Expect(err).To(MatchError(ContainSubstring("Value must be one of decoderbufs, pgoutput")))
+		Expect(err).NotTo(MatchError(ContainSubstring("slot.drop.on.stop")))
+		// Offline errors link the reference documentation like remote ones.
+		Expect(err).To(MatchError(ContainSubstring("postgresql.html#postgresql-property-plugin-name")))
+	})
+
+	It("admits connectors without a bundled schema with a warning", func() {
+		dbc := newTestConnector(unreachableHost)
+		dbc.Spec.Config["connector.class"] = "com.example.CustomConnector"
+
+		warnings, err := offlineValidator().ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("no bundled config schema for com.example.CustomConnector")))
+	})
+
+	It("rejects malformed schema files", func() {
+		_, err := parseSchemas(fstest.MapFS{
+			"schemas/broken.yaml": {Data: []byte("connectorClass: x\nproperties:\n  a:\n    typ: int\n")},
+		})
+		Expect(err).To(MatchError(ContainSubstring("schemas/broken.yaml")))
+
+		_, err = parseSchemas(fstest.MapFS{
+			"schemas/anonymous.yaml": {Data: []byte("properties: {}\n")},
+		})
+		Expect(err).To(MatchError(ContainSubstring("connectorClass is required")))
+	})
+})
@@ -0,0 +1,45 @@
+package v1alpha1
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	topicPrefixKey        = "topic.prefix"
+	legacyTopicPrefixKey  = "database.server.name"
+	topicPrefixMigrateMsg = "database.server.name was renamed to topic.prefix in Debezium 2.0"
+)
+
+// topicPrefixConnectorClasses are the connector classes that name their topics after
+// topic.prefix, or database.server.name before Debezium 2.0.
+var topicPrefixConnectorClasses = []string{
+	mySQLConnectorClass,
+	mariaDBConnectorClass,
+	postgresConnectorClass,
+	sqlServerConnectorClass,
+	oracleConnectorClass,
+	db2ConnectorClass,
+	mongoDBConnectorClass,
+}
+
+// validateTopicPrefix requires connectors of topicPrefixConnectorClasses to set exactly one of
+// topic.prefix and database.server.name.
+func validateTopicPrefix(config map[string]string) field.ErrorList {
+	if !slices.Contains(topicPrefixConnectorClasses, config["connector.class"]) {
+		return nil
+	}
+	path := field.NewPath("spec").Child("config")
+	_, hasPrefix := config[topicPrefixKey]
+	_, hasLegacy := config[legacyTopicPrefixKey]
+	switch {
+	case hasPrefix && hasLegacy:
+		return field.ErrorList{field.Forbidden(path.Child(legacyTopicPrefixKey),
+			topicPrefixMigrateMsg+"; remove database.server.name and keep topic.prefix")}
+	case !hasPrefix && !hasLegacy:
+		return field.ErrorList{field.Required(path.Child(topicPrefixKey),
+			"config must set topic.prefix, or database.server.name on Debezium before 2.0")}
+	}
+	return nil
+}
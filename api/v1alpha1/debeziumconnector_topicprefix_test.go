@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topic prefix", func() {
+	ctx := context.Background()
+
+	It("accepts topic.prefix or database.server.name", func() {
+		Expect(validateTopicPrefix(map[string]string{"connector.class": mySQLConnectorClass, "topic.prefix": "shop"})).To(BeEmpty())
+		Expect(validateTopicPrefix(map[string]string{"connector.class": postgresConnectorClass, "database.server.name": "shop"})).To(BeEmpty())
+	})
+
+	It("rejects both keys with a migration message", func() {
+		dbc := newTestConnector("http://unused")
+		dbc.Spec.Config["database.server.name"] = "inventory"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.database.server.name: Forbidden: database.server.name was renamed to topic.prefix in Debezium 2.0")))
+	})
+
+	It("requires one of the keys", func() {
+		dbc := newTestConnector("http://unused")
+		delete(dbc.Spec.Config, "topic.prefix")
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.topic.prefix: Required value")))
+	})
+
+	It("only checks the listed connector classes", func() {
+		Expect(validateTopicPrefix(map[string]string{"connector.class": "com.example.CustomConnector"})).To(BeEmpty())
+		Expect(validateTopicPrefix(map[string]string{"connector.class": "com.example.CustomConnector", "topic.prefix": "a", "database.server.name": "b"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// aliasList returns the aliases of a comma-separated transforms or predicates list and the
+// aliases listed more than once.
+func aliasList(value string) (aliases, duplicates []string) {
+	seen := map[string]bool{}
+	for _, alias := range strings.Split(value, ",") {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		if seen[alias] {
+			duplicates = append(duplicates, alias)
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	return aliases, duplicates
+}
+
+// ValidateTransformChain checks that the single message transforms and predicates of config refer
+// to each other consistently: every listed transform and predicate has a type, no alias is listed
+// twice, and every predicate a transform refers to is listed in "predicates". Connect replaces the
+// whole config at once, so a config that fails these checks is never sent, e.g. when a transform
+// and its predicate are renamed in one change and only one of them was updated.
+func ValidateTransformChain(config map[string]string) field.ErrorList {
+	configPath := field.NewPath("spec").Child("config")
+	var allErrs field.ErrorList
+
+	predicates := map[string]bool{}
+	aliases, duplicates := aliasList(config["predicates"])
+	for _, alias := range duplicates {
+		allErrs = append(allErrs, field.Duplicate(configPath.Child("predicates"), alias))
+	}
+	for _, alias := range aliases {
+		predicates[alias] = true
+		if strings.TrimSpace(config["predicates."+alias+".type"]) == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("predicates."+alias+".type"),
+				fmt.Sprintf("predicate %q is listed in predicates but has no type", alias)))
+		}
+	}
+
+	aliases, duplicates = aliasList(config["transforms"])
+	for _, alias := range duplicates {
+		allErrs = append(allErrs, field.Duplicate(configPath.Child("transforms"), alias))
+	}
+	for _, alias := range aliases {
+		if strings.TrimSpace(config["transforms."+alias+".type"]) == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("transforms."+alias+".type"),
+				fmt.Sprintf("transform %q is listed in transforms but has no type", alias)))
+		}
+		key := "transforms." + alias + ".predicate"
+		predicate, ok := config[key]
+		if !ok {
+			continue
+		}
+		predicate = strings.TrimSpace(predicate)
+		if predicates[predicate] {
+			continue
+		}
+		msg := fmt.Sprintf("transform %q refers to predicate %q, which is not listed in predicates", alias, predicate)
+		if len(predicates) > 0 {
+			defined := make([]string, 0, len(predicates))
+			for name := range predicates {
+				defined = append(defined, name)
+			}
+			sort.Strings(defined)
+			msg += fmt.Sprintf(" (%s)", strings.Join(defined, ", "))
+		}
+		allErrs = append(allErrs, field.Invalid(configPath.Child(key), config[key], msg))
+	}
+	return allErrs
+}
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transform chain validation", func() {
+	It("accepts transforms and predicates that refer to each other", func() {
+		Expect(ValidateTransformChain(map[string]string{
+			"transforms":                 "unwrap, route",
+			"transforms.unwrap.type":     "io.debezium.transforms.ExtractNewRecordState",
+			"transforms.route.type":      "org.apache.kafka.connect.transforms.RegexRouter",
+			"transforms.route.predicate": "isOrders",
+			"transforms.route.negate":    "true",
+			"predicates":                 "isOrders",
+			"predicates.isOrders.type":   "org.apache.kafka.connect.transforms.predicates.TopicNameMatches",
+		})).To(BeEmpty())
+		Expect(ValidateTransformChain(map[string]string{"name": "inventory"})).To(BeEmpty())
+	})
+
+	It("rejects a transform that refers to an undefined predicate", func() {
+		errs := ValidateTransformChain(map[string]string{
+			"transforms":                  "route",
+			"transforms.route.type":       "org.apache.kafka.connect.transforms.RegexRouter",
+			"transforms.route.predicate":  "isOrder",
+			"predicates":                  "isOrders,isTombstone",
+			"predicates.isOrders.type":    "org.apache.kafka.connect.transforms.predicates.TopicNameMatches",
+			"predicates.isTombstone.type": "org.apache.kafka.connect.transforms.predicates.RecordIsTombstone",
+		})
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.config.transforms.route.predicate"))
+		Expect(errs[0].Detail).To(Equal(`transform "route" refers to predicate "isOrder", which is not listed in predicates (isOrders, isTombstone)`))
+	})
+
+	It("rejects a predicate reference when no predicates are listed", func() {
+		errs := ValidateTransformChain(map[string]string{
+			"transforms":                 "route",
+			"transforms.route.type":      "org.apache.kafka.connect.transforms.RegexRouter",
+			"transforms.route.predicate": "isOrders",
+			"predicates.isOrders.type":   "org.apache.kafka.connect.transforms.predicates.TopicNameMatches",
+		})
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Detail).To(Equal(`transform "route" refers to predicate "isOrders", which is not listed in predicates`))
+	})
+
+	It("rejects listed transforms and predicates without a type", func() {
+		errs := ValidateTransformChain(map[string]string{
+			"transforms":                  "unwrap",
+			"transforms.unwrap.predicate": "isOrders",
+			"predicates":                  "isOrders",
+		})
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring("spec.config.transforms.unwrap.type"),
+			ContainSubstring("spec.config.predicates.isOrders.type"),
+		))
+		Expect(errs).To(HaveLen(2))
+	})
+
+	It("rejects aliases listed twice", func() {
+		errs := ValidateTransformChain(map[string]string{
+			"transforms":               "route,route",
+			"transforms.route.type":    "org.apache.kafka.connect.transforms.RegexRouter",
+			"predicates":               "isOrders, isOrders",
+			"predicates.isOrders.type": "org.apache.kafka.connect.transforms.predicates.TopicNameMatches",
+		})
+		Expect(errs.ToAggregate().Error()).To(And(
+			ContainSubstring(`spec.config.transforms: Duplicate value: "route"`),
+			ContainSubstring(`spec.config.predicates: Duplicate value: "isOrders"`),
+		))
+	})
+
+	It("rejects inconsistent references before calling the Debezium host", func() {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+			_, _ = w.Write([]byte(`{"errors":{}}`))
+		}))
+		defer server.Close()
+
+		dbc := newTestConnector(server.URL)
+		dbc.Spec.Config["transforms"] = "route"
+		dbc.Spec.Config["transforms.route.type"] = "org.apache.kafka.connect.transforms.RegexRouter"
+		dbc.Spec.Config["transforms.route.predicate"] = "isOrders"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(context.Background(), dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.transforms.route.predicate")))
+		Expect(called).To(BeFalse())
+	})
+})
@@ -17,24 +17,579 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DebeziumConnectorSpec defines the desired state of DebeziumConnector
 type DebeziumConnectorSpec struct {
-	// +kubebuilder:validation:Required
-	DebeziumHost string `json:"debeziumHost"`
-	// +kubebuilder:validation:Required
-	Config map[string]string `json:"config"`
+	// DebeziumHost is the Kafka Connect host the connector runs on. When empty, the host named by
+	// the DefaultHostAnnotation of the resource's namespace is used.
+	// +optional
+	DebeziumHost string `json:"debeziumHost,omitempty"`
+	// ValidationHost is the Kafka Connect host the admission webhook sends the config to for
+	// validation, e.g. a sanctioned validation proxy. Defaults to DebeziumHost.
+	// +optional
+	ValidationHost string `json:"validationHost,omitempty"`
+	// Config holds the connector configuration. Keys set here take precedence over ConfigSecretRef.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+	// ConfigProperties holds connector configuration in the Java properties format, as found in
+	// Debezium .properties files. Keys set in Config take precedence over keys set here.
+	// +optional
+	ConfigProperties string `json:"configProperties,omitempty"`
+	// ConfigSecretRef references a Secret in the same namespace whose keys and values are used as
+	// connector configuration, merged under Config.
+	// +optional
+	ConfigSecretRef *corev1.LocalObjectReference `json:"configSecretRef,omitempty"`
+	// TemplateRefs name DebeziumConnectorTemplates in the same namespace whose config is merged
+	// under ConfigSecretRef and Config. Templates are applied in order, so keys of later templates
+	// take precedence over earlier ones.
+	// +optional
+	TemplateRefs []string `json:"templateRefs,omitempty"`
+	// ReconcileIntervalSeconds is how long to wait before polling the connector again after a
+	// successful reconcile. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReconcileIntervalSeconds int32 `json:"reconcileIntervalSeconds,omitempty"`
+	// MaxReconcileIntervalSeconds enables polling a stable connector less often: while it stays
+	// Available and unchanged, the interval doubles after each reconcile up to this maximum. Any
+	// change or failure resets it to ReconcileIntervalSeconds. Disabled when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReconcileIntervalSeconds int32 `json:"maxReconcileIntervalSeconds,omitempty"`
+	// RetryIntervalSeconds is how long to wait before retrying after a failed reconcile, e.g. when
+	// the Debezium host is unreachable. Failures use exponential backoff when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetryIntervalSeconds int32 `json:"retryIntervalSeconds,omitempty"`
+	// ExportEffectiveConfig writes the resolved config, with sensitive values masked, to the
+	// ConfigMap <name>-effective-config.
+	// +optional
+	ExportEffectiveConfig bool `json:"exportEffectiveConfig,omitempty"`
+	// ErrorHandling configures error tolerance and the dead letter queue. It expands into the
+	// errors.* connector config keys; setting the same keys in Config to other values is rejected.
+	// +optional
+	ErrorHandling *ErrorHandlingSpec `json:"errorHandling,omitempty"`
+	// ProducerOverrides are Kafka producer properties for this connector, e.g. compression.type.
+	// They expand into producer.override.* config keys, which the Connect workers must allow
+	// through connector.client.config.override.policy. Setting the same keys in Config to other
+	// values is rejected.
+	// +optional
+	ProducerOverrides map[string]string `json:"producerOverrides,omitempty"`
+	// ConsumerOverrides are Kafka consumer properties for this connector, e.g. max.poll.records,
+	// used by sink connectors. They expand into consumer.override.* config keys like
+	// ProducerOverrides.
+	// +optional
+	ConsumerOverrides map[string]string `json:"consumerOverrides,omitempty"`
+	// MigrationPolicy controls what happens to the connector on the previous host when
+	// DebeziumHost changes: Delete (default) removes it once the connector is RUNNING on the new
+	// host, Retain leaves it stopped.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	MigrationPolicy string `json:"migrationPolicy,omitempty"`
+	// UpdateStrategy controls how config changes that require a restart are applied: InPlace
+	// (default) updates the running connector, StopAndResume stops the connector, updates it and
+	// then resumes it. StopAndResume falls back to InPlace on Kafka Connect before 3.5.
+	// +kubebuilder:validation:Enum=InPlace;StopAndResume
+	// +optional
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+	// CircuitBreaker pauses the connector when it fails too often, instead of letting it restart
+	// and load the source database again.
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+	// DesiredState is the state the connector is kept in: Running, Paused or Stopped. A connector
+	// paused, resumed or stopped through the Connect REST API is moved back to it. The state is
+	// not enforced when unset. Stopped requires Kafka Connect 3.5 or later.
+	// +kubebuilder:validation:Enum=Running;Paused;Stopped
+	// +optional
+	DesiredState string `json:"desiredState,omitempty"`
+	// LogLevel is set on the Connect workers for the logger of the connector class's package, e.g.
+	// io.debezium.connector.mysql, without restarting them. The previous level is restored when it
+	// is cleared. Requires Kafka Connect 2.4 or later; before 3.7 only the worker serving the
+	// request is changed.
+	// +kubebuilder:validation:Enum=TRACE;DEBUG;INFO;WARN;ERROR;FATAL;OFF
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+	// MaintenanceWindow restricts creating, updating and deleting the connector on the Debezium
+	// host to a recurring period. Changes made outside of it are deferred to the next window,
+	// while the connector status is still reported. Changes are applied at any time when unset.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// ConflictPolicy controls what happens when the config on the Debezium host was changed
+	// outside the operator since it was last observed and differs from the desired config:
+	// Overwrite (default) replaces it with the desired config, Refuse leaves it in place until
+	// the conflict is resolved. Both report the ConflictDetected condition.
+	// +kubebuilder:validation:Enum=Overwrite;Refuse
+	// +optional
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+	// DeleteTopicsOnRemoval deletes the connector's internal Debezium topics from Kafka once the
+	// connector was removed from the Debezium host: the schema history topic, the signal topic
+	// and the schema change topic. Topics another DebeziumConnector still refers to, and the
+	// topics holding captured data, are never deleted. Requires the operator to be started with
+	// --kafka-bootstrap-servers.
+	// +optional
+	DeleteTopicsOnRemoval bool `json:"deleteTopicsOnRemoval,omitempty"`
+	// RetainConfigOnRemoval saves the connector's config on the Debezium host before the
+	// connector is deleted, so it can be recreated or audited later: ConfigMap stores it with
+	// sensitive values masked in the ConfigMap <name>-final-config, Secret stores it unmasked in
+	// the Secret <name>-final-config, and Event emits it masked in an event. The ConfigMap and
+	// Secret are not owned by the resource, so they outlive it.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret;Event
+	// +optional
+	RetainConfigOnRemoval string `json:"retainConfigOnRemoval,omitempty"`
+	// ConfigTemplating evaluates config values as Go templates over the resource's metadata,
+	// e.g. "{{ .Namespace }}-{{ .Name }}", before they are validated and sent to the Debezium
+	// host. Templates can read .Name, .Namespace, .Labels and .Annotations.
+	// +optional
+	ConfigTemplating bool `json:"configTemplating,omitempty"`
+	// NameCollisionPolicy controls what happens when another, older DebeziumConnector already
+	// manages a connector with the same name on the Debezium host: Reject (default) leaves the
+	// connector to the other resource and reports the DuplicateConnector condition, SuffixHash
+	// appends a short hash of the resource's namespace and name to the connector name and
+	// records the result in Status.EffectiveName.
+	// +kubebuilder:validation:Enum=Reject;SuffixHash
+	// +optional
+	NameCollisionPolicy string `json:"nameCollisionPolicy,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of the week in which the operator changes connectors.
+type MaintenanceWindow struct {
+	// Days are the days of the week the window opens on: Mon, Tue, Wed, Thu, Fri, Sat or Sun.
+	// The window opens every day when empty.
+	// +kubebuilder:validation:items:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// Start is the time of day the window opens, as HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// End is the time of day the window closes, as HH:MM. A window that ends before it starts
+	// closes on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+	// TimeZone is the IANA time zone of Start and End, e.g. Europe/Berlin. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// MigrationStatus is the progress of moving the connector from one Debezium host to another.
+type MigrationStatus struct {
+	// From is the host the connector is moved from.
+	From string `json:"from"`
+	// To is the host the connector is moved to.
+	To string `json:"to"`
+	// Phase is the last step completed on the new host: Created when the connector was created
+	// stopped and still needs the offsets of the previous host, Resumed once it was started.
+	// +optional
+	Phase string `json:"phase,omitempty"`
 }
 
+// Migration phases for MigrationStatus.Phase.
+const (
+	MigrationPhaseCreated = "Created"
+	MigrationPhaseResumed = "Resumed"
+)
+
+// Migration policies for Spec.MigrationPolicy.
+const (
+	MigrationPolicyDelete = "Delete"
+	MigrationPolicyRetain = "Retain"
+)
+
+// Update strategies for Spec.UpdateStrategy.
+const (
+	UpdateStrategyInPlace       = "InPlace"
+	UpdateStrategyStopAndResume = "StopAndResume"
+)
+
+// Conflict policies for Spec.ConflictPolicy.
+const (
+	ConflictPolicyOverwrite = "Overwrite"
+	ConflictPolicyRefuse    = "Refuse"
+)
+
+// Name collision policies for Spec.NameCollisionPolicy.
+const (
+	NameCollisionReject     = "Reject"
+	NameCollisionSuffixHash = "SuffixHash"
+)
+
+// Destinations of the final config for Spec.RetainConfigOnRemoval.
+const (
+	RetainConfigConfigMap = "ConfigMap"
+	RetainConfigSecret    = "Secret"
+	RetainConfigEvent     = "Event"
+)
+
+// Desired connector states for Spec.DesiredState.
+const (
+	DesiredStateRunning = "Running"
+	DesiredStatePaused  = "Paused"
+	DesiredStateStopped = "Stopped"
+)
+
+// ErrorHandlingSpec configures how the connector handles record failures.
+type ErrorHandlingSpec struct {
+	// Tolerance is "none" to fail the task on the first error, or "all" to skip failed records.
+	// +kubebuilder:validation:Enum=none;all
+	// +optional
+	Tolerance string `json:"tolerance,omitempty"`
+	// RetryTimeoutMillis is how long a failed operation is retried; -1 retries indefinitely.
+	// +optional
+	RetryTimeoutMillis *int64 `json:"retryTimeoutMillis,omitempty"`
+	// RetryDelayMaxMillis caps the delay between retries.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetryDelayMaxMillis *int64 `json:"retryDelayMaxMillis,omitempty"`
+	// LogErrors logs failed operations and problematic records.
+	// +optional
+	LogErrors bool `json:"logErrors,omitempty"`
+	// LogMessages includes the failed records in the error log.
+	// +optional
+	LogMessages bool `json:"logMessages,omitempty"`
+	// DeadLetterQueue routes failed records to a topic. Required when Tolerance is "all".
+	// +optional
+	DeadLetterQueue *DeadLetterQueueSpec `json:"deadLetterQueue,omitempty"`
+}
+
+// DeadLetterQueueSpec configures the dead letter queue topic.
+type DeadLetterQueueSpec struct {
+	// Topic is the name of the dead letter queue topic.
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+	// ReplicationFactor of the dead letter queue topic when Connect creates it.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+	// ContextHeaders adds headers describing the failure to records in the dead letter queue.
+	// +optional
+	ContextHeaders bool `json:"contextHeaders,omitempty"`
+}
+
+// CircuitBreakerSpec configures when a repeatedly failing connector is paused.
+type CircuitBreakerSpec struct {
+	// MaxFailures is the number of failures within the window that opens the circuit.
+	// +kubebuilder:validation:Minimum=1
+	MaxFailures int32 `json:"maxFailures"`
+	// WindowSeconds is the period over which failures are counted.
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds int32 `json:"windowSeconds"`
+}
+
+// ServiceAccountAnnotation names the service account, in the resource's namespace, whose
+// permissions are used to read Secrets and ConfigMaps referenced by the connector.
+const ServiceAccountAnnotation = "debezium.io/service-account"
+
+// ReconcilePausedAnnotation pauses mutation of the connector when set to "true".
+const ReconcilePausedAnnotation = "debezium.io/reconcile-paused"
+
+// ResetCircuitBreakerAnnotation closes an open circuit and resumes the connector when set to
+// "true". The operator removes the annotation once the circuit is closed.
+const ResetCircuitBreakerAnnotation = "debezium.io/reset-circuit-breaker"
+
+// ForceReplaceAnnotation replaces the whole connector config on the Debezium host, dropping any
+// keys not in the desired config, whenever its value changes. Any new value, e.g. a timestamp,
+// triggers one replacement.
+const ForceReplaceAnnotation = "debezium.io/force-replace"
+
+// DefaultHostAnnotation, set on a Namespace, names the Kafka Connect host used by the
+// DebeziumConnectors in that namespace that do not set Spec.DebeziumHost.
+const DefaultHostAnnotation = "debezium.io/default-host"
+
+// TraceAnnotation records a structured trace of each reconcile, with the steps taken, REST calls
+// made and decisions, in the connector's "<name>-reconcile-trace" ConfigMap when set to "true".
+const TraceAnnotation = "debezium.io/trace"
+
+// PreviewAnnotation makes the reconcile compute the changes it would make to the connector config
+// on the Debezium host, and report them in Status.ConfigPreview instead of applying them, when
+// set to "true".
+const PreviewAnnotation = "debezium.io/preview"
+
+// SkipRemoteValidationAnnotation makes the admission webhook skip the call to the Debezium
+// validate endpoint for the resource when set to "true"; the local checks still apply.
+const SkipRemoteValidationAnnotation = "debezium.io/skip-remote-validation"
+
+// ConnectVersionAnnotation is set by the operator to the Kafka Connect version of the Debezium
+// host.
+const ConnectVersionAnnotation = "debezium.io/connect-version"
+
+// EffectiveNameAnnotation is set by the operator to the name of the connector on the Debezium
+// host.
+const EffectiveNameAnnotation = "debezium.io/effective-name"
+
+// LastStatusAnnotation is set by the operator to the HTTP status code of the last Kafka Connect
+// REST response of the latest reconcile.
+const LastStatusAnnotation = "debezium.io/last-status"
+
+// Condition types reported on DebeziumConnector status.
+const (
+	// ConditionValidated indicates whether the Debezium host accepted the connector configuration.
+	ConditionValidated = "Validated"
+	// ConditionTaskParallelism indicates whether tasks.max matches the parallelism the connector can achieve.
+	ConditionTaskParallelism = "TaskParallelism"
+	// ConditionPaused indicates whether the operator is currently refraining from modifying the connector.
+	ConditionPaused = "Paused"
+	// ConditionFeatureSupported reports a requested operation the Kafka Connect version does not support.
+	ConditionFeatureSupported = "FeatureSupported"
+	// ConditionCreateTimedOut indicates that the last connector create did not complete in time.
+	ConditionCreateTimedOut = "CreateTimedOut"
+	// ConditionCreateConfirmed indicates whether the last created connector reached RUNNING after
+	// Connect accepted it.
+	ConditionCreateConfirmed = "CreateConfirmed"
+	// ConditionUpdateTimedOut indicates that the last connector update did not complete in time.
+	ConditionUpdateTimedOut = "UpdateTimedOut"
+	// ConditionReconfiguring indicates that the connector is being stopped, updated and resumed
+	// to apply a config change that requires a restart.
+	ConditionReconfiguring = "Reconfiguring"
+	// ConditionDuplicateConnector indicates that another DebeziumConnector already manages the
+	// connector with the same name on the same host, so this resource is not applied.
+	ConditionDuplicateConnector = "DuplicateConnector"
+	// ConditionUnassigned indicates that the connector or some of its tasks are not running on
+	// any Connect worker.
+	ConditionUnassigned = "Unassigned"
+	// ConditionConfigSize indicates whether the serialized config fits comfortably within the
+	// message size limit of the Kafka Connect config topic.
+	ConditionConfigSize = "ConfigSize"
+	// ConditionTopicNames indicates whether the topic names Debezium derives from the topic
+	// prefix and the included tables are legal Kafka topic names.
+	ConditionTopicNames = "TopicNames"
+	// ConditionCircuitOpen indicates that the connector was paused after failing too often and
+	// stays paused until the circuit is reset.
+	ConditionCircuitOpen = "CircuitOpen"
+	// ConditionTopicTracking indicates whether Status.Topics reflects the topics reported by the
+	// Connect worker, or why it is left empty.
+	ConditionTopicTracking = "TopicTracking"
+	// ConditionTopicPrerequisites indicates whether the topics the connector needs exist on the
+	// Kafka brokers; it is only reported when the operator checks topics.
+	ConditionTopicPrerequisites = "TopicPrerequisites"
+	// ConditionAvailable summarizes the health of the connector for alerting: it is True when the
+	// last reconcile succeeded, the desired config is applied, and the connector and all its tasks
+	// are RUNNING. The reason names the first criterion that is not met.
+	ConditionAvailable = "Available"
+	// ConditionUnmanaged indicates that a connector with the same name exists on the Debezium host
+	// without the operator's managed tag, so the operator leaves it alone.
+	ConditionUnmanaged = "Unmanaged"
+	// ConditionDeferredUntil indicates that changes to the connector wait for the next
+	// maintenance window, whose start is given in the message.
+	ConditionDeferredUntil = "DeferredUntil"
+	// ConditionConflictDetected indicates that the config on the Debezium host was changed outside
+	// the operator and differs from the desired config. The reason tells whether it was
+	// overwritten or left in place.
+	ConditionConflictDetected = "ConflictDetected"
+	// ConditionHostCircuitOpen indicates that requests to the Debezium host are suspended after
+	// repeated failures, so the connector is not reconciled until the host answers a probe again.
+	ConditionHostCircuitOpen = "HostCircuitOpen"
+	// ConditionSchemaRegistryReachable indicates whether the schema registries the connector's
+	// converters use answer requests.
+	ConditionSchemaRegistryReachable = "SchemaRegistryReachable"
+)
+
+// Phases reported in Status.Phase. When the phase cannot be derived, the connector state is used.
+const (
+	// PhaseSnapshotting means the connector is taking its initial snapshot.
+	PhaseSnapshotting = "Snapshotting"
+	// PhaseStreaming means the connector is streaming changes.
+	PhaseStreaming = "Streaming"
+	// PhaseStopped means the connector is paused or stopped.
+	PhaseStopped = "Stopped"
+)
+
 // DebeziumConnectorStatus defines the observed state of DebeziumConnector
 type DebeziumConnectorStatus struct {
 	ConnectorStatus string `json:"connectorStatus,omitempty"`
+	// Phase is whether the connector is snapshotting, streaming or stopped.
+	Phase string `json:"phase,omitempty"`
+	// SourcePosition is the last committed position in the source database, e.g. the LSN of a
+	// PostgreSQL connector or the binlog position or GTID set of a MySQL connector, as a coarse
+	// replication lag signal. It is empty when the Debezium host does not expose offsets.
+	SourcePosition string `json:"sourcePosition,omitempty"`
+	// ConnectVersion is the Kafka Connect version reported by the Debezium host.
+	ConnectVersion string `json:"connectVersion,omitempty"`
+	// LastValidationTime is when the configuration was last accepted by the validate endpoint.
+	LastValidationTime *metav1.Time `json:"lastValidationTime,omitempty"`
+	// ValidatedOverridesHash is a hash of the config override annotations the Validated condition
+	// was computed with. Annotations do not change the generation, so a changed hash re-runs
+	// validation.
+	ValidatedOverridesHash string `json:"validatedOverridesHash,omitempty"`
+	// Migration is the progress of moving the connector to another Debezium host; it is cleared
+	// once the migration completed.
+	// +optional
+	Migration *MigrationStatus `json:"migration,omitempty"`
+	// EffectiveName is the name of the connector on the Debezium host when NameCollisionPolicy
+	// SuffixHash resolved a name collision; it is empty when the name from the config is used.
+	EffectiveName string `json:"effectiveName,omitempty"`
+	// DebeziumHost is the host the connector was last reconciled on; the connector is migrated
+	// when it differs from Spec.DebeziumHost.
+	DebeziumHost string `json:"debeziumHost,omitempty"`
+	// ObservedGeneration is the generation last fully reconciled against the Debezium host.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ConfigHash is a hash of the config last fully reconciled against the Debezium host.
+	ConfigHash string `json:"configHash,omitempty"`
+	// HostConfigHash is a hash of the connector config last applied to or seen on the Debezium
+	// host. A config on the host with another hash was changed outside the operator.
+	HostConfigHash string `json:"hostConfigHash,omitempty"`
+	// ReconcileIntervalSeconds is how long the operator waits before polling the connector again.
+	// It grows up to Spec.MaxReconcileIntervalSeconds while the connector is stable.
+	ReconcileIntervalSeconds int32 `json:"reconcileIntervalSeconds,omitempty"`
+	// FailureCount counts the times the connector or one of its tasks was seen entering FAILED.
+	FailureCount int32 `json:"failureCount,omitempty"`
+	// RecentFailures are the times of the failures within the circuit breaker window.
+	// +optional
+	RecentFailures []metav1.Time `json:"recentFailures,omitempty"`
+	// Failing is whether the connector or one of its tasks was FAILED at the last status check.
+	Failing bool `json:"failing,omitempty"`
+	// FailedRestarts counts the restarts of the connector after it failed with a matching config.
+	FailedRestarts int32 `json:"failedRestarts,omitempty"`
+	// LastFailedRestartTime is when the connector was last restarted after it failed.
+	LastFailedRestartTime *metav1.Time `json:"lastFailedRestartTime,omitempty"`
+	// Tasks are the connector's tasks and the Connect workers they are assigned to, as of the
+	// last status check.
+	// +listType=map
+	// +listMapKey=id
+	// +optional
+	Tasks []TaskStatus `json:"tasks,omitempty"`
+	// Topics are the topics the connector has written to, as tracked by the Connect worker.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+	// ForceReplaceNonce is the last value of the force-replace annotation that was processed.
+	ForceReplaceNonce string `json:"forceReplaceNonce,omitempty"`
+	// LastForceReplaceTime is when the connector config was last replaced through the annotation.
+	LastForceReplaceTime *metav1.Time `json:"lastForceReplaceTime,omitempty"`
+	// ConfigSources maps each config key to the layer it was taken from: a template
+	// ("template/<name>"), the config Secret ("secret/<name>"), the resource's spec ("spec") or an
+	// override annotation ("annotation"). It is only reported for connectors with TemplateRefs.
+	// +optional
+	ConfigSources map[string]string `json:"configSources,omitempty"`
+	// Logger is the log level applied for Spec.LogLevel.
+	// +optional
+	Logger *LoggerStatus `json:"logger,omitempty"`
+	// ConfigPreview is the change the operator would make to the connector, computed while the
+	// PreviewAnnotation is set.
+	// +optional
+	ConfigPreview *ConfigPreview `json:"configPreview,omitempty"`
+	// CreatedTime is when the operator last created the connector on the Debezium host.
+	CreatedTime *metav1.Time `json:"createdTime,omitempty"`
+	// TimeToRunning is how long the connector took from CreatedTime to the first status check
+	// that found it RUNNING. It is unset until then.
+	TimeToRunning *metav1.Duration `json:"timeToRunning,omitempty"`
+	// DeletionChecks counts how often the connector was still present on the Debezium host after deletion.
+	DeletionChecks int32 `json:"deletionChecks,omitempty"`
+	// History holds the last changes of the connector state, oldest first, for post-mortems.
+	// It is capped at ten entries.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	History []StateTransition `json:"history,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Reasons of a StateTransition.
+const (
+	// TransitionReasonCreated is the first state of a connector the operator created.
+	TransitionReasonCreated = "Created"
+	// TransitionReasonObserved is a state change the operator did not cause or classify.
+	TransitionReasonObserved = "Observed"
+	// TransitionReasonDesiredState is a change to the state Spec.DesiredState asks for.
+	TransitionReasonDesiredState = "DesiredState"
+	// TransitionReasonFailed is a change to FAILED.
+	TransitionReasonFailed = "Failed"
+	// TransitionReasonRecovered is a change from FAILED to RUNNING.
+	TransitionReasonRecovered = "Recovered"
+)
+
+// StateTransition is a change of the connector state observed by the operator.
+type StateTransition struct {
+	// Time is when the change was observed.
+	Time metav1.Time `json:"time"`
+	// From is the previous state; empty when the connector had no state yet.
+	// +optional
+	From string `json:"from,omitempty"`
+	// To is the new state.
+	To string `json:"to"`
+	// Reason classifies the change: Created, DesiredState, Failed, Recovered or Observed.
+	Reason string `json:"reason"`
+}
+
+// TaskStatus is the state of a connector task and the Connect worker running it.
+type TaskStatus struct {
+	// ID is the task number assigned by Connect.
+	ID int32 `json:"id"`
+	// State is the task state, e.g. RUNNING, FAILED or UNASSIGNED.
+	State string `json:"state,omitempty"`
+	// WorkerID is the host and port of the Connect worker the task is assigned to.
+	WorkerID string `json:"workerId,omitempty"`
+}
+
+// Operations of a ConfigChange.
+const (
+	// ConfigChangeAdd adds a key missing on the Debezium host.
+	ConfigChangeAdd = "Add"
+	// ConfigChangeUpdate changes the value of a key.
+	ConfigChangeUpdate = "Update"
+	// ConfigChangeRemove removes a key not in the desired config.
+	ConfigChangeRemove = "Remove"
+)
+
+// Actions of a ConfigPreview.
+const (
+	// PreviewActionCreate means the connector would be created.
+	PreviewActionCreate = "Create"
+	// PreviewActionUpdate means the connector config would be updated.
+	PreviewActionUpdate = "Update"
+	// PreviewActionNone means the connector config is up to date.
+	PreviewActionNone = "None"
+)
+
+// ConfigPreview is the change a reconcile would make to the connector on the Debezium host.
+type ConfigPreview struct {
+	// ObservedGeneration is the generation the preview was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time is when the preview was computed.
+	Time metav1.Time `json:"time"`
+	// DebeziumHost is the host the config was compared with.
+	DebeziumHost string `json:"debeziumHost"`
+	// Action is Create when the connector does not exist, Update when its config differs from the
+	// desired config, and None otherwise.
+	Action string `json:"action"`
+	// Changes are the config keys that would change, in key order. Sensitive values are masked.
+	// +optional
+	Changes []ConfigChange `json:"changes,omitempty"`
+}
+
+// ConfigChange is a config key a reconcile would change.
+type ConfigChange struct {
+	// Key is the config key.
+	Key string `json:"key"`
+	// Operation is Add, Update or Remove.
+	Operation string `json:"operation"`
+	// Current is the value on the Debezium host.
+	// +optional
+	Current string `json:"current,omitempty"`
+	// Desired is the value of the desired config.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+}
+
+// LoggerStatus is a log level the operator applied to a logger on the Connect workers.
+type LoggerStatus struct {
+	// Name is the logger, e.g. io.debezium.connector.mysql.
+	Name string `json:"name"`
+	// Level is the level applied to the logger.
+	Level string `json:"level"`
+	// PreviousLevel is the effective level of the logger before it was changed, which is restored
+	// when Spec.LogLevel is cleared.
+	PreviousLevel string `json:"previousLevel,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=dbc;dzc,categories=debezium
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.connectorStatus`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:webhook:path=/validate-api-debezium-v1alpha1-debeziumconnector,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.debezium,resources=debeziumconnectors,verbs=create;update,versions=v1alpha1,name=vdebeziumconnector.api.debezium.io,admissionReviewVersions=v1
 
 // DebeziumConnector is the Schema for the debeziumconnectors API
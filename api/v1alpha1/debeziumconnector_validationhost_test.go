@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation host", func() {
+	ctx := context.Background()
+
+	var (
+		management, proxy           *httptest.Server
+		managementCalls, proxyCalls atomic.Int32
+	)
+
+	BeforeEach(func() {
+		managementCalls.Store(0)
+		proxyCalls.Store(0)
+		management = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			managementCalls.Add(1)
+			_, _ = w.Write([]byte(`{"errors":{}}`))
+		}))
+		proxy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxyCalls.Add(1)
+			Expect(r.URL.Path).To(Equal("/connector-plugins/io.debezium.connector.mysql.MySqlConnector/config/validate"))
+			_, _ = w.Write([]byte(`{"errors":{"database.hostname":"Missing required configuration"}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		management.Close()
+		proxy.Close()
+	})
+
+	It("validates against the DebeziumHost by default", func() {
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, newTestConnector(management.URL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(managementCalls.Load()).To(Equal(int32(1)))
+		Expect(proxyCalls.Load()).To(BeZero())
+	})
+
+	It("skips the remote call when the resource asks for it", func() {
+		dbc := newTestConnector(proxy.URL)
+		dbc.Annotations = map[string]string{SkipRemoteValidationAnnotation: "true"}
+		warnings, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(ContainSubstring("remote validation was skipped")))
+		Expect(proxyCalls.Load()).To(BeZero())
+	})
+
+	It("still runs the local checks when skipping the remote call", func() {
+		dbc := newTestConnector(proxy.URL)
+		dbc.Annotations = map[string]string{SkipRemoteValidationAnnotation: "true"}
+		delete(dbc.Spec.Config, "connector.class")
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.connector.class")))
+		Expect(proxyCalls.Load()).To(BeZero())
+	})
+
+	It("calls the validate endpoint unless the annotation is \"true\"", func() {
+		dbc := newTestConnector(proxy.URL)
+		dbc.Annotations = map[string]string{SkipRemoteValidationAnnotation: "false"}
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.database.hostname")))
+		Expect(proxyCalls.Load()).To(Equal(int32(1)))
+	})
+
+	It("validates against the ValidationHost when set", func() {
+		dbc := newTestConnector(management.URL)
+		dbc.Spec.ValidationHost = proxy.URL
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.config.database.hostname")))
+		Expect(proxyCalls.Load()).To(Equal(int32(1)))
+		Expect(managementCalls.Load()).To(BeZero())
+	})
+
+	It("validates below the Connect base path", func() {
+		prefixed := httptest.NewServer(http.StripPrefix("/kafka-connect", proxy.Config.Handler))
+		defer prefixed.Close()
+		_, err := (&DebeziumConnectorValidator{ConnectBasePath: "/kafka-connect/"}).ValidateCreate(ctx, newTestConnector(prefixed.URL+"/"))
+		Expect(err).To(MatchError(ContainSubstring("spec.config.database.hostname")))
+		Expect(proxyCalls.Load()).To(Equal(int32(1)))
+	})
+
+	It("defers validation to reconciliation without a host", func() {
+		warnings, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, newTestConnector(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(ContainSubstring("remote validation is deferred")))
+		Expect(managementCalls.Load()).To(BeZero())
+	})
+
+	It("does not share cached results between validation hosts", func() {
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+		_, err := v.ValidateCreate(ctx, newTestConnector(management.URL))
+		Expect(err).NotTo(HaveOccurred())
+
+		dbc := newTestConnector(management.URL)
+		dbc.Spec.ValidationHost = proxy.URL
+		_, err = v.ValidateCreate(ctx, dbc)
+		Expect(err).To(HaveOccurred())
+		Expect(proxyCalls.Load()).To(Equal(int32(1)))
+	})
+})
@@ -2,103 +2,342 @@ package v1alpha1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
 )
 
-// Ensure that DebeziumConnector implements the admission.Validator interface.
-var _ admission.Validator = &DebeziumConnector{}
+// DefaultRemoteValidationTimeout bounds the remote validation call when no timeout is configured.
+const DefaultRemoteValidationTimeout = 3 * time.Second
+
+// DefaultSchemaRegistryProbeTimeout bounds the schema registry probe when no timeout is configured.
+const DefaultSchemaRegistryProbeTimeout = 2 * time.Second
+
+// DebeziumConnectorValidator validates DebeziumConnector resources on admission.
+// +kubebuilder:object:generate=false
+type DebeziumConnectorValidator struct {
+	// HTTPClient is used for the remote validation call; http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// RemoteValidationTimeout bounds the call to the Debezium validate endpoint.
+	RemoteValidationTimeout time.Duration
+	// BestEffort admits the resource with a warning when remote validation times out,
+	// instead of rejecting it.
+	BestEffort bool
+	// ConflictRules lists mutually exclusive config keys; DefaultConfigConflictRules is used when nil.
+	ConflictRules []ConfigConflictRule
+	// Client looks up other DebeziumConnectors to detect topic prefix collisions; the check is
+	// skipped when nil.
+	Client client.Reader
+	// WarnOnTopicPrefixCollision admits colliding connectors with a warning instead of rejecting them.
+	WarnOnTopicPrefixCollision bool
+	// Cache holds recent remote validation results; remote validation is not cached when nil.
+	Cache *ValidationCache
+	// ConfigSizeLimit rejects configs whose serialized size exceeds it, and warns about configs
+	// approaching it; the size is not checked when zero.
+	ConfigSizeLimit int
+	// DisableRemoteValidation skips the call to the Debezium validate endpoint, e.g. in
+	// air-gapped clusters, and validates configs against the bundled schema of their connector
+	// class instead.
+	DisableRemoteValidation bool
+	// ConnectBasePath is the path prefix the Kafka Connect REST API is served below on the
+	// Debezium hosts; the API is served at the root when empty.
+	ConnectBasePath string
+	// ProbeSchemaRegistry checks that the schema registries of the key and value converters
+	// answer, and admits the resource with a warning when they do not.
+	ProbeSchemaRegistry bool
+	// SchemaRegistryProbeTimeout bounds the schema registry probe;
+	// DefaultSchemaRegistryProbeTimeout when zero.
+	SchemaRegistryProbeTimeout time.Duration
+	// SchemaRegistryProbeHosts restricts the probe to registries on these hosts, or on
+	// subdomains of entries starting with a dot, since the operator would otherwise send requests
+	// to any URL a connector author writes; every host is probed when empty.
+	SchemaRegistryProbeHosts []string
+	// SubstituteEnv reports that the reconciler replaces ${env:VAR} tokens with operator
+	// environment variables, so remote validation of configs containing them is deferred to
+	// reconciliation. Otherwise the tokens are sent to the Debezium host as they are, and the
+	// resource is admitted with a warning.
+	SubstituteEnv bool
+}
+
+// Ensure that DebeziumConnectorValidator implements the admission.CustomValidator interface.
+var _ admission.CustomValidator = &DebeziumConnectorValidator{}
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
-func (r *DebeziumConnector) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *DebeziumConnector) SetupWebhookWithManager(mgr ctrl.Manager, validator *DebeziumConnectorValidator) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(validator).
 		Complete()
 }
 
-// ValidateCreate implements admission.Validator for create operations.
-func (r *DebeziumConnector) ValidateCreate() (admission.Warnings, error) {
-	return nil, r.validateDebeziumConnector()
+// ValidateCreate implements admission.CustomValidator for create operations.
+func (v *DebeziumConnectorValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	dbc, ok := obj.(*DebeziumConnector)
+	if !ok {
+		return nil, fmt.Errorf("expected a DebeziumConnector but got %T", obj)
+	}
+	return v.validateDebeziumConnector(ctx, dbc)
 }
 
-// ValidateUpdate implements admission.Validator for update operations.
-func (r *DebeziumConnector) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
-	return nil, r.validateDebeziumConnector()
+// ValidateUpdate implements admission.CustomValidator for update operations.
+func (v *DebeziumConnectorValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	dbc, ok := newObj.(*DebeziumConnector)
+	if !ok {
+		return nil, fmt.Errorf("expected a DebeziumConnector but got %T", newObj)
+	}
+	return v.validateDebeziumConnector(ctx, dbc)
 }
 
-// ValidateDelete implements admission.Validator for delete operations.
-func (r *DebeziumConnector) ValidateDelete() (admission.Warnings, error) {
+// ValidateDelete implements admission.CustomValidator for delete operations.
+func (v *DebeziumConnectorValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
 
 // validateDebeziumConnector validates the configuration of a DebeziumConnector CR.
 // It performs minimal local checks and then delegates to the Debezium Connect validation endpoint.
-func (r *DebeziumConnector) validateDebeziumConnector() error {
-	var allErrs field.ErrorList
+// In best-effort mode a timed-out remote call is reported as a warning rather than an error.
+func (v *DebeziumConnectorValidator) validateDebeziumConnector(ctx context.Context, r *DebeziumConnector) (admission.Warnings, error) {
+	// Validate the config as the reconciler will apply it, including annotation overrides.
+	config, allErrs := r.DesiredConfig()
+	allErrs = append(allErrs, r.validateIntervals()...)
+	allErrs = append(allErrs, r.validateMaintenanceWindow()...)
+	allErrs = append(allErrs, r.validateErrorHandling(config)...)
+	allErrs = append(allErrs, r.validateClientOverrides(config)...)
+
+	// Configs too large for the Connect config topic fail to commit without a clear error.
+	var warnings admission.Warnings
+	if v.ConfigSizeLimit > 0 {
+		sizeErrs, sizeWarnings := validateConfigSize(config, v.ConfigSizeLimit)
+		allErrs = append(allErrs, sizeErrs...)
+		warnings = append(warnings, sizeWarnings...)
+	}
 
-	connectorClass, ok := r.Spec.Config["connector.class"]
+	// Converters fail opaquely at runtime when their schema registry is down.
+	if v.ProbeSchemaRegistry {
+		_, problems := util.ProbeSchemaRegistries(ctx, nil, config, v.schemaRegistryProbeTimeout(), v.SchemaRegistryProbeHosts)
+		warnings = append(warnings, problems...)
+	}
+
+	// Two connectors writing the same topics corrupt each other's change streams.
+	collisions, err := v.findTopicPrefixCollisions(ctx, r, config)
+	if err != nil {
+		return nil, err
+	}
+	if v.WarnOnTopicPrefixCollision {
+		for _, collision := range collisions {
+			warnings = append(warnings, collision.Error())
+		}
+	} else {
+		allErrs = append(allErrs, collisions...)
+	}
+
+	// Part of the config lives in a Secret, a template, in Vault or in the environment, which the
+	// webhook does not read; the reconciler validates the resolved config and reports the result
+	// in the Validated condition.
+	if r.Spec.ConfigSecretRef != nil || len(r.Spec.TemplateRefs) > 0 || hasExternalReference(config, v.SubstituteEnv) {
+		if len(allErrs) > 0 {
+			return nil, apierrors.NewInvalid(GroupVersion.WithKind("DebeziumConnector").GroupKind(), r.Name, allErrs)
+		}
+		return append(warnings, "config is partly stored outside the resource; remote validation is deferred to reconciliation"), nil
+	}
+	if hasExternalReference(config, true) {
+		warnings = append(warnings, "config contains ${env:...} tokens, which the operator does not substitute; they are sent to the Debezium host as they are")
+	}
+
+	connectorClass, ok := config["connector.class"]
 	if !ok {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("config").Child("connector.class"), "config must include key \"connector.class\""))
 	}
 
-	if _, ok := r.Spec.Config["name"]; !ok {
+	if _, ok := config["name"]; !ok {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("config").Child("name"), "config must include key \"name\""))
 	}
 
+	conflictRules := v.ConflictRules
+	if conflictRules == nil {
+		conflictRules = DefaultConfigConflictRules
+	}
+	allErrs = append(allErrs, validateConfigConflicts(config, conflictRules)...)
+	allErrs = append(allErrs, validateRegexLists(config)...)
+	allErrs = append(allErrs, ValidateTransformChain(config)...)
+	allErrs = append(allErrs, validateTopicPrefix(config)...)
+
 	// If minimal checks fail, return errors without calling the external endpoint.
 	if len(allErrs) > 0 {
-		return apierrors.NewInvalid(GroupVersion.WithKind("DebeziumConnector").GroupKind(), r.Name, allErrs)
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("DebeziumConnector").GroupKind(), r.Name, allErrs)
+	}
+
+	var remoteErrs map[string]string
+	if v.DisableRemoteValidation {
+		// Without network access, check the config structurally against the bundled schema.
+		offlineErrs, ok, err := validateOffline(connectorClass, config)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return append(warnings, fmt.Sprintf("no bundled config schema for %s; config was not validated", connectorClass)), nil
+		}
+		remoteErrs = offlineErrs
+	} else if r.Annotations[SkipRemoteValidationAnnotation] == "true" {
+		// The resource's validate endpoint is known to be slow or flaky; the reconciler still
+		// validates the config and reports the result in the Validated condition.
+		return append(warnings, fmt.Sprintf("remote validation was skipped as requested by the %s annotation", SkipRemoteValidationAnnotation)), nil
+	} else {
+		// The namespace default host is resolved by the reconciler, which validates the config
+		// against it.
+		host := r.validationHost()
+		if host == "" {
+			return append(warnings, "spec.debeziumHost is not set; remote validation is deferred to reconciliation"), nil
+		}
+		// Identical configs re-applied within the cache TTL reuse the previous remote result.
+		cacheKey := validationCacheKey(host, connectorClass, config)
+		var cached bool
+		remoteErrs, cached = v.Cache.Get(cacheKey)
+		if !cached && isDryRun(ctx) {
+			// A server-side dry run should be fast and leave no trace on the Debezium host, so
+			// the config is checked against the bundled schema instead.
+			offlineErrs, ok, err := validateOffline(connectorClass, config)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return append(warnings, "dry run: config was not validated by the Debezium host"), nil
+			}
+			remoteErrs = offlineErrs
+			warnings = append(warnings, fmt.Sprintf("dry run: config was validated against the bundled schema of %s, not by the Debezium host", connectorClass))
+		} else if !cached {
+			var err error
+			remoteErrs, err = v.validateRemotely(ctx, host, connectorClass, config)
+			switch {
+			case errors.Is(err, errRemoteValidationUnsupported):
+				return warnings, nil
+			case err != nil && v.BestEffort && isTimeout(err):
+				return append(warnings, fmt.Sprintf("Debezium validation endpoint did not respond within %s; config was not validated remotely", v.remoteValidationTimeout())), nil
+			case err != nil:
+				return nil, err
+			}
+			v.Cache.Put(cacheKey, remoteErrs)
+		}
+	}
+
+	// If the external endpoint reports any errors, aggregate them without exposing sensitive values,
+	// pointing to the reference documentation of keys we recognize.
+	if len(remoteErrs) > 0 {
+		masked := util.MaskSensitiveConfig(config)
+		for key, msg := range remoteErrs {
+			msg = withConfigDocsHint(connectorClass, key, util.RedactText(msg, config))
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), masked[key], msg))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("DebeziumConnector").GroupKind(), r.Name, allErrs)
 	}
 
+	return warnings, nil
+}
+
+// isDryRun reports whether ctx belongs to a server-side dry-run admission request, e.g. from
+// kubectl apply --dry-run=server.
+func isDryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.DryRun != nil && *req.DryRun
+}
+
+// schemaRegistryProbeTimeout returns the deadline for the schema registry probe.
+func (v *DebeziumConnectorValidator) schemaRegistryProbeTimeout() time.Duration {
+	if v.SchemaRegistryProbeTimeout > 0 {
+		return v.SchemaRegistryProbeTimeout
+	}
+	return DefaultSchemaRegistryProbeTimeout
+}
+
+// errRemoteValidationUnsupported is returned when the Debezium host does not offer the validate endpoint.
+var errRemoteValidationUnsupported = errors.New("remote validation is not supported by the Debezium host")
+
+// validationHost returns the host the webhook validates the config against.
+func (r *DebeziumConnector) validationHost() string {
+	if r.Spec.ValidationHost != "" {
+		return r.Spec.ValidationHost
+	}
+	return r.Spec.DebeziumHost
+}
+
+// remoteValidationTimeout returns the bound for the remote validation call.
+func (v *DebeziumConnectorValidator) remoteValidationTimeout() time.Duration {
+	if v.RemoteValidationTimeout > 0 {
+		return v.RemoteValidationTimeout
+	}
+	return DefaultRemoteValidationTimeout
+}
+
+// validateRemotely calls the Debezium Connect validation endpoint and returns the reported
+// errors by config key.
+func (v *DebeziumConnectorValidator) validateRemotely(ctx context.Context, host, connectorClass string, config map[string]string) (map[string]string, error) {
 	// Construct the URL for the Debezium Connect validation endpoint.
-	validateURL := fmt.Sprintf("%s/connector-plugins/%s/config/validate", r.Spec.DebeziumHost, connectorClass)
+	validateURL := util.ConnectURL(host, v.ConnectBasePath, fmt.Sprintf("/connector-plugins/%s/config/validate", connectorClass))
 
 	// Prepare payload for the validation endpoint.
 	payload := map[string]interface{}{
-		"name":   r.Spec.Config["name"],
-		"config": r.Spec.Config,
+		"name":   config["name"],
+		"config": config,
 	}
 	data, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config payload: %v", err)
+		return nil, fmt.Errorf("failed to marshal config payload: %v", err)
 	}
 
-	// Create an HTTP client with a timeout.
-	httpClient := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequest("POST", validateURL, bytes.NewBuffer(data))
+	// Bound the remote call separately from the API server's webhook timeout.
+	ctx, cancel := context.WithTimeout(ctx, v.remoteValidationTimeout())
+	defer cancel()
+
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", validateURL, bytes.NewBuffer(data))
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %v", err)
+		return nil, fmt.Errorf("failed to create HTTP request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error calling Debezium validation endpoint: %v", err)
+		return nil, fmt.Errorf("error calling Debezium validation endpoint: %w", err)
 	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read validation response: %v", err)
+		return nil, fmt.Errorf("failed to read validation response: %w", err)
 	}
 
-	// If the external endpoint returns 405, log and skip external validation.
+	// If the external endpoint returns 405, skip external validation.
 	if resp.StatusCode == http.StatusMethodNotAllowed {
-		return nil
+		return nil, errRemoteValidationUnsupported
 	}
 
 	// Check for non-success HTTP response.
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("debezium validation endpoint returned status %d: %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("debezium validation endpoint returned status %d: %s", resp.StatusCode, util.RedactText(string(respBody), config))
 	}
 
 	// Parse the validation response.
@@ -106,19 +345,24 @@ func (r *DebeziumConnector) validateDebeziumConnector() error {
 		Errors map[string]string `json:"errors"`
 	}
 	if err := json.Unmarshal(respBody, &validationResp); err != nil {
-		return fmt.Errorf("failed to unmarshal validation response: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal validation response: %v", err)
 	}
+	return validationResp.Errors, nil
+}
 
-	// If the external endpoint reports any errors, aggregate them.
-	if len(validationResp.Errors) > 0 {
-		for key, msg := range validationResp.Errors {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("config").Child(key), r.Spec.Config[key], msg))
+// hasExternalReference reports whether a config value refers to a Vault secret or, when env is
+// set, an operator environment variable, which are only substituted during reconciliation.
+func hasExternalReference(config map[string]string, env bool) bool {
+	for _, v := range config {
+		if strings.Contains(v, "${vault:") || (env && strings.Contains(v, "${env:")) {
+			return true
 		}
 	}
+	return false
+}
 
-	if len(allErrs) > 0 {
-		return apierrors.NewInvalid(GroupVersion.WithKind("DebeziumConnector").GroupKind(), r.Name, allErrs)
-	}
-
-	return nil
+// isTimeout reports whether err was caused by a deadline or network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
 }
@@ -0,0 +1,241 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// newTestConnector returns a DebeziumConnector pointing at host with a minimal valid config.
+func newTestConnector(host string) *DebeziumConnector {
+	return &DebeziumConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+		Spec: DebeziumConnectorSpec{
+			DebeziumHost: host,
+			Config: map[string]string{
+				"name":            "inventory",
+				"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+				"topic.prefix":    "inventory",
+			},
+		},
+	}
+}
+
+var _ = Describe("DebeziumConnector Webhook", func() {
+	ctx := context.Background()
+
+	Context("When the validation endpoint is slow", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(300 * time.Millisecond):
+				case <-r.Context().Done():
+				}
+				_, _ = w.Write([]byte(`{"errors":{}}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("rejects the request in strict mode", func() {
+			v := &DebeziumConnectorValidator{RemoteValidationTimeout: 50 * time.Millisecond}
+			_, err := v.ValidateCreate(ctx, newTestConnector(server.URL))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("admits the request with a warning in best-effort mode", func() {
+			v := &DebeziumConnectorValidator{RemoteValidationTimeout: 50 * time.Millisecond, BestEffort: true}
+			warnings, err := v.ValidateUpdate(ctx, nil, newTestConnector(server.URL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("did not respond"))
+		})
+
+		It("still enforces local checks in best-effort mode", func() {
+			dbc := newTestConnector(server.URL)
+			delete(dbc.Spec.Config, "connector.class")
+			v := &DebeziumConnectorValidator{RemoteValidationTimeout: 50 * time.Millisecond, BestEffort: true}
+			_, err := v.ValidateCreate(ctx, dbc)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When the request is a server-side dry run", func() {
+		var (
+			server *httptest.Server
+			calls  atomic.Int32
+		)
+
+		BeforeEach(func() {
+			calls.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				_, _ = w.Write([]byte(`{"configs":[]}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		dryRunContext := func(dryRun bool) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{DryRun: &dryRun},
+			})
+		}
+
+		validMySQLConnector := func() *DebeziumConnector {
+			dbc := newTestConnector(server.URL)
+			dbc.Spec.Config["database.hostname"] = "mysql"
+			dbc.Spec.Config["database.user"] = "debezium"
+			return dbc
+		}
+
+		It("validates against the bundled schema without calling the Debezium host", func() {
+			v := &DebeziumConnectorValidator{}
+			warnings, err := v.ValidateCreate(dryRunContext(true), validMySQLConnector())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("dry run: config was validated against the bundled schema")))
+			Expect(calls.Load()).To(BeZero())
+
+			_, err = v.ValidateCreate(dryRunContext(true), newTestConnector(server.URL))
+			Expect(err).To(MatchError(ContainSubstring("database.hostname")))
+			Expect(calls.Load()).To(BeZero())
+		})
+
+		It("still enforces local checks", func() {
+			dbc := validMySQLConnector()
+			delete(dbc.Spec.Config, "name")
+			_, err := (&DebeziumConnectorValidator{}).ValidateUpdate(dryRunContext(true), nil, dbc)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.name")))
+		})
+
+		It("admits connectors without a bundled schema with a warning", func() {
+			dbc := newTestConnector(server.URL)
+			dbc.Spec.Config["connector.class"] = "com.example.CustomConnector"
+			warnings, err := (&DebeziumConnectorValidator{}).ValidateCreate(dryRunContext(true), dbc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("not validated by the Debezium host")))
+		})
+
+		It("reuses a cached remote result", func() {
+			v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+			_, err := v.ValidateCreate(dryRunContext(false), validMySQLConnector())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls.Load()).To(Equal(int32(1)))
+
+			warnings, err := v.ValidateCreate(dryRunContext(true), validMySQLConnector())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(calls.Load()).To(Equal(int32(1)))
+		})
+	})
+
+	Context("When probing the schema registry", func() {
+		var connect, registry *httptest.Server
+
+		BeforeEach(func() {
+			connect = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"errors":{}}`))
+			}))
+			registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{}`))
+			}))
+		})
+
+		AfterEach(func() {
+			connect.Close()
+			registry.Close()
+		})
+
+		avroConnector := func(registryURL string) *DebeziumConnector {
+			dbc := newTestConnector(connect.URL)
+			dbc.Spec.Config["value.converter"] = "io.confluent.connect.avro.AvroConverter"
+			dbc.Spec.Config["value.converter.schema.registry.url"] = registryURL
+			return dbc
+		}
+
+		It("admits a connector whose registry answers without a warning", func() {
+			v := &DebeziumConnectorValidator{ProbeSchemaRegistry: true}
+			warnings, err := v.ValidateCreate(ctx, avroConnector(registry.URL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("admits a connector whose registry is unreachable with a warning", func() {
+			url := registry.URL
+			registry.Close()
+			v := &DebeziumConnectorValidator{ProbeSchemaRegistry: true, SchemaRegistryProbeTimeout: 100 * time.Millisecond}
+			warnings, err := v.ValidateCreate(ctx, avroConnector(url))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("value.converter.schema.registry.url is unreachable")))
+		})
+
+		It("does not probe the registry unless enabled", func() {
+			url := registry.URL
+			registry.Close()
+			warnings, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, avroConnector(url))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("When the config references environment variables", func() {
+		var (
+			server *httptest.Server
+			calls  atomic.Int32
+		)
+
+		BeforeEach(func() {
+			calls.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				_, _ = w.Write([]byte(`{"errors":{}}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		envConnector := func() *DebeziumConnector {
+			dbc := newTestConnector(server.URL)
+			dbc.Spec.Config["schema.history.internal.kafka.bootstrap.servers"] = "${env:DBZ_BROKERS}"
+			return dbc
+		}
+
+		It("defers remote validation when the operator substitutes them", func() {
+			warnings, err := (&DebeziumConnectorValidator{SubstituteEnv: true}).ValidateCreate(ctx, envConnector())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("remote validation is deferred")))
+			Expect(calls.Load()).To(BeZero())
+		})
+
+		It("validates remotely with a warning when the operator does not substitute them", func() {
+			warnings, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, envConnector())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("does not substitute")))
+			Expect(calls.Load()).To(Equal(int32(1)))
+		})
+	})
+
+	It("rejects malformed config properties with the failing line", func() {
+		dbc := newTestConnector("http://connect.invalid:8083")
+		dbc.Spec.ConfigProperties = "tasks.max=1\n\n=orphan value\n"
+		_, err := (&DebeziumConnectorValidator{}).ValidateCreate(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("spec.configProperties")))
+		Expect(err).To(MatchError(ContainSubstring("line 3: missing key")))
+	})
+})
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebeziumConnectorSetSpec defines the desired state of DebeziumConnectorSet
+type DebeziumConnectorSetSpec struct {
+	// DebeziumHost is the host the connectors are created on, unless a connector sets its own.
+	// +kubebuilder:validation:Required
+	DebeziumHost string `json:"debeziumHost"`
+	// Connectors are reconciled as a group: none is created or updated while the config of another
+	// is invalid, and all of them are deleted with the set. Connectors removed from the list are
+	// deleted from their Debezium host.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Connectors []NamedConnectorSpec `json:"connectors"`
+	// ReconcileIntervalSeconds is how long to wait before polling the connectors again after a
+	// successful reconcile. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReconcileIntervalSeconds int32 `json:"reconcileIntervalSeconds,omitempty"`
+	// UpdateStrategy controls how config changes are applied to the connectors: AllAtOnce
+	// (default) creates and updates all of them on every reconcile, RollingUpdate does so in list
+	// order and waits for the changed connectors to return to RUNNING before changing the next.
+	// +kubebuilder:validation:Enum=AllAtOnce;RollingUpdate
+	// +optional
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+	// MaxUnavailable is the number of connectors a RollingUpdate allows to be not RUNNING at the
+	// same time, counting the connectors it changed that have not returned to RUNNING yet.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUnavailable int32 `json:"maxUnavailable,omitempty"`
+}
+
+// Update strategies for DebeziumConnectorSetSpec.UpdateStrategy.
+const (
+	SetUpdateStrategyAllAtOnce     = "AllAtOnce"
+	SetUpdateStrategyRollingUpdate = "RollingUpdate"
+)
+
+// NamedConnectorSpec is a connector in a DebeziumConnectorSet.
+type NamedConnectorSpec struct {
+	// Name is the connector name on the Debezium host; it overrides the "name" config key.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// DebeziumHost overrides the host of the set for this connector.
+	// +optional
+	DebeziumHost string `json:"debeziumHost,omitempty"`
+	// Config holds the connector configuration.
+	Config map[string]string `json:"config"`
+}
+
+// Conditions reported in DebeziumConnectorSetStatus.Conditions.
+const (
+	// ConditionReady indicates whether all connectors of the set are RUNNING.
+	ConditionReady = "Ready"
+)
+
+// ConnectorSetMemberStatus is the observed state of one connector in a DebeziumConnectorSet.
+type ConnectorSetMemberStatus struct {
+	// Name is the connector name on the Debezium host.
+	Name string `json:"name"`
+	// DebeziumHost is the host the connector was last reconciled on.
+	DebeziumHost string `json:"debeziumHost,omitempty"`
+	// ConnectorStatus is the connector state reported by the Debezium host.
+	ConnectorStatus string `json:"connectorStatus,omitempty"`
+	// Message describes why the connector could not be reconciled.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConnectorSetRolloutStatus is the progress of a RollingUpdate of a DebeziumConnectorSet.
+type ConnectorSetRolloutStatus struct {
+	// UpdatedConnectors is the number of connectors running the desired config, in the form
+	// updated/total.
+	UpdatedConnectors string `json:"updatedConnectors"`
+	// Updating lists the connectors running the desired config that are not RUNNING yet.
+	// +optional
+	Updating []string `json:"updating,omitempty"`
+	// Pending lists the connectors waiting to be created or updated, in rollout order.
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+}
+
+// DebeziumConnectorSetStatus defines the observed state of DebeziumConnectorSet
+type DebeziumConnectorSetStatus struct {
+	// Connectors holds the state of each connector of the set.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Connectors []ConnectorSetMemberStatus `json:"connectors,omitempty"`
+	// ReadyConnectors is the number of connectors that are RUNNING, in the form ready/total.
+	ReadyConnectors string `json:"readyConnectors,omitempty"`
+	// Rollout is the progress of the current RollingUpdate; it is not set for other strategies.
+	// +optional
+	Rollout *ConnectorSetRolloutStatus `json:"rollout,omitempty"`
+	// ObservedGeneration is the generation last reconciled against the Debezium hosts.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=dbcs,categories=debezium
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.readyConnectors`
+//+kubebuilder:printcolumn:name="Updated",type=string,JSONPath=`.status.rollout.updatedConnectors`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DebeziumConnectorSet is the Schema for the debeziumconnectorsets API
+type DebeziumConnectorSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DebeziumConnectorSetSpec   `json:"spec,omitempty"`
+	Status DebeziumConnectorSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DebeziumConnectorSetList contains a list of DebeziumConnectorSet
+type DebeziumConnectorSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DebeziumConnectorSet `json:"items"`
+}
+
+// MaxUnavailableConnectors returns the number of connectors a RollingUpdate allows to be not
+// RUNNING at the same time.
+func (r *DebeziumConnectorSet) MaxUnavailableConnectors() int {
+	if r.Spec.MaxUnavailable > 0 {
+		return int(r.Spec.MaxUnavailable)
+	}
+	return 1
+}
+
+func init() {
+	SchemeBuilder.Register(&DebeziumConnectorSet{}, &DebeziumConnectorSetList{})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebeziumConnectorTemplateSpec defines the config shared by the connectors referencing the template
+type DebeziumConnectorTemplateSpec struct {
+	// Config holds connector configuration merged under the config of the connectors referencing
+	// the template.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=dbct,categories=debezium
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DebeziumConnectorTemplate is the Schema for the debeziumconnectortemplates API
+type DebeziumConnectorTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DebeziumConnectorTemplateSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DebeziumConnectorTemplateList contains a list of DebeziumConnectorTemplate
+type DebeziumConnectorTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DebeziumConnectorTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DebeziumConnectorTemplate{}, &DebeziumConnectorTemplateList{})
+}
@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// ValidationCache is a size-bounded, TTL-based cache of remote validation results, keyed by
+// host, connector class and config hash. It is safe for concurrent admission requests, and a nil
+// *ValidationCache caches nothing.
+// +kubebuilder:object:generate=false
+type ValidationCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru orders entries from most to least recently used.
+	lru *list.List
+	// servers holds the last observed version and commit of each host, see ObserveServer.
+	servers map[string]string
+}
+
+// validationCacheEntry is a cached validation result.
+type validationCacheEntry struct {
+	key       string
+	errs      map[string]string
+	expiresAt time.Time
+}
+
+// NewValidationCache returns a cache holding up to maxEntries results for ttl each.
+func NewValidationCache(ttl time.Duration, maxEntries int) *ValidationCache {
+	return &ValidationCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// validationCacheKey identifies a remote validation request.
+func validationCacheKey(host, connectorClass string, config map[string]string) string {
+	return host + "\x00" + connectorClass + "\x00" + util.ConfigHash(config)
+}
+
+// Get returns the cached validation errors for key, if present and not expired.
+func (c *ValidationCache) Get(key string) (map[string]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*validationCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.errs, true
+}
+
+// Put stores the validation errors for key, evicting the least recently used entry when full.
+func (c *ValidationCache) Put(key string, errs map[string]string) {
+	if c == nil || c.maxEntries <= 0 || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &validationCacheEntry{key: key, errs: errs, expiresAt: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*validationCacheEntry).key)
+	}
+}
+
+// ObserveServer records the Connect version and commit a host reports. When they differ from
+// the ones observed before, e.g. after its workers were upgraded with new plugin versions, the
+// cached results of that host are dropped, so configs are validated again by the new plugins.
+// It returns whether results were dropped.
+func (c *ValidationCache) ObserveServer(host, version, commit string) bool {
+	if c == nil {
+		return false
+	}
+	fingerprint := version + "\x00" + commit
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous, seen := c.servers[host]
+	if c.servers == nil {
+		c.servers = map[string]string{}
+	}
+	c.servers[host] = fingerprint
+	if !seen || previous == fingerprint {
+		return false
+	}
+	prefix := host + "\x00"
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.lru.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+	return true
+}
+
+// Len returns the number of cached results, including expired ones not yet evicted.
+func (c *ValidationCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,126 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation cache", func() {
+	ctx := context.Background()
+
+	var (
+		server *httptest.Server
+		calls  atomic.Int32
+	)
+
+	BeforeEach(func() {
+		calls.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			_, _ = w.Write([]byte(`{"errors":{}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reuses the remote result for an identical config", func() {
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+		for i := 0; i < 3; i++ {
+			_, err := v.ValidateCreate(ctx, newTestConnector(server.URL))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("validates again when the config changes", func() {
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+		dbc := newTestConnector(server.URL)
+		_, err := v.ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		dbc.Spec.Config["tasks.max"] = "2"
+		_, err = v.ValidateUpdate(ctx, nil, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("validates again once the entry expires", func() {
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(20*time.Millisecond, 10)}
+		_, err := v.ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).NotTo(HaveOccurred())
+		time.Sleep(40 * time.Millisecond)
+		_, err = v.ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("caches reported validation errors", func() {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			_, _ = w.Write([]byte(`{"errors":{"tasks.max":"must be positive"}}`))
+		})
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+		for i := 0; i < 2; i++ {
+			_, err := v.ValidateCreate(ctx, newTestConnector(server.URL))
+			Expect(err).To(MatchError(ContainSubstring("must be positive")))
+		}
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("evicts the least recently used entry when full", func() {
+		c := NewValidationCache(time.Minute, 2)
+		c.Put("a", nil)
+		c.Put("b", nil)
+		_, ok := c.Get("a")
+		Expect(ok).To(BeTrue())
+		c.Put("c", nil)
+		Expect(c.Len()).To(Equal(2))
+		_, ok = c.Get("b")
+		Expect(ok).To(BeFalse())
+		_, ok = c.Get("a")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("validates again once the Connect version of the host changes", func() {
+		v := &DebeziumConnectorValidator{Cache: NewValidationCache(time.Minute, 10)}
+		Expect(v.Cache.ObserveServer(server.URL, "3.6.0", "abc")).To(BeFalse())
+		_, err := v.ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Cache.ObserveServer(server.URL, "3.6.0", "abc")).To(BeFalse())
+		_, err = v.ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(1)))
+
+		Expect(v.Cache.ObserveServer(server.URL, "3.7.0", "def")).To(BeTrue())
+		Expect(v.Cache.Len()).To(Equal(0))
+		_, err = v.ValidateCreate(ctx, newTestConnector(server.URL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("keeps the results of other hosts when a host changes", func() {
+		c := NewValidationCache(time.Minute, 10)
+		c.ObserveServer("http://a:8083", "3.6.0", "abc")
+		c.Put(validationCacheKey("http://a:8083", "MySqlConnector", nil), nil)
+		c.Put(validationCacheKey("http://b:8083", "MySqlConnector", nil), nil)
+		Expect(c.ObserveServer("http://a:8083", "3.6.0", "def")).To(BeTrue())
+		_, ok := c.Get(validationCacheKey("http://a:8083", "MySqlConnector", nil))
+		Expect(ok).To(BeFalse())
+		_, ok = c.Get(validationCacheKey("http://b:8083", "MySqlConnector", nil))
+		Expect(ok).To(BeTrue())
+	})
+
+	It("caches nothing when nil", func() {
+		var c *ValidationCache
+		c.Put("a", nil)
+		_, ok := c.Get("a")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -21,16 +21,124 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerSpec.
+func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigChange) DeepCopyInto(out *ConfigChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigChange.
+func (in *ConfigChange) DeepCopy() *ConfigChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPreview) DeepCopyInto(out *ConfigPreview) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ConfigChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPreview.
+func (in *ConfigPreview) DeepCopy() *ConfigPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectorSetMemberStatus) DeepCopyInto(out *ConnectorSetMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorSetMemberStatus.
+func (in *ConnectorSetMemberStatus) DeepCopy() *ConnectorSetMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectorSetMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectorSetRolloutStatus) DeepCopyInto(out *ConnectorSetRolloutStatus) {
+	*out = *in
+	if in.Updating != nil {
+		in, out := &in.Updating, &out.Updating
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorSetRolloutStatus.
+func (in *ConnectorSetRolloutStatus) DeepCopy() *ConnectorSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectorSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadLetterQueueSpec) DeepCopyInto(out *DeadLetterQueueSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadLetterQueueSpec.
+func (in *DeadLetterQueueSpec) DeepCopy() *DeadLetterQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadLetterQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebeziumConnector) DeepCopyInto(out *DebeziumConnector) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnector.
@@ -83,6 +191,119 @@ func (in *DebeziumConnectorList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorSet) DeepCopyInto(out *DebeziumConnectorSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorSet.
+func (in *DebeziumConnectorSet) DeepCopy() *DebeziumConnectorSet {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebeziumConnectorSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorSetList) DeepCopyInto(out *DebeziumConnectorSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DebeziumConnectorSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorSetList.
+func (in *DebeziumConnectorSetList) DeepCopy() *DebeziumConnectorSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebeziumConnectorSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorSetSpec) DeepCopyInto(out *DebeziumConnectorSetSpec) {
+	*out = *in
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]NamedConnectorSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorSetSpec.
+func (in *DebeziumConnectorSetSpec) DeepCopy() *DebeziumConnectorSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorSetStatus) DeepCopyInto(out *DebeziumConnectorSetStatus) {
+	*out = *in
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]ConnectorSetMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ConnectorSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorSetStatus.
+func (in *DebeziumConnectorSetStatus) DeepCopy() *DebeziumConnectorSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebeziumConnectorSpec) DeepCopyInto(out *DebeziumConnectorSpec) {
 	*out = *in
@@ -93,6 +314,45 @@ func (in *DebeziumConnectorSpec) DeepCopyInto(out *DebeziumConnectorSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ConfigSecretRef != nil {
+		in, out := &in.ConfigSecretRef, &out.ConfigSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TemplateRefs != nil {
+		in, out := &in.TemplateRefs, &out.TemplateRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ErrorHandling != nil {
+		in, out := &in.ErrorHandling, &out.ErrorHandling
+		*out = new(ErrorHandlingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProducerOverrides != nil {
+		in, out := &in.ProducerOverrides, &out.ProducerOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConsumerOverrides != nil {
+		in, out := &in.ConsumerOverrides, &out.ConsumerOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorSpec.
@@ -108,6 +368,80 @@ func (in *DebeziumConnectorSpec) DeepCopy() *DebeziumConnectorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebeziumConnectorStatus) DeepCopyInto(out *DebeziumConnectorStatus) {
 	*out = *in
+	if in.LastValidationTime != nil {
+		in, out := &in.LastValidationTime, &out.LastValidationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(MigrationStatus)
+		**out = **in
+	}
+	if in.RecentFailures != nil {
+		in, out := &in.RecentFailures, &out.RecentFailures
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastFailedRestartTime != nil {
+		in, out := &in.LastFailedRestartTime, &out.LastFailedRestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]TaskStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastForceReplaceTime != nil {
+		in, out := &in.LastForceReplaceTime, &out.LastForceReplaceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ConfigSources != nil {
+		in, out := &in.ConfigSources, &out.ConfigSources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Logger != nil {
+		in, out := &in.Logger, &out.Logger
+		*out = new(LoggerStatus)
+		**out = **in
+	}
+	if in.ConfigPreview != nil {
+		in, out := &in.ConfigPreview, &out.ConfigPreview
+		*out = new(ConfigPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CreatedTime != nil {
+		in, out := &in.CreatedTime, &out.CreatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeToRunning != nil {
+		in, out := &in.TimeToRunning, &out.TimeToRunning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]StateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorStatus.
@@ -119,3 +453,216 @@ func (in *DebeziumConnectorStatus) DeepCopy() *DebeziumConnectorStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorTemplate) DeepCopyInto(out *DebeziumConnectorTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorTemplate.
+func (in *DebeziumConnectorTemplate) DeepCopy() *DebeziumConnectorTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebeziumConnectorTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorTemplateList) DeepCopyInto(out *DebeziumConnectorTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DebeziumConnectorTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorTemplateList.
+func (in *DebeziumConnectorTemplateList) DeepCopy() *DebeziumConnectorTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebeziumConnectorTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebeziumConnectorTemplateSpec) DeepCopyInto(out *DebeziumConnectorTemplateSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebeziumConnectorTemplateSpec.
+func (in *DebeziumConnectorTemplateSpec) DeepCopy() *DebeziumConnectorTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DebeziumConnectorTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorHandlingSpec) DeepCopyInto(out *ErrorHandlingSpec) {
+	*out = *in
+	if in.RetryTimeoutMillis != nil {
+		in, out := &in.RetryTimeoutMillis, &out.RetryTimeoutMillis
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetryDelayMaxMillis != nil {
+		in, out := &in.RetryDelayMaxMillis, &out.RetryDelayMaxMillis
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeadLetterQueue != nil {
+		in, out := &in.DeadLetterQueue, &out.DeadLetterQueue
+		*out = new(DeadLetterQueueSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandlingSpec.
+func (in *ErrorHandlingSpec) DeepCopy() *ErrorHandlingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorHandlingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerStatus) DeepCopyInto(out *LoggerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerStatus.
+func (in *LoggerStatus) DeepCopy() *LoggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedConnectorSpec) DeepCopyInto(out *NamedConnectorSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedConnectorSpec.
+func (in *NamedConnectorSpec) DeepCopy() *NamedConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateTransition) DeepCopyInto(out *StateTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateTransition.
+func (in *StateTransition) DeepCopy() *StateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(StateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
+func (in *TaskStatus) DeepCopy() *TaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
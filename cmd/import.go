@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/controller"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// runImport implements the import subcommand: it generates DebeziumConnector manifests for the
+// connectors on a Kafka Connect host, and returns the process exit code.
+func runImport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var opts controller.ImportOptions
+	var outputDir string
+	var sensitiveKeyPattern string
+	var timeout time.Duration
+	fs.StringVar(&opts.Host, "host", "", "Kafka Connect REST endpoint to import connectors from, e.g. http://connect:8083.")
+	fs.StringVar(&opts.BasePath, "connect-base-path", "", "Path prefix the Kafka Connect REST API is served below on the host.")
+	fs.StringVar(&opts.Namespace, "namespace", "default", "Namespace of the generated resources.")
+	fs.StringVar(&opts.NamePrefix, "name-prefix", "", "Only import connectors whose names start with this prefix.")
+	fs.BoolVar(&opts.IncludeSecretValues, "include-secret-values", false,
+		"Write sensitive config values into the generated Secrets instead of REPLACE_ME placeholders.")
+	fs.StringVar(&outputDir, "output-dir", "", "Directory to write one <name>.yaml manifest per connector to. Manifests are printed when empty.")
+	fs.StringVar(&sensitiveKeyPattern, "redact-key-pattern", util.DefaultSensitiveKeyPattern,
+		"Regular expression matching config keys whose values are moved into a Secret.")
+	fs.DurationVar(&timeout, "timeout", time.Minute, "Timeout for reading the connectors from Kafka Connect.")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: manager import --host <url> [flags]")
+		fmt.Fprintln(stderr, "Generates DebeziumConnector manifests for the connectors on a Kafka Connect host.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if opts.Host == "" {
+		fmt.Fprintln(stderr, "--host is required")
+		fs.Usage()
+		return 2
+	}
+	if err := util.SetSensitiveKeyPattern(sensitiveKeyPattern); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	imported, err := controller.ImportConnectors(ctx, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if outputDir != "" {
+		paths, err := controller.WriteImportedConnectors(outputDir, imported)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		for _, path := range paths {
+			fmt.Fprintln(stdout, path)
+		}
+		return 0
+	}
+	for i, c := range imported {
+		data, err := c.Manifest()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		if i > 0 {
+			fmt.Fprintln(stdout, "---")
+		}
+		fmt.Fprint(stdout, string(data))
+	}
+	return 0
+}
@@ -22,13 +22,22 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -37,7 +46,10 @@ import (
 
 	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
 	"github.com/oleksandrfrolov95/debezium-operator/internal/controller"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/kafka"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/operatorconfig"
 	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -53,11 +65,76 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var tlsMinVersion string
+	var tlsCipherSuites string
+	var webhookCertExtraSANs string
+	var webhookCertKey string
+	var webhookCASecret string
+	var webhookFailurePolicy string
+	var webhookNamespaceSelector string
+	var configHistoryLimit int
+	var webhookValidationTimeout time.Duration
+	var webhookBestEffort bool
+	var disableRemoteValidation bool
+	var webhookCacheTTL time.Duration
+	var webhookCacheSize int
+	var conflictRulesFile string
+	var warnOnTopicPrefixCollision bool
+	var enableImpersonation bool
+	var pauseReconciliation bool
+	var deepCheckEvery int
+	var restartUnassignedAfter time.Duration
+	var restartFailedCooldown time.Duration
+	var hostFailureThreshold int
+	var hostBreakerCooldown time.Duration
+	var maxConcurrentReconciles int
+	var maxConcurrentHostOperations int
+	var normalizeListValues bool
+	var normalizeTypedValues bool
+	var configSizeLimit int
+	var rejectOversizedConfig bool
+	var operationTimeout time.Duration
+	var createConfirmTimeout time.Duration
+	var probeSchemaRegistry bool
+	var schemaRegistryProbeTimeout time.Duration
+	var schemaRegistryProbeHosts string
+	var connectRequestTimeout time.Duration
+	var connectMaxIdleConns int
+	var connectMaxIdleConnsPerHost int
+	var connectMaxConnsPerHost int
+	var connectIdleConnTimeout time.Duration
+	var connectKeepAlive time.Duration
+	var connectBasePath string
+	var configEnvPrefix string
+	var stripConfigKeyPrefixes string
+	var sensitiveKeyPattern string
+	var detectOrphans bool
+	var pruneOrphans bool
+	var orphanNamePrefix string
+	var orphanDetectionInterval time.Duration
+	var kafkaBootstrapServers string
+	var kafkaSASLMechanism string
+	var kafkaTLS bool
+	var createMissingTopics bool
+	var printVersion bool
+	var manageNetworkPolicy bool
+	var networkPolicyName string
+	var operatorDeploymentName string
+	var watchLabelSelector string
+	var requireManagedTag bool
+	var stateChangeEvents bool
+	var referencedSecretsOnly bool
+	var stateChangeWebhookURL string
+	var configFile string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -66,11 +143,178 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&tlsMinVersion, "tls-min-version", "1.2",
+		"Minimum TLS version of the webhook and secure metrics servers: 1.0, 1.1, 1.2 or 1.3.")
+	flag.StringVar(&tlsCipherSuites, "tls-cipher-suites", "",
+		"Comma-separated list of allowed TLS 1.2 cipher suites for the webhook and secure metrics servers, "+
+			"e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Go's defaults are used when empty.")
+	flag.StringVar(&webhookCertExtraSANs, "webhook-cert-extra-sans", "",
+		"Comma-separated DNS names and IP addresses added to the generated webhook certificate, in addition to the forms of the webhook Service name.")
+	flag.StringVar(&webhookCertKey, "webhook-cert-key", "rsa-2048",
+		"Key of the generated webhook certificate: rsa-2048, rsa-3072, rsa-4096, ecdsa-p256 or ecdsa-p384.")
+	flag.StringVar(&webhookCASecret, "webhook-ca-secret", "",
+		"Name of a Secret in the operator namespace holding a CA certificate and key (tls.crt and tls.key, or ca.crt and ca.key) "+
+			"that signs the webhook certificate. The certificate is self-signed when empty.")
+	flag.StringVar(&webhookFailurePolicy, "webhook-failure-policy", "",
+		"Failure policy set on the validating webhook at startup: Fail rejects DebeziumConnector changes while the webhook "+
+			"is unavailable, Ignore admits them unvalidated. The configured policy is kept when empty.")
+	flag.StringVar(&webhookNamespaceSelector, "webhook-namespace-selector", "",
+		"Label selector, e.g. debezium.io/validation!=disabled, set as the validating webhook's namespaceSelector at startup. "+
+			"The configured selector is kept when empty.")
+	flag.IntVar(&configHistoryLimit, "config-history-limit", 5,
+		"Number of applied connector configs to retain per connector. Set to 0 to disable config history.")
+	flag.DurationVar(&webhookValidationTimeout, "webhook-validation-timeout", apiv1alpha1.DefaultRemoteValidationTimeout,
+		"Timeout for the webhook's call to the Debezium config validation endpoint.")
+	flag.BoolVar(&webhookBestEffort, "webhook-validation-best-effort", false,
+		"If set, remote validation timeouts are returned as admission warnings instead of rejecting the request")
+	flag.BoolVar(&disableRemoteValidation, "disable-remote-validation", false,
+		"If set, the webhook does not call the Debezium validate endpoint and validates configs against the bundled schema of their connector class instead.")
+	flag.DurationVar(&webhookCacheTTL, "webhook-validation-cache-ttl", 30*time.Second,
+		"How long the webhook reuses a remote validation result for an identical config. Set to 0 to disable caching.")
+	flag.IntVar(&webhookCacheSize, "webhook-validation-cache-size", 256,
+		"Maximum number of remote validation results kept by the webhook.")
+	flag.StringVar(&conflictRulesFile, "config-conflict-rules-file", "",
+		"Path to a YAML file, typically mounted from a ConfigMap, with conflict rules added to the built-in set.")
+	flag.BoolVar(&warnOnTopicPrefixCollision, "warn-on-topic-prefix-collision", false,
+		"If set, connectors sharing a topic prefix with another connector in the namespace are admitted with a warning instead of rejected.")
+	flag.BoolVar(&enableImpersonation, "enable-impersonation", false,
+		"If set, referenced Secrets and ConfigMaps are read as the service account named by the "+
+			"debezium.io/service-account annotation of each DebeziumConnector.")
+	flag.BoolVar(&pauseReconciliation, "pause-reconciliation", false,
+		"If set, the operator reports connector status but does not create, update or delete connectors.")
+	flag.DurationVar(&operationTimeout, "connector-operation-timeout", 30*time.Second,
+		"Deadline for creating or updating a connector; slower operations are reported in the CreateTimedOut or UpdateTimedOut condition.")
+	flag.DurationVar(&createConfirmTimeout, "create-confirm-timeout", 0,
+		"If set, poll the status of a created connector for up to this long until it is RUNNING, and report the outcome "+
+			"in the CreateConfirmed condition. Zero does not wait for created connectors to start.")
+	flag.BoolVar(&probeSchemaRegistry, "probe-schema-registry", false,
+		"If set, check that the schema registries in key.converter.schema.registry.url and value.converter.schema.registry.url "+
+			"answer, warn on admission when they do not, and report the result in the SchemaRegistryReachable condition.")
+	flag.DurationVar(&schemaRegistryProbeTimeout, "schema-registry-probe-timeout", apiv1alpha1.DefaultSchemaRegistryProbeTimeout,
+		"Deadline for probing all schema registries of a connector.")
+	flag.StringVar(&schemaRegistryProbeHosts, "schema-registry-probe-hosts", "",
+		"Comma-separated hosts the schema registry probe may send requests to; entries starting with a dot also allow "+
+			"their subdomains. Empty probes any host a connector config names.")
+	flag.DurationVar(&connectRequestTimeout, "connect-request-timeout", util.DefaultHTTPClientOptions.Timeout,
+		"Timeout of a single request to the Kafka Connect REST API.")
+	flag.IntVar(&connectMaxIdleConns, "connect-max-idle-conns", util.DefaultHTTPClientOptions.MaxIdleConns,
+		"Maximum number of idle connections to all Kafka Connect hosts. Zero means no limit.")
+	flag.IntVar(&connectMaxIdleConnsPerHost, "connect-max-idle-conns-per-host", util.DefaultHTTPClientOptions.MaxIdleConnsPerHost,
+		"Maximum number of idle connections kept per Kafka Connect host. Should cover the concurrent requests to one host so connections are reused.")
+	flag.IntVar(&connectMaxConnsPerHost, "connect-max-conns-per-host", 0,
+		"Maximum number of connections per Kafka Connect host; further requests wait for a free connection. Zero means no limit.")
+	flag.DurationVar(&connectIdleConnTimeout, "connect-idle-conn-timeout", util.DefaultHTTPClientOptions.IdleConnTimeout,
+		"How long an idle connection to a Kafka Connect host is kept open.")
+	flag.DurationVar(&connectKeepAlive, "connect-keep-alive", util.DefaultHTTPClientOptions.KeepAlive,
+		"TCP keep-alive period of connections to Kafka Connect hosts.")
+	flag.StringVar(&connectBasePath, "connect-base-path", "",
+		"Path prefix the Kafka Connect REST API is served below on the Debezium hosts, e.g. /kafka-connect behind a gateway.")
+	flag.IntVar(&deepCheckEvery, "deep-check-every", 10,
+		"While a connector's spec is unchanged and it is RUNNING, compare its config with the Debezium host "+
+			"only every N reconciles. Values below 2 compare it on every reconcile.")
+	flag.DurationVar(&restartUnassignedAfter, "restart-unassigned-after", 0,
+		"If set, restart connectors that stay UNASSIGNED for this long. Zero disables restarts.")
+	flag.DurationVar(&restartFailedCooldown, "restart-failed-cooldown", 5*time.Minute,
+		"Minimum time between restarts of a connector that is FAILED, or has FAILED tasks, although its config matches. Zero disables restarts.")
+	flag.IntVar(&hostFailureThreshold, "host-failure-threshold", 5,
+		"Suspend requests to a Debezium host after this many consecutive requests failed with a connection error "+
+			"or a 502, 503 or 504 response, until a probe request succeeds. Zero never suspends requests.")
+	flag.DurationVar(&hostBreakerCooldown, "host-breaker-cooldown", 30*time.Second,
+		"Time requests to a failing Debezium host are suspended before a probe request is sent.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Number of DebeziumConnectors reconciled at the same time.")
+	flag.IntVar(&maxConcurrentHostOperations, "max-concurrent-host-operations", 0,
+		"Maximum number of requests that create, update, pause, restart or delete connectors in flight to each Debezium host, "+
+			"independently of --max-concurrent-reconciles. Zero means no limit.")
+	flag.BoolVar(&normalizeListValues, "normalize-list-values", false,
+		"If set, comma-separated list values such as table.include.list are compared ignoring whitespace "+
+			"and, for include and exclude lists, item order when detecting config drift.")
+	flag.BoolVar(&normalizeTypedValues, "normalize-typed-values", false,
+		"If set, boolean and numeric config values such as tasks.max are normalized to a canonical form, e.g. "+
+			"TRUE to true or 1000.0 to 1000, before they are sent and when detecting config drift.")
+	flag.IntVar(&configSizeLimit, "config-size-limit", apiv1alpha1.DefaultConfigSizeLimit,
+		"Message size limit in bytes of the Kafka Connect config topic. Connectors whose serialized config approaches it are reported in the ConfigSize condition.")
+	flag.BoolVar(&rejectOversizedConfig, "reject-oversized-config", false,
+		"If set, the webhook rejects connectors whose serialized config exceeds --config-size-limit.")
+	flag.StringVar(&stripConfigKeyPrefixes, "strip-config-key-prefixes", "",
+		"Comma-separated config key prefixes, e.g. operator., whose keys are kept in the resource but not sent to the Debezium host.")
+	flag.StringVar(&configEnvPrefix, "config-env-prefix", "",
+		"If set, ${env:VAR} tokens in connector config are replaced with operator environment variables starting with this prefix.")
+	flag.StringVar(&sensitiveKeyPattern, "redact-key-pattern", util.DefaultSensitiveKeyPattern,
+		"Regular expression matching config keys whose values are masked in logs, events, conditions and config history.")
+	flag.BoolVar(&detectOrphans, "detect-orphans", false,
+		"If set, periodically report connectors on managed Debezium hosts that have no DebeziumConnector resource.")
+	flag.BoolVar(&pruneOrphans, "prune-orphans", false,
+		"If set together with --detect-orphans, delete orphaned connectors. Requires --orphan-name-prefix.")
+	flag.StringVar(&orphanNamePrefix, "orphan-name-prefix", "",
+		"Only connectors whose names start with this prefix are considered for orphan detection and pruning.")
+	flag.DurationVar(&orphanDetectionInterval, "orphan-detection-interval", 10*time.Minute,
+		"Interval between orphan detection runs.")
+	flag.BoolVar(&manageNetworkPolicy, "manage-network-policy", false,
+		"If set, maintain a NetworkPolicy allowing the operator pods egress to all Debezium hosts, the Kafka bootstrap "+
+			"servers, Vault, the state change webhook, probed schema registries, DNS and the API server.")
+	flag.StringVar(&networkPolicyName, "network-policy-name", "debezium-operator-egress",
+		"Name of the NetworkPolicy managed with --manage-network-policy.")
+	flag.StringVar(&operatorDeploymentName, "operator-deployment-name", "debezium-operator",
+		"Name of the operator Deployment, which selects the pods of and owns the managed NetworkPolicy.")
+	flag.StringVar(&kafkaBootstrapServers, "kafka-bootstrap-servers", "",
+		"Comma-separated Kafka brokers used to check that the topics a connector refers to exist before it is created. "+
+			"Requires an operator built with -tags kafka.")
+	flag.StringVar(&kafkaSASLMechanism, "kafka-sasl-mechanism", "",
+		"SASL mechanism for the Kafka brokers: PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512. The credentials are read from "+
+			"the KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD environment variables.")
+	flag.BoolVar(&kafkaTLS, "kafka-tls", false, "Connect to the Kafka brokers with TLS.")
+	flag.BoolVar(&createMissingTopics, "create-missing-topics", false,
+		"Create missing topics on the Kafka brokers instead of waiting for them before creating a connector.")
+	flag.StringVar(&watchLabelSelector, "watch-label-selector", "",
+		"Label selector, e.g. partition=a, limiting the DebeziumConnectors this instance reconciles, "+
+			"to partition connectors across operator instances. All connectors are reconciled when empty.")
+	flag.BoolVar(&requireManagedTag, "require-managed-tag", false,
+		"Tag created connectors with operator.managed=true, and refuse to update or delete connectors on the Debezium host without the tag.")
+	flag.BoolVar(&stateChangeEvents, "state-change-events", false,
+		"Emit a StateChanged event for each connector or task state change observed while reconciling.")
+	flag.StringVar(&stateChangeWebhookURL, "state-change-webhook-url", "",
+		"URL, e.g. a Slack incoming webhook, that receives a JSON post for each connector or task state change. "+
+			"Posts are best effort and never delay reconciles.")
+	flag.BoolVar(&referencedSecretsOnly, "referenced-secrets-only", false,
+		"If set, the operator watches only the Secrets DebeziumConnectors reference in spec.configSecretRef, each by name, "+
+			"and Secrets in its own namespace, so it needs no cluster-wide access to Secrets.")
+	flag.StringVar(&configFile, "config", "",
+		"YAML file with operator settings keyed by flag name. Flags given on the command line take precedence. "+
+			"The file is reloaded when it changes; see the README for the settings applied without a restart.")
+	flag.BoolVar(&printVersion, "version", false, "Print the operator version and exit.")
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if printVersion {
+		fmt.Println(version.String())
+		os.Exit(0)
+	}
+
 	ctrllog.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	setupLog.Info("starting debezium-operator", version.KeysAndValues()...)
+
+	// Settings from the config file fill in the flags not given on the command line.
+	configFlags := operatorconfig.NewFlags(flag.CommandLine)
+	var fileConfig *operatorconfig.Config
+	if configFile != "" {
+		var err error
+		if fileConfig, err = operatorconfig.Load(configFile); err != nil {
+			setupLog.Error(err, "invalid --config")
+			os.Exit(1)
+		}
+		if _, err := configFlags.Apply(fileConfig); err != nil {
+			setupLog.Error(err, "invalid --config")
+			os.Exit(1)
+		}
+		setupLog.Info("loaded operator config", "path", configFile)
+	}
+
+	if err := util.SetSensitiveKeyPattern(sensitiveKeyPattern); err != nil {
+		setupLog.Error(err, "invalid --redact-key-pattern")
+		os.Exit(1)
+	}
 
 	// Directory where cert files will be stored.
 	const certDir = "/tmp/certs"
@@ -79,18 +323,34 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Get the webhook service name and namespace from environment variables.
-	serviceName := os.Getenv("WEBHOOK_SERVICE_NAME")
-	if serviceName == "" {
-		serviceName = "debezium-operator"
-	}
-	namespace := os.Getenv("POD_NAMESPACE")
-	if namespace == "" {
-		namespace = "debezium-operator-ns"
+	// Resolve the webhook service name and namespace; a certificate for the wrong name would fail
+	// TLS verification by the API server.
+	serviceName, namespace, err := util.ResolveWebhookService(os.Getenv, util.ServiceAccountNamespaceFile)
+	if err != nil {
+		setupLog.Error(err, "unable to determine the webhook service")
+		os.Exit(1)
 	}
 	// Build the common name.
 	commonName := fmt.Sprintf("%s.%s.svc", serviceName, namespace)
 	fmt.Printf("Using commonName: %s\n", commonName)
+	// The webhook may be reached by any form of the service name, or by extra names and IPs.
+	certKey, err := util.ParseCertKey(webhookCertKey)
+	if err != nil {
+		setupLog.Error(err, "invalid --webhook-cert-key")
+		os.Exit(1)
+	}
+	certHosts := append(util.ServiceDNSNames(serviceName, namespace), strings.Split(webhookCertExtraSANs, ",")...)
+	var webhookPolicy util.WebhookPolicy
+	if webhookPolicy.FailurePolicy, err = util.ParseFailurePolicy(webhookFailurePolicy); err != nil {
+		setupLog.Error(err, "invalid --webhook-failure-policy")
+		os.Exit(1)
+	}
+	if webhookNamespaceSelector != "" {
+		if webhookPolicy.NamespaceSelector, err = metav1.ParseToLabelSelector(webhookNamespaceSelector); err != nil {
+			setupLog.Error(err, "invalid --webhook-namespace-selector")
+			os.Exit(1)
+		}
+	}
 
 	// Setup TLS options: disable HTTP/2 if not enabled.
 	disableHTTP2 := func(c *tls.Config) {
@@ -101,6 +361,12 @@ func main() {
 	if !enableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
+	tlsVersionOpt, err := util.TLSConfigOption(tlsMinVersion, strings.Split(tlsCipherSuites, ","))
+	if err != nil {
+		setupLog.Error(err, "invalid TLS options")
+		os.Exit(1)
+	}
+	tlsOpts = append(tlsOpts, tlsVersionOpt)
 
 	// Create the webhook server with the specified certificate directory.
 	webhookServer := webhook.NewServer(webhook.Options{
@@ -110,17 +376,27 @@ func main() {
 	})
 
 	// Create the manager using ctrl.NewManager.
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	managerOptions := ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
+			TLSOpts:       tlsOpts,
 		},
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "7b7a467c.debezium",
-	})
+	}
+	if referencedSecretsOnly {
+		// The shared informer watches only the operator's own Secrets, e.g. the webhook
+		// certificate. Other Secrets are read directly or through the referenced Secret cache.
+		managerOptions.Cache.ByObject = map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {Namespaces: map[string]cache.Config{namespace: {}}},
+		}
+		managerOptions.Client.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}
+	}
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -134,10 +410,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Use the direct client to load or generate the certificate.
+	// Use the direct client to load or generate the certificate, signed by the configured CA if any.
 	const secretName = "debezium-operator-tls"
 	ctx := context.Background()
-	if err := util.LoadOrGenerateCert(ctx, directClient, namespace, secretName, certDir, commonName); err != nil {
+	var certCA *util.CertAuthority
+	if webhookCASecret != "" {
+		if certCA, err = util.LoadCertAuthority(ctx, directClient, namespace, webhookCASecret); err != nil {
+			setupLog.Error(err, "failed to load webhook CA")
+			os.Exit(1)
+		}
+	}
+	if err := util.LoadOrGenerateCert(ctx, directClient, namespace, secretName, certDir, commonName, certKey, certCA, certHosts...); err != nil {
 		setupLog.Error(err, "failed to load or generate certificate")
 		os.Exit(1)
 	}
@@ -149,18 +432,259 @@ func main() {
 		setupLog.Error(err, "failed to update webhook caBundle")
 		os.Exit(1)
 	}
+	if err := util.UpdateWebhookPolicy(ctx, directClient, webhookName, vwcName, webhookPolicy); err != nil {
+		setupLog.Error(err, "failed to update webhook policy")
+		os.Exit(1)
+	}
+
+	// Reload the webhook certificate when the TLS secret is rotated.
+	if err := (&controller.CertSecretReconciler{
+		Client:                   mgr.GetClient(),
+		CertDir:                  certDir,
+		Secret:                   types.NamespacedName{Namespace: namespace, Name: secretName},
+		WebhookName:              webhookName,
+		WebhookConfigurationName: vwcName,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "webhook-cert")
+		os.Exit(1)
+	}
 
 	// Setup controllers.
-	if err = (&controller.DebeziumConnectorReconciler{
+	// One client is shared by the reconcilers and the webhook, so connections to the Kafka
+	// Connect hosts are pooled across reconciles.
+	connectClient := util.NewHTTPClient(util.HTTPClientOptions{
+		Timeout:             connectRequestTimeout,
+		MaxIdleConns:        connectMaxIdleConns,
+		MaxIdleConnsPerHost: connectMaxIdleConnsPerHost,
+		MaxConnsPerHost:     connectMaxConnsPerHost,
+		IdleConnTimeout:     connectIdleConnTimeout,
+		KeepAlive:           connectKeepAlive,
+	})
+	// The webhook caches remote validation results; the reconciler drops those of a host whose
+	// Connect version changed.
+	validationCache := apiv1alpha1.NewValidationCache(webhookCacheTTL, webhookCacheSize)
+	reconciler := &controller.DebeziumConnectorReconciler{
+		Client:                 mgr.GetClient(),
+		HTTPClient:             connectClient,
+		ConnectBasePath:        connectBasePath,
+		Recorder:               mgr.GetEventRecorderFor("debeziumconnector-controller"),
+		ConfigHistoryLimit:     configHistoryLimit,
+		PauseReconciliation:    pauseReconciliation,
+		DeepCheckEvery:         deepCheckEvery,
+		OperationTimeout:       operationTimeout,
+		CreateConfirmTimeout:   createConfirmTimeout,
+		RestartUnassignedAfter: restartUnassignedAfter,
+		RestartFailedCooldown:  restartFailedCooldown,
+		HostFailureThreshold:   hostFailureThreshold,
+		HostBreakerCooldown:    hostBreakerCooldown,
+		ConfigSizeLimit:        configSizeLimit,
+		RequireManagedTag:      requireManagedTag,
+		StateChangeEvents:      stateChangeEvents,
+	}
+	reconciler.MaxConcurrentReconciles = maxConcurrentReconciles
+	reconciler.MaxConcurrentHostOperations = maxConcurrentHostOperations
+	reconciler.ValidationCache = validationCache
+	if referencedSecretsOnly {
+		clientset, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			setupLog.Error(err, "unable to create Kubernetes clientset")
+			os.Exit(1)
+		}
+		reconciler.SecretCache = controller.NewReferencedSecretCache(clientset)
+	}
+	var registryProbeHosts []string
+	for _, host := range strings.Split(schemaRegistryProbeHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			registryProbeHosts = append(registryProbeHosts, host)
+		}
+	}
+	if probeSchemaRegistry {
+		reconciler.ProbeSchemaRegistry = true
+		reconciler.SchemaRegistryProbeTimeout = schemaRegistryProbeTimeout
+		reconciler.SchemaRegistryProbeHosts = registryProbeHosts
+	}
+	if enableImpersonation {
+		reconciler.RestConfig = cfg
+	}
+	if watchLabelSelector != "" {
+		if reconciler.WatchSelector, err = labels.Parse(watchLabelSelector); err != nil {
+			setupLog.Error(err, "invalid --watch-label-selector")
+			os.Exit(1)
+		}
+	}
+	if normalizeListValues {
+		reconciler.ListValuedKeys = util.DefaultListValuedKeys
+	}
+	if normalizeTypedValues {
+		reconciler.ValueTypes = util.DefaultValueTypes
+	}
+	// ${downward:field} references resolve to metadata of the operator pod.
+	reconciler.ConfigTransformers = append(reconciler.ConfigTransformers, controller.DownwardConfigTransformer{})
+	if stripConfigKeyPrefixes != "" {
+		var prefixes []string
+		for _, prefix := range strings.Split(stripConfigKeyPrefixes, ",") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+		reconciler.ConfigTransformers = append(reconciler.ConfigTransformers, controller.StripKeysConfigTransformer{Prefixes: prefixes})
+	}
+	if configEnvPrefix != "" {
+		reconciler.ConfigTransformers = append(reconciler.ConfigTransformers, controller.EnvConfigTransformer{Prefix: configEnvPrefix})
+	}
+	vaultResolver, err := controller.NewVaultResolverFromEnv()
+	if err != nil {
+		setupLog.Error(err, "unable to configure Vault resolver")
+		os.Exit(1)
+	}
+	if vaultResolver != nil {
+		setupLog.Info("resolving ${vault:path#key} config references", "address", vaultResolver.Address)
+		reconciler.ConfigResolvers = append(reconciler.ConfigResolvers, vaultResolver)
+	}
+	if kafkaBootstrapServers != "" {
+		admin, err := kafka.NewAdmin(kafka.Options{
+			BootstrapServers: strings.Split(kafkaBootstrapServers, ","),
+			SASLMechanism:    kafkaSASLMechanism,
+			Username:         os.Getenv("KAFKA_SASL_USERNAME"),
+			Password:         os.Getenv("KAFKA_SASL_PASSWORD"),
+			TLS:              kafkaTLS,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to configure the Kafka admin client")
+			os.Exit(1)
+		}
+		setupLog.Info("checking connector topics on Kafka", "bootstrapServers", kafkaBootstrapServers)
+		reconciler.TopicAdmin = admin
+		reconciler.CreateMissingTopics = createMissingTopics
+	} else if createMissingTopics {
+		setupLog.Error(nil, "--create-missing-topics requires --kafka-bootstrap-servers")
+		os.Exit(1)
+	}
+	if stateChangeWebhookURL != "" {
+		notifier := controller.NewWebhookNotifier(stateChangeWebhookURL, nil)
+		if err := mgr.Add(notifier); err != nil {
+			setupLog.Error(err, "unable to set up state change notifications")
+			os.Exit(1)
+		}
+		reconciler.StateNotifier = notifier
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DebeziumConnector")
+		os.Exit(1)
+	}
+	if err = (&controller.DebeziumConnectorSetReconciler{
 		Client:     mgr.GetClient(),
-		HTTPClient: mgr.GetHTTPClient(),
+		Recorder:   mgr.GetEventRecorderFor("debeziumconnectorset-controller"),
+		Connectors: reconciler,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "DebeziumConnector")
+		setupLog.Error(err, "unable to create controller", "controller", "DebeziumConnectorSet")
 		os.Exit(1)
 	}
 
+	// Optionally detect (and prune) connectors without a backing DebeziumConnector.
+	if detectOrphans {
+		if pruneOrphans && orphanNamePrefix == "" {
+			setupLog.Error(nil, "--prune-orphans requires --orphan-name-prefix to avoid deleting connectors managed by other tools")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controller.OrphanDetector{
+			Reconciler: reconciler,
+			Interval:   orphanDetectionInterval,
+			Prune:      pruneOrphans,
+			NamePrefix: orphanNamePrefix,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up orphan detection")
+			os.Exit(1)
+		}
+	}
+
+	// Apply the reloadable settings of the config file whenever it changes.
+	if configFile != "" {
+		watcher, err := operatorconfig.NewWatcher(configFile, func(ctx context.Context, next *operatorconfig.Config) {
+			logger := ctrllog.FromContext(ctx).WithName("operatorconfig")
+			changed, err := configFlags.Apply(next, operatorconfig.Reloadable...)
+			if err != nil {
+				logger.Error(err, "failed to apply operator config")
+				return
+			}
+			reconciler.UpdateSettings(func(r *controller.DebeziumConnectorReconciler) {
+				r.PauseReconciliation = pauseReconciliation
+				r.DeepCheckEvery = deepCheckEvery
+				r.OperationTimeout = operationTimeout
+				r.CreateConfirmTimeout = createConfirmTimeout
+				r.RestartUnassignedAfter = restartUnassignedAfter
+				r.RestartFailedCooldown = restartFailedCooldown
+				r.ConfigHistoryLimit = configHistoryLimit
+				r.StateChangeEvents = stateChangeEvents
+			})
+			logger.Info("Applied operator config", "changed", changed)
+			if restart := operatorconfig.RestartRequired(fileConfig, next); len(restart) > 0 {
+				logger.Info("Operator config changes take effect after a restart", "settings", restart)
+			}
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to watch --config")
+			os.Exit(1)
+		}
+		if err := mgr.Add(watcher); err != nil {
+			setupLog.Error(err, "unable to set up config reloading")
+			os.Exit(1)
+		}
+	}
+
+	// Optionally allow the operator egress to the Debezium hosts in locked-down clusters.
+	if manageNetworkPolicy {
+		var extraHosts []string
+		if kafkaBootstrapServers != "" {
+			extraHosts = append(extraHosts, strings.Split(kafkaBootstrapServers, ",")...)
+		}
+		if vaultResolver != nil {
+			extraHosts = append(extraHosts, vaultResolver.Address)
+		}
+		if stateChangeWebhookURL != "" {
+			extraHosts = append(extraHosts, stateChangeWebhookURL)
+		}
+		if err := (&controller.NetworkPolicyReconciler{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			Policy:           types.NamespacedName{Namespace: namespace, Name: networkPolicyName},
+			DeploymentName:   operatorDeploymentName,
+			ExtraHosts:       extraHosts,
+			SchemaRegistries: probeSchemaRegistry,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "networkpolicy")
+			os.Exit(1)
+		}
+	}
+
 	// Register the webhook for DebeziumConnector.
-	if err := (&apiv1alpha1.DebeziumConnector{}).SetupWebhookWithManager(mgr); err != nil {
+	validator := &apiv1alpha1.DebeziumConnectorValidator{
+		HTTPClient:                 connectClient,
+		ConnectBasePath:            connectBasePath,
+		RemoteValidationTimeout:    webhookValidationTimeout,
+		BestEffort:                 webhookBestEffort,
+		DisableRemoteValidation:    disableRemoteValidation,
+		ConflictRules:              apiv1alpha1.DefaultConfigConflictRules,
+		Client:                     mgr.GetClient(),
+		WarnOnTopicPrefixCollision: warnOnTopicPrefixCollision,
+		Cache:                      validationCache,
+		ProbeSchemaRegistry:        probeSchemaRegistry,
+		SchemaRegistryProbeTimeout: schemaRegistryProbeTimeout,
+		SchemaRegistryProbeHosts:   registryProbeHosts,
+		SubstituteEnv:              configEnvPrefix != "",
+	}
+	if rejectOversizedConfig {
+		validator.ConfigSizeLimit = configSizeLimit
+	}
+	if conflictRulesFile != "" {
+		rules, err := apiv1alpha1.LoadConfigConflictRules(conflictRulesFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load config conflict rules")
+			os.Exit(1)
+		}
+		validator.ConflictRules = append(slices.Clip(validator.ConflictRules), rules...)
+	}
+	if err := (&apiv1alpha1.DebeziumConnector{}).SetupWebhookWithManager(mgr, validator); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "DebeziumConnector")
 		os.Exit(1)
 	}
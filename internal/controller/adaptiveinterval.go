@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// nextReconcileInterval returns the interval to wait before polling the connector again: double
+// the current interval, capped at max, while the connector is stable, and base otherwise. The
+// interval never grows when max does not exceed base.
+func nextReconcileInterval(current, base, max time.Duration, stable bool) time.Duration {
+	if !stable || max <= base || current < base {
+		return base
+	}
+	if next := 2 * current; next < max {
+		return next
+	}
+	return max
+}
+
+// connectorStable reports whether dbc is Available and neither its applied config nor its
+// observed state changed since previous, and the reconcile did not change the connector.
+func connectorStable(dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, changed bool) bool {
+	if changed || !meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionAvailable) {
+		return false
+	}
+	return previous.ObservedGeneration == dbc.Status.ObservedGeneration &&
+		previous.ConfigHash == dbc.Status.ConfigHash &&
+		previous.ConnectorStatus == dbc.Status.ConnectorStatus &&
+		equality.Semantic.DeepEqual(previous.Tasks, dbc.Status.Tasks)
+}
+
+// recordReconcileInterval computes the interval until the next poll of dbc, records it in the
+// status and returns it.
+func recordReconcileInterval(dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, changed bool) time.Duration {
+	current := time.Duration(previous.ReconcileIntervalSeconds) * time.Second
+	interval := nextReconcileInterval(current, dbc.ReconcileInterval(), dbc.MaxReconcileInterval(), connectorStable(dbc, previous, changed))
+	dbc.Status.ReconcileIntervalSeconds = int32(interval / time.Second)
+	return interval
+}
+
+// resetReconcileInterval records the base interval after a failed reconcile, so polling a
+// recovered connector starts backing off from the beginning.
+func resetReconcileInterval(dbc *apiv1alpha1.DebeziumConnector) {
+	dbc.Status.ReconcileIntervalSeconds = int32(dbc.ReconcileInterval() / time.Second)
+}
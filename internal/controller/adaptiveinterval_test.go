@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Adaptive reconcile interval", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	DescribeTable("computes the next interval",
+		func(current time.Duration, stable bool, expected time.Duration) {
+			Expect(nextReconcileInterval(current, time.Minute, 5*time.Minute, stable)).To(Equal(expected))
+		},
+		Entry("first stable reconcile", time.Duration(0), true, time.Minute),
+		Entry("doubles while stable", 2*time.Minute, true, 4*time.Minute),
+		Entry("caps at the maximum", 4*time.Minute, true, 5*time.Minute),
+		Entry("stays at the maximum", 5*time.Minute, true, 5*time.Minute),
+		Entry("resets when not stable", 4*time.Minute, false, time.Minute),
+	)
+
+	It("does not grow without a maximum", func() {
+		Expect(nextReconcileInterval(time.Minute, time.Minute, 0, true)).To(Equal(time.Minute))
+	})
+
+	Context("when reconciling", func() {
+		var (
+			connect *fakeConnect
+			r       *DebeziumConnectorReconciler
+		)
+
+		BeforeEach(func() {
+			connect = newFakeConnect()
+			dbc := newTestDebeziumConnector(connect.URL)
+			dbc.Spec.ReconcileIntervalSeconds = 30
+			dbc.Spec.MaxReconcileIntervalSeconds = 100
+			connect.setConnector(dbc.Spec.Config, "RUNNING")
+			connect.tasks["inventory"] = []taskStatus{{ID: 0, State: "RUNNING"}}
+			r = &DebeziumConnectorReconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+				HTTPClient: connect.Client(),
+			}
+		})
+
+		AfterEach(func() {
+			connect.Close()
+		})
+
+		reconcileOnce := func() time.Duration {
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			latest := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			Expect(time.Duration(latest.Status.ReconcileIntervalSeconds) * time.Second).To(Equal(result.RequeueAfter))
+			return result.RequeueAfter
+		}
+
+		It("backs off while the connector is stable", func() {
+			Expect(reconcileOnce()).To(Equal(30 * time.Second))
+			Expect(reconcileOnce()).To(Equal(60 * time.Second))
+			Expect(reconcileOnce()).To(Equal(100 * time.Second))
+			Expect(reconcileOnce()).To(Equal(100 * time.Second))
+		})
+
+		It("does not write the status once the interval stopped growing", func() {
+			reconcileOnce()
+			reconcileOnce()
+			Expect(reconcileOnce()).To(Equal(100 * time.Second))
+			latest := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			resourceVersion := latest.ResourceVersion
+
+			Expect(reconcileOnce()).To(Equal(100 * time.Second))
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			Expect(latest.ResourceVersion).To(Equal(resourceVersion))
+		})
+
+		It("is not reconciled again by its own status updates", func() {
+			reconcileOnce()
+			old := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, old)).To(Succeed())
+			updated := old.DeepCopy()
+			updated.Status.ReconcileIntervalSeconds = 60
+			updated.ResourceVersion += "1"
+			for _, p := range r.connectorPredicates() {
+				if !p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}) {
+					return
+				}
+			}
+			Fail("a status update passes the predicates")
+		})
+
+		It("resets when a task fails", func() {
+			reconcileOnce()
+			Expect(reconcileOnce()).To(Equal(60 * time.Second))
+
+			connect.mu.Lock()
+			connect.tasks["inventory"] = []taskStatus{{ID: 0, State: "FAILED"}}
+			connect.mu.Unlock()
+			Expect(reconcileOnce()).To(Equal(30 * time.Second))
+		})
+
+		It("resets when the connector config drifted", func() {
+			reconcileOnce()
+			Expect(reconcileOnce()).To(Equal(60 * time.Second))
+
+			connect.mu.Lock()
+			connect.configs["inventory"]["snapshot.mode"] = "never"
+			connect.mu.Unlock()
+			Expect(reconcileOnce()).To(Equal(30 * time.Second))
+		})
+	})
+})
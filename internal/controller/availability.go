@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// availability derives the Available condition of dbc from the outcome of the reconcile and the
+// status it recorded. The connector is available when, in this order of precedence:
+//   - the reconcile succeeded (ReconcileFailed),
+//   - the config applied on the Debezium host is the desired config of the current generation
+//     (ConfigNotApplied),
+//   - the connector is RUNNING (ConnectorNotRunning),
+//   - it has tasks (NoTasks), and all of them are RUNNING (TasksNotRunning).
+func availability(dbc *apiv1alpha1.DebeziumConnector, configHash string, reconcileErr error) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               apiv1alpha1.ConditionAvailable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: dbc.Generation,
+	}
+	switch {
+	case reconcileErr != nil:
+		cond.Reason = "ReconcileFailed"
+		cond.Message = util.RedactText(reconcileErr.Error(), nil)
+	case dbc.Status.ObservedGeneration != dbc.Generation || dbc.Status.ConfigHash != configHash:
+		cond.Reason = "ConfigNotApplied"
+		cond.Message = "The desired config has not been applied on the Debezium host"
+	case dbc.Status.ConnectorStatus != "RUNNING":
+		cond.Reason = "ConnectorNotRunning"
+		cond.Message = fmt.Sprintf("Connector is %s", dbc.Status.ConnectorStatus)
+	case len(dbc.Status.Tasks) == 0:
+		cond.Reason = "NoTasks"
+		cond.Message = "Connector has no tasks"
+	default:
+		for _, task := range dbc.Status.Tasks {
+			if task.State != "RUNNING" {
+				cond.Reason = "TasksNotRunning"
+				cond.Message = fmt.Sprintf("Task %d is %s", task.ID, task.State)
+				return cond
+			}
+		}
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Healthy"
+		cond.Message = "Connector and all tasks are RUNNING with the desired config"
+	}
+	return cond
+}
+
+// recordAvailability sets the Available condition of dbc.
+func recordAvailability(dbc *apiv1alpha1.DebeziumConnector, configHash string, reconcileErr error) {
+	meta.SetStatusCondition(&dbc.Status.Conditions, availability(dbc, configHash, reconcileErr))
+}
+
+// retryAfterFailure reports a failed reconcile in the Available condition and returns the result
+// for retrying it. The error has already been logged by the caller.
+func (r *DebeziumConnectorReconciler) retryAfterFailure(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, err error) (ctrl.Result, error) {
+	recordAvailability(dbc, "", err)
+	resetReconcileInterval(dbc)
+	if updateErr := r.updateStatus(ctx, dbc); updateErr != nil {
+		log.FromContext(ctx).Error(updateErr, "failed to update DebeziumConnector status")
+	}
+	return retryResult(dbc, err)
+}
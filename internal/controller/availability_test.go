@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector availability", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	// healthy returns a connector whose status meets every criterion for the config hash "abc".
+	healthy := func() *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector("http://connect:8083")
+		dbc.Status.ObservedGeneration = dbc.Generation
+		dbc.Status.ConfigHash = "abc"
+		dbc.Status.ConnectorStatus = "RUNNING"
+		dbc.Status.Tasks = []apiv1alpha1.TaskStatus{{ID: 0, State: "RUNNING"}, {ID: 1, State: "RUNNING"}}
+		return dbc
+	}
+
+	DescribeTable("derives the Available condition",
+		func(mutate func(*apiv1alpha1.DebeziumConnector), reconcileErr error, status metav1.ConditionStatus, reason string) {
+			dbc := healthy()
+			mutate(dbc)
+			cond := availability(dbc, "abc", reconcileErr)
+			Expect(cond.Type).To(Equal(apiv1alpha1.ConditionAvailable))
+			Expect(cond.Status).To(Equal(status))
+			Expect(cond.Reason).To(Equal(reason))
+			Expect(cond.ObservedGeneration).To(Equal(dbc.Generation))
+		},
+		Entry("healthy", func(*apiv1alpha1.DebeziumConnector) {}, nil, metav1.ConditionTrue, "Healthy"),
+		Entry("reconcile failed", func(*apiv1alpha1.DebeziumConnector) {}, errors.New("connection refused"), metav1.ConditionFalse, "ReconcileFailed"),
+		Entry("spec changed", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Generation++ }, nil, metav1.ConditionFalse, "ConfigNotApplied"),
+		Entry("resolved config changed", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.ConfigHash = "old" }, nil, metav1.ConditionFalse, "ConfigNotApplied"),
+		Entry("connector failed", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.ConnectorStatus = "FAILED" }, nil, metav1.ConditionFalse, "ConnectorNotRunning"),
+		Entry("connector paused", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.ConnectorStatus = "PAUSED" }, nil, metav1.ConditionFalse, "ConnectorNotRunning"),
+		Entry("connector state unknown", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.ConnectorStatus = "UNKNOWN" }, nil, metav1.ConditionFalse, "ConnectorNotRunning"),
+		Entry("no tasks", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.Tasks = nil }, nil, metav1.ConditionFalse, "NoTasks"),
+		Entry("task failed", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.Tasks[1].State = "FAILED" }, nil, metav1.ConditionFalse, "TasksNotRunning"),
+		Entry("task unassigned", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.Tasks[0].State = "UNASSIGNED" }, nil, metav1.ConditionFalse, "TasksNotRunning"),
+		Entry("failed reconcile of a failed connector", func(dbc *apiv1alpha1.DebeziumConnector) { dbc.Status.ConnectorStatus = "FAILED" }, errors.New("boom"), metav1.ConditionFalse, "ReconcileFailed"),
+	)
+
+	It("names the first task that is not running", func() {
+		dbc := healthy()
+		dbc.Status.Tasks[1].State = "FAILED"
+		Expect(availability(dbc, "abc", nil).Message).To(Equal("Task 1 is FAILED"))
+	})
+
+	Context("when reconciling", func() {
+		var connect *fakeConnect
+
+		BeforeEach(func() {
+			connect = newFakeConnect()
+		})
+
+		AfterEach(func() {
+			connect.Close()
+		})
+
+		reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector) *metav1.Condition {
+			r := &DebeziumConnectorReconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+				HTTPClient: connect.Client(),
+			}
+			_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			latest := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			return meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionAvailable)
+		}
+
+		It("reports a connector with running tasks as available", func() {
+			dbc := newTestDebeziumConnector(connect.URL)
+			connect.setConnector(dbc.Spec.Config, "RUNNING")
+			connect.tasks["inventory"] = []taskStatus{{ID: 0, State: "RUNNING"}}
+
+			cond := reconcileOnce(dbc)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		It("reports a failed reconcile", func() {
+			dbc := newTestDebeziumConnector(connect.URL)
+			connect.Close()
+
+			cond := reconcileOnce(dbc)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("ReconcileFailed"))
+		})
+	})
+})
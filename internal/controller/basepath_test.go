@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connect base path", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		gateway *httptest.Server
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		// The gateway only serves Connect below /kafka-connect.
+		gateway = httptest.NewServer(http.StripPrefix("/kafka-connect", http.HandlerFunc(connect.serve)))
+	})
+
+	AfterEach(func() {
+		gateway.Close()
+		connect.Close()
+	})
+
+	reconcileOnce := func(basePath string) (*apiv1alpha1.DebeziumConnector, error) {
+		dbc := newTestDebeziumConnector(gateway.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:          fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:      gateway.Client(),
+			ConnectBasePath: basePath,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest, err
+	}
+
+	It("sends the REST calls below the base path", func() {
+		latest, err := reconcileOnce("/kafka-connect/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+	})
+
+	It("fails when the gateway needs a base path that is not configured", func() {
+		_, err := reconcileOnce("")
+		Expect(err).To(HaveOccurred())
+		Expect(connect.requests).To(BeEmpty())
+	})
+})
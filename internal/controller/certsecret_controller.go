@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// CertSecretReconciler keeps the webhook serving certificate in CertDir in sync with the TLS
+// Secret. The webhook server watches CertDir and reloads the certificate when the files change,
+// so a rotated Secret takes effect without restarting the operator.
+type CertSecretReconciler struct {
+	client.Client
+	// CertDir is the directory the webhook server loads tls.crt and tls.key from.
+	CertDir string
+	// Secret is the TLS Secret holding the webhook certificate.
+	Secret types.NamespacedName
+	// WebhookName and WebhookConfigurationName identify the webhook whose caBundle is updated
+	// after a rotation; the caBundle is left untouched when WebhookConfigurationName is empty.
+	WebhookName              string
+	WebhookConfigurationName string
+}
+
+// Reconcile writes a changed certificate to CertDir and updates the webhook caBundle.
+func (r *CertSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Keep serving the current certificate until a new Secret is created.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	changed, err := util.SyncCertFiles(r.CertDir, secret)
+	if err != nil {
+		logger.Error(err, "failed to write webhook certificate")
+		return ctrl.Result{}, err
+	}
+	if !changed {
+		return ctrl.Result{}, nil
+	}
+	logger.Info("Webhook certificate rotated; reloading", "secret", req.NamespacedName)
+
+	if r.WebhookConfigurationName != "" {
+		if err := util.UpdateWebhookCABundle(ctx, r.Client, r.WebhookName, r.WebhookConfigurationName, r.Secret.Namespace, r.Secret.Name); err != nil {
+			logger.Error(err, "failed to update webhook caBundle")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. It runs on every replica, since each
+// one serves the webhook from its own CertDir.
+func (r *CertSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	needLeaderElection := false
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhook-cert").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.Secret.Namespace && obj.GetName() == r.Secret.Name
+		}))).
+		WithOptions(controller.Options{NeedLeaderElection: &needLeaderElection}).
+		Complete(r)
+}
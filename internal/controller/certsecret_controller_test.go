@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("Webhook certificate reload", func() {
+	key := types.NamespacedName{Name: "debezium-operator-tls", Namespace: "debezium-operator-ns"}
+
+	// newCertSecret returns a TLS Secret holding a freshly generated self-signed certificate.
+	newCertSecret := func() *corev1.Secret {
+		dir := GinkgoT().TempDir()
+		Expect(util.GenerateSelfSignedCert(dir, "debezium-operator.debezium-operator-ns.svc", util.DefaultCertKey)).To(Succeed())
+		certData, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+		Expect(err).NotTo(HaveOccurred())
+		keyData, err := os.ReadFile(filepath.Join(dir, "tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Data:       map[string][]byte{"tls.crt": certData, "tls.key": keyData},
+			Type:       corev1.SecretTypeTLS,
+		}
+	}
+
+	It("rewrites the cert files so the certwatcher serves the rotated certificate", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		certDir := GinkgoT().TempDir()
+		original := newCertSecret()
+		r := &CertSecretReconciler{
+			Client:  fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(original).Build(),
+			CertDir: certDir,
+			Secret:  key,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		watcher, err := certwatcher.New(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		go func() { _ = watcher.Start(ctx) }()
+		served := func() []byte {
+			cert, err := watcher.GetCertificate(nil)
+			Expect(err).NotTo(HaveOccurred())
+			return cert.Certificate[0]
+		}
+		originalCert := served()
+
+		rotated := newCertSecret()
+		latest := &corev1.Secret{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		latest.Data = rotated.Data
+		Expect(r.Update(ctx, latest)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(filepath.Join(certDir, "tls.crt"))).To(Equal(rotated.Data["tls.crt"]))
+		Eventually(served).ShouldNot(Equal(originalCert))
+	})
+
+	It("leaves the files untouched when the secret is unchanged", func() {
+		certDir := GinkgoT().TempDir()
+		secret := newCertSecret()
+		changed, err := util.SyncCertFiles(certDir, secret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		changed, err = util.SyncCertFiles(certDir, secret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+})
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// isFailing reports whether the connector or one of its tasks is FAILED.
+func isFailing(status *connectorStatus) bool {
+	if status.Connector.State == "FAILED" {
+		return true
+	}
+	for _, task := range status.Tasks {
+		if task.State == "FAILED" {
+			return true
+		}
+	}
+	return false
+}
+
+// recordFailure counts a new failure when the connector or one of its tasks entered FAILED
+// since the last status check.
+func recordFailure(dbc *apiv1alpha1.DebeziumConnector, status *connectorStatus, now time.Time) {
+	failing := isFailing(status)
+	if failing && !dbc.Status.Failing {
+		dbc.Status.FailureCount++
+		if dbc.Spec.CircuitBreaker != nil {
+			dbc.Status.RecentFailures = append(dbc.Status.RecentFailures, metav1.NewTime(now))
+		}
+	}
+	dbc.Status.Failing = failing
+
+	// Only failures within the window count towards opening the circuit.
+	if dbc.Spec.CircuitBreaker == nil {
+		dbc.Status.RecentFailures = nil
+		return
+	}
+	window := time.Duration(dbc.Spec.CircuitBreaker.WindowSeconds) * time.Second
+	recent := dbc.Status.RecentFailures[:0]
+	for _, t := range dbc.Status.RecentFailures {
+		if now.Sub(t.Time) < window {
+			recent = append(recent, t)
+		}
+	}
+	dbc.Status.RecentFailures = recent
+}
+
+// reconcileCircuitBreaker counts connector failures and, with Spec.CircuitBreaker set, pauses
+// the connector once MaxFailures occurred within the window. An open circuit keeps the connector
+// paused until the ResetCircuitBreakerAnnotation is set.
+func (r *DebeziumConnectorReconciler) reconcileCircuitBreaker(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *connectorStatus, until time.Time) error {
+	recordFailure(dbc, status, time.Now())
+	breaker := dbc.Spec.CircuitBreaker
+	if breaker == nil {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)
+		return nil
+	}
+	if !r.mutationsAllowed(dbc, until) {
+		return nil
+	}
+
+	host := dbc.Spec.DebeziumHost
+	if meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionCircuitOpen) {
+		if dbc.Annotations[apiv1alpha1.ResetCircuitBreakerAnnotation] == "true" {
+			return r.resetCircuitBreaker(ctx, dbc, name)
+		}
+		// Keep the connector paused even if it was resumed behind the operator's back.
+		if status.Connector.State != "PAUSED" {
+			return r.changeConnectorState(ctx, host, name, "pause")
+		}
+		return nil
+	}
+
+	if len(dbc.Status.RecentFailures) < int(breaker.MaxFailures) {
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:               apiv1alpha1.ConditionCircuitOpen,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Closed",
+			Message:            fmt.Sprintf("%d of %d allowed failures within %ds", len(dbc.Status.RecentFailures), breaker.MaxFailures, breaker.WindowSeconds),
+			ObservedGeneration: dbc.Generation,
+		})
+		return nil
+	}
+
+	if err := r.changeConnectorState(ctx, host, name, "pause"); err != nil {
+		return fmt.Errorf("failed to pause connector: %w", err)
+	}
+	message := fmt.Sprintf("Connector failed %d times within %ds and was paused; set the %s annotation to resume it",
+		len(dbc.Status.RecentFailures), breaker.WindowSeconds, apiv1alpha1.ResetCircuitBreakerAnnotation)
+	r.recordEvent(dbc, corev1.EventTypeWarning, "CircuitOpen", "%s", message)
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionCircuitOpen,
+		Status:             metav1.ConditionTrue,
+		Reason:             "TooManyFailures",
+		Message:            message,
+		ObservedGeneration: dbc.Generation,
+	})
+	log.FromContext(ctx).Info("Circuit breaker opened; connector paused", "name", name, "failures", len(dbc.Status.RecentFailures))
+	return nil
+}
+
+// resetCircuitBreaker closes the circuit: it resumes the connector, restarts its failed tasks,
+// forgets past failures and removes the reset annotation.
+func (r *DebeziumConnectorReconciler) resetCircuitBreaker(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) error {
+	host := dbc.Spec.DebeziumHost
+	if err := r.changeConnectorState(ctx, host, name, "resume"); err != nil {
+		return fmt.Errorf("failed to resume connector: %w", err)
+	}
+	// Resuming does not restart failed tasks.
+	restartURL := r.connectURL(host, "/connectors/%s/restart?includeTasks=true&onlyFailed=true", name)
+	if err := r.sendJSON(ctx, http.MethodPost, restartURL, nil, nil); err != nil {
+		return fmt.Errorf("failed to restart failed tasks: %w", err)
+	}
+
+	if err := updateOnConflict(ctx, r.Client, dbc, func(o client.Object) {
+		annotations := o.GetAnnotations()
+		delete(annotations, apiv1alpha1.ResetCircuitBreakerAnnotation)
+		o.SetAnnotations(annotations)
+	}); err != nil {
+		return err
+	}
+	dbc.Status.RecentFailures = nil
+	dbc.Status.Failing = false
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionCircuitOpen,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Reset",
+		Message:            "Circuit was reset and the connector resumed",
+		ObservedGeneration: dbc.Generation,
+	})
+	r.recordEvent(dbc, corev1.EventTypeNormal, "CircuitReset", "Connector %s resumed after the circuit breaker was reset", name)
+	return nil
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Circuit breaker", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+		r        *DebeziumConnectorReconciler
+	)
+
+	setTaskState := func(state string) {
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		connect.tasks["inventory"] = []taskStatus{{ID: 0, State: state}}
+	}
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) {
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		recorder = record.NewFakeRecorder(10)
+		r = &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+	}
+
+	reconcileOnce := func() *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	withBreaker := func() *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.CircuitBreaker = &apiv1alpha1.CircuitBreakerSpec{MaxFailures: 2, WindowSeconds: 600}
+		return dbc
+	}
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	It("pauses the connector after too many failures within the window", func() {
+		newReconciler(withBreaker())
+
+		setTaskState("FAILED")
+		latest := reconcileOnce()
+		Expect(latest.Status.FailureCount).To(Equal(int32(1)))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)).To(BeTrue())
+
+		// A failure that persists is counted once.
+		latest = reconcileOnce()
+		Expect(latest.Status.FailureCount).To(Equal(int32(1)))
+
+		setTaskState("RUNNING")
+		reconcileOnce()
+		setTaskState("FAILED")
+		latest = reconcileOnce()
+		Expect(latest.Status.FailureCount).To(Equal(int32(2)))
+		Expect(latest.Status.RecentFailures).To(HaveLen(2))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("TooManyFailures"))
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/pause"}))
+		Expect(connect.states["inventory"]).To(Equal("PAUSED"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning CircuitOpen")))
+	})
+
+	It("keeps an open circuit paused until it is reset by annotation", func() {
+		dbc := withBreaker()
+		dbc.Status.Failing = true
+		dbc.Status.Conditions = []metav1.Condition{{
+			Type:               apiv1alpha1.ConditionCircuitOpen,
+			Status:             metav1.ConditionTrue,
+			Reason:             "TooManyFailures",
+			LastTransitionTime: metav1.Now(),
+		}}
+		newReconciler(dbc)
+		setTaskState("FAILED")
+
+		// The connector was resumed outside the operator.
+		reconcileOnce()
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/pause"}))
+
+		latest := reconcileOnce()
+		latest.Annotations = map[string]string{apiv1alpha1.ResetCircuitBreakerAnnotation: "true"}
+		Expect(r.Update(ctx, latest)).To(Succeed())
+
+		latest = reconcileOnce()
+		Expect(connect.mutations()).To(Equal([]string{
+			"PUT /connectors/inventory/pause",
+			"PUT /connectors/inventory/resume",
+			"POST /connectors/inventory/restart",
+		}))
+		Expect(latest.Annotations).NotTo(HaveKey(apiv1alpha1.ResetCircuitBreakerAnnotation))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("Reset"))
+		Expect(latest.Status.RecentFailures).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Normal CircuitReset")))
+	})
+
+	It("ignores failures outside the window", func() {
+		dbc := withBreaker()
+		dbc.Status.RecentFailures = []metav1.Time{metav1.NewTime(time.Now().Add(-time.Hour))}
+		newReconciler(dbc)
+
+		setTaskState("FAILED")
+		latest := reconcileOnce()
+		Expect(latest.Status.RecentFailures).To(HaveLen(1))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)).To(BeTrue())
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+
+	It("only counts failures without a circuit breaker", func() {
+		newReconciler(newTestDebeziumConnector(connect.URL))
+		connect.setConnector(map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"tasks.max":       "1",
+		}, "FAILED")
+
+		latest := reconcileOnce()
+		Expect(latest.Status.FailureCount).To(Equal(int32(1)))
+		Expect(latest.Status.RecentFailures).To(BeEmpty())
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCircuitOpen)).To(BeNil())
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+})
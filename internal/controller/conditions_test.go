@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status condition transitions", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	var (
+		connect *fakeConnect
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		dbc := newTestDebeziumConnector(connect.URL)
+		r = &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+
+		// Reconcile once and backdate the resulting conditions.
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.Conditions).NotTo(BeEmpty())
+		for i := range latest.Status.Conditions {
+			latest.Status.Conditions[i].LastTransitionTime = past
+		}
+		Expect(r.Status().Update(ctx, latest)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileAndGet := func() *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("keeps LastTransitionTime when no condition changes", func() {
+		latest := reconcileAndGet()
+		for _, cond := range latest.Status.Conditions {
+			Expect(cond.LastTransitionTime.Equal(&past)).To(BeTrue(), "condition %s moved", cond.Type)
+		}
+	})
+
+	It("re-validates the config when an override annotation changes", func() {
+		validations := func() int {
+			connect.mu.Lock()
+			defer connect.mu.Unlock()
+			n := 0
+			for _, req := range connect.requests {
+				if strings.Contains(req, "/config/validate") {
+					n++
+				}
+			}
+			return n
+		}
+		before := validations()
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		latest.Annotations = map[string]string{apiv1alpha1.ConfigOverrideAnnotationPrefix + "snapshot.mode": "never"}
+		Expect(r.Update(ctx, latest)).To(Succeed())
+
+		latest = reconcileAndGet()
+		Expect(validations()).To(Equal(before + 1))
+		Expect(latest.Status.ValidatedOverridesHash).To(Equal(latest.ConfigOverridesHash()))
+
+		reconcileAndGet()
+		Expect(validations()).To(Equal(before + 1))
+	})
+
+	It("moves LastTransitionTime only for the condition that changed", func() {
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		latest.Annotations = map[string]string{apiv1alpha1.ReconcilePausedAnnotation: "true"}
+		Expect(r.Update(ctx, latest)).To(Succeed())
+
+		latest = reconcileAndGet()
+		paused := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionPaused)
+		Expect(paused.Status).To(Equal(metav1.ConditionTrue))
+		Expect(paused.LastTransitionTime.After(past.Time)).To(BeTrue())
+
+		validated := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionValidated)
+		Expect(validated.Status).To(Equal(metav1.ConditionTrue))
+		Expect(validated.LastTransitionTime.Equal(&past)).To(BeTrue())
+	})
+})
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// requiredConfigKeys must be present in the resolved config of every connector.
+var requiredConfigKeys = []string{"name", "connector.class"}
+
+// ConfigResolver resolves values a connector's config refers to but does not contain, such
+// as keys of a Secret or values stored in Vault.
+type ConfigResolver interface {
+	// Resolve returns config with the values provided by the resolver applied. It must not
+	// modify config.
+	Resolve(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) (map[string]string, error)
+}
+
+// secretConfigResolver merges the keys of the connector's ConfigSecretRef under its config.
+type secretConfigResolver struct {
+	r *DebeziumConnectorReconciler
+}
+
+// Resolve implements ConfigResolver.
+func (s secretConfigResolver) Resolve(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) (map[string]string, error) {
+	ref := dbc.Spec.ConfigSecretRef
+	if ref == nil {
+		return config, nil
+	}
+	reader, err := s.r.configReader(dbc)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: dbc.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get config secret %s: %w", ref.Name, err)
+	}
+	resolved := make(map[string]string, len(secret.Data)+len(config))
+	for k, v := range secret.Data {
+		resolved[k] = string(v)
+	}
+	for k, v := range config {
+		resolved[k] = v
+	}
+	return resolved, nil
+}
+
+// resolveConfig builds the config to apply to the Debezium host: the config of the connector's
+// templates, overlaid by the keys of ConfigSecretRef, Spec.Config and then by annotation
+// overrides, with any configured resolvers and then transformers applied in order, the managed
+// tag added when RequireManagedTag is set, and typed values normalized.
+func (r *DebeziumConnectorReconciler) resolveConfig(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) (map[string]string, error) {
+	config, _, err := r.resolveLayeredConfig(ctx, dbc)
+	return config, err
+}
+
+// resolveLayeredConfig resolves the config like resolveConfig and also returns the layer each
+// key of the templates, the config Secret, the spec and the override annotations was taken from.
+func (r *DebeziumConnectorReconciler) resolveLayeredConfig(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) (map[string]string, map[string]string, error) {
+	config, fieldErrs := dbc.DesiredConfig()
+	if len(fieldErrs) > 0 {
+		return nil, nil, fieldErrs.ToAggregate()
+	}
+	sources := make(map[string]string, len(config))
+	for k := range config {
+		sources[k] = configSourceSpec
+	}
+	overrides, _ := dbc.ConfigOverrides()
+	for k := range overrides {
+		sources[k] = configSourceAnnotation
+	}
+
+	config, err := secretConfigResolver{r: r}.Resolve(ctx, dbc, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ref := dbc.Spec.ConfigSecretRef; ref != nil {
+		for k := range config {
+			if _, ok := sources[k]; !ok {
+				sources[k] = "secret/" + ref.Name
+			}
+		}
+	}
+	if config, err = r.mergeTemplates(ctx, dbc, config, sources); err != nil {
+		return nil, nil, err
+	}
+
+	for _, resolver := range r.ConfigResolvers {
+		resolved, err := resolver.Resolve(ctx, dbc, config)
+		if err != nil {
+			return nil, nil, err
+		}
+		config = resolved
+	}
+	config, err = r.transformConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.RequireManagedTag {
+		config[managedConfigKey] = "true"
+	}
+	config = r.normalizeValues(config)
+
+	for _, key := range requiredConfigKeys {
+		if _, ok := config[key]; !ok {
+			return nil, nil, fmt.Errorf("resolved config must include key %q", key)
+		}
+	}
+	return config, sources, nil
+}
+
+// connectorName returns the name of the connector on the Debezium host: the suffixed name of a
+// resolved name collision, or else the configured name, falling back to Spec.Config when the
+// full config cannot be resolved (e.g. its Secret was already deleted).
+func (r *DebeziumConnectorReconciler) connectorName(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) string {
+	if dbc.Status.EffectiveName != "" {
+		return dbc.Status.EffectiveName
+	}
+	if config, err := r.resolveConfig(ctx, dbc); err == nil {
+		return config["name"]
+	}
+	return dbc.Spec.Config["name"]
+}
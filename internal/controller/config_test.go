@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Config resolution", func() {
+	ctx := context.Background()
+	var dbc *apiv1alpha1.DebeziumConnector
+
+	BeforeEach(func() {
+		dbc = &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+			Spec: apiv1alpha1.DebeziumConnectorSpec{
+				DebeziumHost:    "http://connect:8083",
+				Config:          map[string]string{"tasks.max": "1"},
+				ConfigSecretRef: &corev1.LocalObjectReference{Name: "inventory-config"},
+			},
+		}
+	})
+
+	newReconciler := func(objs ...client.Object) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build(),
+		}
+	}
+
+	It("merges the config Secret under inline config and annotations", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: "default"},
+			Data: map[string][]byte{
+				"name":              []byte("inventory"),
+				"connector.class":   []byte("io.debezium.connector.mysql.MySqlConnector"),
+				"tasks.max":         []byte("4"),
+				"database.password": []byte("dbz"),
+				"snapshot.mode":     []byte("initial"),
+			},
+		}
+		dbc.Annotations = map[string]string{apiv1alpha1.ConfigOverrideAnnotationPrefix + "snapshot.mode": "never"}
+
+		config, err := newReconciler(secret).resolveConfig(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("name", "inventory"))
+		Expect(config).To(HaveKeyWithValue("database.password", "dbz"))
+		Expect(config).To(HaveKeyWithValue("tasks.max", "1"))
+		Expect(config).To(HaveKeyWithValue("snapshot.mode", "never"))
+	})
+
+	It("fails when the config Secret is missing", func() {
+		_, err := newReconciler().resolveConfig(ctx, dbc)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails when required keys are missing after the merge", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: "default"},
+			Data:       map[string][]byte{"name": []byte("inventory")},
+		}
+		_, err := newReconciler(secret).resolveConfig(ctx, dbc)
+		Expect(err).To(MatchError(ContainSubstring("connector.class")))
+	})
+})
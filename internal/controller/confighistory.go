@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// configHistoryKey is the ConfigMap data key holding the JSON-encoded history.
+const configHistoryKey = "history.json"
+
+// configHistoryEntry is a masked snapshot of a config applied to the Debezium host.
+type configHistoryEntry struct {
+	AppliedAt metav1.Time       `json:"appliedAt"`
+	Config    map[string]string `json:"config"`
+}
+
+// configHistoryName returns the name of the ConfigMap holding the history of a DebeziumConnector.
+func configHistoryName(dbc *apiv1alpha1.DebeziumConnector) string {
+	return dbc.Name + "-config-history"
+}
+
+// appendConfigHistory appends entry to history and prunes the oldest entries beyond limit.
+func appendConfigHistory(history []configHistoryEntry, entry configHistoryEntry, limit int) []configHistoryEntry {
+	history = append(history, entry)
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history
+}
+
+// recordConfigHistory stores a masked snapshot of the applied config in the connector's
+// history ConfigMap, keeping at most ConfigHistoryLimit entries.
+func (r *DebeziumConnectorReconciler) recordConfigHistory(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	if r.ConfigHistoryLimit <= 0 {
+		return nil
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configHistoryName(dbc),
+			Namespace: dbc.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		var history []configHistoryEntry
+		if raw, ok := cm.Data[configHistoryKey]; ok {
+			if err := json.Unmarshal([]byte(raw), &history); err != nil {
+				return fmt.Errorf("failed to decode config history: %w", err)
+			}
+		}
+		history = appendConfigHistory(history, configHistoryEntry{
+			AppliedAt: metav1.Now(),
+			Config:    util.MaskSensitiveConfig(config),
+		}, r.ConfigHistoryLimit)
+		data, err := json.Marshal(history)
+		if err != nil {
+			return fmt.Errorf("failed to encode config history: %w", err)
+		}
+		cm.Labels = util.StandardLabels(dbc.Labels)
+		cm.Data = map[string]string{configHistoryKey: string(data)}
+		return controllerutil.SetControllerReference(dbc, cm, r.Scheme())
+	})
+	return err
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("Config history", func() {
+	It("prunes the oldest entries beyond the limit", func() {
+		var history []configHistoryEntry
+		for i := 0; i < 5; i++ {
+			history = appendConfigHistory(history, configHistoryEntry{
+				Config: map[string]string{"tasks.max": fmt.Sprint(i)},
+			}, 3)
+		}
+		Expect(history).To(HaveLen(3))
+		Expect(history[0].Config["tasks.max"]).To(Equal("2"))
+		Expect(history[2].Config["tasks.max"]).To(Equal("4"))
+	})
+
+	It("stores masked snapshots in the history ConfigMap", func() {
+		ctx := context.Background()
+		dbc := &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "inventory",
+				Namespace: "default",
+				UID:       "uid",
+				Labels:    map[string]string{"team": "data"},
+			},
+		}
+		r := &DebeziumConnectorReconciler{
+			Client:             fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).Build(),
+			ConfigHistoryLimit: 2,
+		}
+
+		for _, tasks := range []string{"1", "2", "3"} {
+			Expect(r.recordConfigHistory(ctx, dbc, map[string]string{
+				"tasks.max":         tasks,
+				"database.password": "dbz",
+			})).To(Succeed())
+		}
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "inventory-config-history", Namespace: "default"}, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.Labels).To(HaveKeyWithValue("team", "data"))
+		Expect(cm.Labels).To(HaveKeyWithValue(util.ManagedByLabel, util.ManagedByValue))
+		var history []configHistoryEntry
+		Expect(json.Unmarshal([]byte(cm.Data[configHistoryKey]), &history)).To(Succeed())
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].Config["tasks.max"]).To(Equal("2"))
+		Expect(history[1].Config["database.password"]).NotTo(Equal("dbz"))
+	})
+})
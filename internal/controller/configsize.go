@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// configSizeLimit returns the config topic message size the resolved config is checked against.
+func (r *DebeziumConnectorReconciler) configSizeLimit() int {
+	if r.ConfigSizeLimit > 0 {
+		return r.ConfigSizeLimit
+	}
+	return apiv1alpha1.DefaultConfigSizeLimit
+}
+
+// configSizeCondition evaluates the serialized size of config against limit.
+func configSizeCondition(config map[string]string, limit int) metav1.Condition {
+	size := apiv1alpha1.EstimateConfigSize(config)
+	cond := metav1.Condition{
+		Type:    apiv1alpha1.ConditionConfigSize,
+		Status:  metav1.ConditionTrue,
+		Reason:  "WithinLimit",
+		Message: fmt.Sprintf("Serialized config is %d bytes, within the limit of %d bytes", size, limit),
+	}
+	switch {
+	case size > limit:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ExceedsLimit"
+		cond.Message = fmt.Sprintf("Serialized config is %d bytes, exceeding the Kafka Connect config topic limit of %d bytes; Connect may fail to store it", size, limit)
+	case size > apiv1alpha1.ConfigSizeWarningThreshold(limit):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ApproachingLimit"
+		cond.Message = fmt.Sprintf("Serialized config is %d bytes, approaching the Kafka Connect config topic limit of %d bytes", size, limit)
+	}
+	return cond
+}
+
+// reconcileConfigSize updates the ConfigSize condition and emits a warning event when the config
+// starts approaching or exceeding the limit.
+func (r *DebeziumConnectorReconciler) reconcileConfigSize(dbc *apiv1alpha1.DebeziumConnector, config map[string]string) {
+	cond := configSizeCondition(config, r.configSizeLimit())
+	cond.ObservedGeneration = dbc.Generation
+	previous := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionConfigSize)
+	if cond.Status == metav1.ConditionFalse && (previous == nil || previous.Reason != cond.Reason) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "ConfigSize"+cond.Reason, "%s", cond.Message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, cond)
+}
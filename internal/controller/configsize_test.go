@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Config size condition", func() {
+	withList := func(n int) map[string]string {
+		return map[string]string{"name": "inventory", "table.include.list": strings.Repeat("t", n)}
+	}
+
+	It("reports configs within, approaching and exceeding the limit", func() {
+		Expect(configSizeCondition(withList(10), 1000).Reason).To(Equal("WithinLimit"))
+
+		cond := configSizeCondition(withList(850), 1000)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("ApproachingLimit"))
+
+		cond = configSizeCondition(withList(2000), 1000)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("ExceedsLimit"))
+		Expect(cond.Message).To(ContainSubstring("limit of 1000 bytes"))
+	})
+
+	It("emits a warning event when the config starts approaching the limit", func() {
+		recorder := record.NewFakeRecorder(10)
+		r := &DebeziumConnectorReconciler{Recorder: recorder, ConfigSizeLimit: 1000}
+		dbc := newTestDebeziumConnector("http://connect:8083")
+
+		r.reconcileConfigSize(dbc, withList(10))
+		Expect(recorder.Events).NotTo(Receive())
+		Expect(meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionConfigSize)).To(BeTrue())
+
+		r.reconcileConfigSize(dbc, withList(850))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning ConfigSizeApproachingLimit")))
+		r.reconcileConfigSize(dbc, withList(860))
+		Expect(recorder.Events).NotTo(Receive())
+
+		r.reconcileConfigSize(dbc, withList(2000))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning ConfigSizeExceedsLimit")))
+	})
+
+	It("uses the default config topic limit", func() {
+		r := &DebeziumConnectorReconciler{}
+		Expect(r.configSizeLimit()).To(Equal(apiv1alpha1.DefaultConfigSizeLimit))
+	})
+})
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// updateOnConflict applies mutate to obj and writes it, re-reading the latest version and
+// applying mutate again when the write conflicts, e.g. with a status update made meanwhile.
+// Only the changes made by mutate are written; the in-memory status of obj is kept.
+func updateOnConflict(ctx context.Context, c client.Client, obj client.Object, mutate func(client.Object)) error {
+	mutate(obj)
+	latest := obj.DeepCopyObject().(client.Object)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+		mutate(latest)
+		return c.Update(ctx, latest)
+	})
+	if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(latest.GetResourceVersion())
+	return nil
+}
+
+// addFinalizer adds finalizer to obj, retrying on conflicts.
+func addFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
+	return updateOnConflict(ctx, c, obj, func(o client.Object) {
+		controllerutil.AddFinalizer(o, finalizer)
+	})
+}
+
+// removeFinalizer removes finalizer from obj, retrying on conflicts. An object that is already
+// gone needs no finalizer removed.
+func removeFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
+	return client.IgnoreNotFound(updateOnConflict(ctx, c, obj, func(o client.Object) {
+		controllerutil.RemoveFinalizer(o, finalizer)
+	}))
+}
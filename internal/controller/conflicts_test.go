@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Write conflicts", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	conflict := apierrors.NewConflict(schema.GroupResource{Group: "api.debezium", Resource: "debeziumconnectors"}, key.Name, nil)
+
+	var (
+		connect                          *fakeConnect
+		updateConflicts, statusConflicts int
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		updateConflicts, statusConflicts = 1, 1
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	// newConflictingReconciler returns a reconciler whose first spec and status writes conflict.
+	newConflictingReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if updateConflicts > 0 {
+						updateConflicts--
+						return conflict
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if statusConflicts > 0 {
+						statusConflicts--
+						return conflict
+					}
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}).Build()
+		return &DebeziumConnectorReconciler{Client: c, HTTPClient: connect.Client()}
+	}
+
+	It("retries conflicting finalizer and status updates", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Finalizers = nil
+		r := newConflictingReconciler(dbc)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updateConflicts).To(BeZero())
+		Expect(statusConflicts).To(BeZero())
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Finalizers).To(ContainElement(debeziumFinalizer))
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+	})
+
+	It("retries a conflicting finalizer removal on deletion", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		r := newConflictingReconciler(dbc)
+		Expect(r.Delete(ctx, dbc)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updateConflicts).To(BeZero())
+		Expect(connect.configs).To(BeEmpty())
+		Expect(apierrors.IsNotFound(r.Get(ctx, key, &apiv1alpha1.DebeziumConnector{}))).To(BeTrue())
+	})
+})
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// serverInfoTTL bounds how long a host's server info is cached, so worker upgrades are picked up.
+const serverInfoTTL = 10 * time.Minute
+
+// connectServerInfo is the response of GET {host}/.
+type connectServerInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+
+	fetchedAt time.Time
+}
+
+// serverInfoCache caches server info per Debezium host. The zero value is ready to use.
+type serverInfoCache struct {
+	mu      sync.Mutex
+	entries map[string]connectServerInfo
+}
+
+func (c *serverInfoCache) get(host string) (connectServerInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.entries[host]
+	if !ok || time.Since(info.fetchedAt) > serverInfoTTL {
+		return connectServerInfo{}, false
+	}
+	return info, true
+}
+
+// set caches the server info of host and returns whether its version or commit differ from the
+// ones cached before, even if those expired.
+func (c *serverInfoCache) set(host string, info connectServerInfo) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]connectServerInfo{}
+	}
+	previous, ok := c.entries[host]
+	c.entries[host] = info
+	return ok && (previous.Version != info.Version || previous.Commit != info.Commit)
+}
+
+// connectFeature is a Connect REST capability that only exists from a given Kafka version on.
+type connectFeature struct {
+	name              string
+	major             int
+	minor             int
+	unsupportedReason string
+}
+
+var (
+	// featureStop is PUT /connectors/{name}/stop (KIP-875).
+	featureStop = connectFeature{name: "connector stop", major: 3, minor: 5, unsupportedReason: "StopUnsupported"}
+	// featureOffsetReset is DELETE and PATCH /connectors/{name}/offsets (KIP-875).
+	featureOffsetReset = connectFeature{name: "offset reset", major: 3, minor: 6, unsupportedReason: "OffsetResetUnsupported"}
+)
+
+// getConnectServerInfo returns the version and commit reported by the Debezium host, cached per host.
+func (r *DebeziumConnectorReconciler) getConnectServerInfo(ctx context.Context, host string) (connectServerInfo, error) {
+	if info, ok := r.serverInfo.get(host); ok {
+		return info, nil
+	}
+	resp, err := r.get(ctx, r.connectURL(host, "/"))
+	if err != nil {
+		return connectServerInfo{}, fmt.Errorf("failed to GET Connect server info: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return connectServerInfo{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return connectServerInfo{}, fmt.Errorf("GET Connect server info returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	var info connectServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return connectServerInfo{}, fmt.Errorf("failed to decode Connect server info: %w", err)
+	}
+	info.fetchedAt = time.Now()
+	if r.serverInfo.set(host, info) {
+		log.FromContext(ctx).Info("Connect server of Debezium host changed", "host", host, "version", info.Version, "commit", info.Commit)
+	}
+	if r.ValidationCache.ObserveServer(host, info.Version, info.Commit) {
+		traceStep(ctx, "dropped cached validation results of %s", host)
+	}
+	return info, nil
+}
+
+// connectVersionChanged reports whether the Connect version of the connector's host changed
+// since its config was last validated, so the config is validated again by the new plugins.
+// Only the cached server info is consulted.
+func (r *DebeziumConnectorReconciler) connectVersionChanged(dbc *apiv1alpha1.DebeziumConnector) bool {
+	info, ok := r.serverInfo.get(dbc.Spec.DebeziumHost)
+	return ok && dbc.Status.ConnectVersion != "" && info.Version != dbc.Status.ConnectVersion
+}
+
+// parseConnectVersion returns the Apache Kafka major and minor version of a Connect version string.
+// Confluent Platform versions ("7.5.0-ccs") are mapped to the Kafka release they ship.
+func parseConnectVersion(version string) (int, int, error) {
+	base, suffix, _ := strings.Cut(version, "-")
+	parts := strings.Split(base, ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("unrecognized Connect version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unrecognized Connect version %q", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unrecognized Connect version %q", version)
+	}
+	if (suffix == "ccs" || suffix == "ce") && major >= 7 {
+		major -= 4
+	}
+	return major, minor, nil
+}
+
+// unsupportedFeatureError reports a feature the Connect server is too old for.
+type unsupportedFeatureError struct {
+	feature connectFeature
+	version string
+}
+
+func (e *unsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s is unsupported by Connect %s (requires %d.%d)", e.feature.name, e.version, e.feature.major, e.feature.minor)
+}
+
+// supportsFeature returns nil when the Debezium host supports feature, an *unsupportedFeatureError
+// when it is too old, or the error encountered determining its version.
+func (r *DebeziumConnectorReconciler) supportsFeature(ctx context.Context, host string, feature connectFeature) error {
+	info, err := r.getConnectServerInfo(ctx, host)
+	if err != nil {
+		return err
+	}
+	major, minor, err := parseConnectVersion(info.Version)
+	if err != nil {
+		return err
+	}
+	if major < feature.major || (major == feature.major && minor < feature.minor) {
+		return &unsupportedFeatureError{feature: feature, version: info.Version}
+	}
+	return nil
+}
+
+// requireFeature reports whether the connector's Debezium host supports feature. When it does not,
+// the FeatureSupported condition explains why the requested operation was skipped.
+func (r *DebeziumConnectorReconciler) requireFeature(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, feature connectFeature) bool {
+	err := r.supportsFeature(ctx, dbc.Spec.DebeziumHost, feature)
+	if err == nil {
+		if cond := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionFeatureSupported); cond != nil && cond.Reason == feature.unsupportedReason {
+			meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionFeatureSupported)
+		}
+		return true
+	}
+	cond := metav1.Condition{
+		Type:               apiv1alpha1.ConditionFeatureSupported,
+		Status:             metav1.ConditionFalse,
+		Reason:             feature.unsupportedReason,
+		Message:            err.Error(),
+		ObservedGeneration: dbc.Generation,
+	}
+	if _, ok := err.(*unsupportedFeatureError); !ok {
+		cond.Status = metav1.ConditionUnknown
+		cond.Message = fmt.Sprintf("cannot determine support for %s: %v", feature.name, err)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, cond)
+	return false
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connect version gating", func() {
+	ctx := context.Background()
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	DescribeTable("parses Connect versions",
+		func(version string, major, minor int) {
+			gotMajor, gotMinor, err := parseConnectVersion(version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]int{gotMajor, gotMinor}).To(Equal([]int{major, minor}))
+		},
+		Entry("Apache Kafka", "3.6.1", 3, 6),
+		Entry("Confluent Platform", "7.5.0-ccs", 3, 5),
+		Entry("Confluent Platform 8", "8.0.0-ce", 4, 0),
+	)
+
+	It("rejects unrecognized versions", func() {
+		_, _, err := parseConnectVersion("unknown")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reports a feature the Connect version does not support", func() {
+		connect.version = "3.4.1"
+		r := &DebeziumConnectorReconciler{HTTPClient: connect.Client()}
+		dbc := newTestDebeziumConnector(connect.URL)
+
+		Expect(r.requireFeature(ctx, dbc, featureStop)).To(BeFalse())
+		cond := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionFeatureSupported)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("StopUnsupported"))
+		Expect(cond.Message).To(Equal("connector stop is unsupported by Connect 3.4.1 (requires 3.5)"))
+	})
+
+	It("allows supported features and clears a stale condition", func() {
+		r := &DebeziumConnectorReconciler{HTTPClient: connect.Client()}
+		dbc := newTestDebeziumConnector(connect.URL)
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:   apiv1alpha1.ConditionFeatureSupported,
+			Status: metav1.ConditionFalse,
+			Reason: featureOffsetReset.unsupportedReason,
+		})
+
+		Expect(r.requireFeature(ctx, dbc, featureOffsetReset)).To(BeTrue())
+		Expect(meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionFeatureSupported)).To(BeNil())
+		newer := connectFeature{name: "newer feature", major: 3, minor: 7, unsupportedReason: "NewerUnsupported"}
+		Expect(r.requireFeature(ctx, dbc, newer)).To(BeFalse())
+	})
+
+	It("caches the server info per host", func() {
+		r := &DebeziumConnectorReconciler{HTTPClient: connect.Client()}
+		for i := 0; i < 3; i++ {
+			info, err := r.getConnectServerInfo(ctx, connect.URL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Version).To(Equal("3.6.0"))
+			Expect(info.Commit).To(Equal("abc"))
+		}
+		Expect(connect.requests).To(Equal([]string{"GET /"}))
+	})
+
+	It("drops cached validation results and validates again when the Connect version changes", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["topic.prefix"] = "inventory"
+		cache := apiv1alpha1.NewValidationCache(time.Minute, 10)
+		validator := &apiv1alpha1.DebeziumConnectorValidator{HTTPClient: connect.Client(), Cache: cache}
+		r := &DebeziumConnectorReconciler{
+			Client:          fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:      connect.Client(),
+			ValidationCache: cache,
+		}
+		key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+		validations := func() int {
+			connect.mu.Lock()
+			defer connect.mu.Unlock()
+			n := 0
+			for _, request := range connect.requests {
+				if strings.HasSuffix(request, "/config/validate") {
+					n++
+				}
+			}
+			return n
+		}
+
+		_, err := validator.ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validations()).To(Equal(2))
+		Expect(cache.Len()).To(Equal(1))
+
+		// The workers are upgraded; the cached server info expires.
+		connect.mu.Lock()
+		connect.version = "3.7.0"
+		connect.mu.Unlock()
+		r.serverInfo.mu.Lock()
+		info := r.serverInfo.entries[connect.URL]
+		info.fetchedAt = time.Now().Add(-serverInfoTTL)
+		r.serverInfo.entries[connect.URL] = info
+		r.serverInfo.mu.Unlock()
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.Len()).To(BeZero())
+		Expect(validations()).To(Equal(3))
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.ConnectVersion).To(Equal("3.7.0"))
+
+		_, err = validator.ValidateCreate(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validations()).To(Equal(4))
+	})
+})
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+const (
+	// createConfirmInitialInterval is the delay before the status of a created connector is
+	// polled again; it doubles after each poll.
+	createConfirmInitialInterval = 500 * time.Millisecond
+	// createConfirmMaxInterval bounds the delay between two polls.
+	createConfirmMaxInterval = 5 * time.Second
+)
+
+// confirmCreated polls the status of a just created connector until it is RUNNING or FAILED, or
+// CreateConfirmTimeout passed, and reports the outcome in the CreateConfirmed condition. Connect
+// accepts a connector before a worker started it, so a 201 alone does not mean it runs.
+func (r *DebeziumConnectorReconciler) confirmCreated(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) {
+	if r.CreateConfirmTimeout <= 0 {
+		return
+	}
+	start := time.Now()
+	deadline := start.Add(r.CreateConfirmTimeout)
+	interval := createConfirmInitialInterval
+	state := "UNKNOWN"
+	for {
+		status, err := r.getDebeziumConnectorStatus(ctx, dbc.Spec.DebeziumHost, name)
+		if err == nil {
+			state = status.Connector.State
+		}
+		if state == "RUNNING" || state == "FAILED" {
+			break
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if interval > remaining {
+			interval = remaining
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > createConfirmMaxInterval {
+			interval = createConfirmMaxInterval
+		}
+	}
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	traceStep(ctx, "created connector is %s after %s", state, elapsed)
+	condition := metav1.Condition{
+		Type:               apiv1alpha1.ConditionCreateConfirmed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Running",
+		Message:            fmt.Sprintf("Connector reached RUNNING %s after it was created", elapsed),
+		ObservedGeneration: dbc.Generation,
+	}
+	switch state {
+	case "RUNNING":
+	case "FAILED":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Failed"
+		condition.Message = fmt.Sprintf("Connector is FAILED %s after it was created", elapsed)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ConfirmTimedOut"
+		condition.Message = fmt.Sprintf("Connector did not reach RUNNING within %s after it was created; it is %s", r.CreateConfirmTimeout, state)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionFalse {
+		log.FromContext(ctx).Info("Created connector is not running", "name", name, "state", state, "elapsed", elapsed)
+		r.recordEvent(dbc, corev1.EventTypeWarning, "CreateNotConfirmed", "%s", condition.Message)
+	}
+}
+
+// reconcileCreateConfirmed marks a created connector that was not confirmed in time as
+// confirmed once it is RUNNING.
+func reconcileCreateConfirmed(dbc *apiv1alpha1.DebeziumConnector, state string) {
+	condition := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionCreateConfirmed)
+	if condition == nil || condition.Status == metav1.ConditionTrue || state != "RUNNING" {
+		return
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionCreateConfirmed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Running",
+		Message:            "Connector reached RUNNING after it was created",
+		ObservedGeneration: dbc.Generation,
+	})
+}
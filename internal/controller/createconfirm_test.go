@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Create confirmation", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		server  *httptest.Server
+		// startedAfter is the number of status polls after which a created connector runs;
+		// negative never starts it.
+		startedAfter int
+		polls        int
+		recorder     *record.FakeRecorder
+		r            *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		startedAfter, polls = 0, 0
+		// Connect accepts the connector, but no worker starts it until startedAfter polls.
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet && req.URL.Path == "/connectors/inventory/status" {
+				connect.mu.Lock()
+				if _, ok := connect.states["inventory"]; ok {
+					polls++
+					connect.states["inventory"] = "UNASSIGNED"
+					if startedAfter >= 0 && polls > startedAfter {
+						connect.states["inventory"] = "RUNNING"
+					}
+				}
+				connect.mu.Unlock()
+			}
+			connect.serve(w, req)
+		}))
+		dbc := newTestDebeziumConnector(server.URL)
+		recorder = record.NewFakeRecorder(10)
+		r = &DebeziumConnectorReconciler{
+			Client:               fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:           server.Client(),
+			Recorder:             recorder,
+			CreateConfirmTimeout: 2 * time.Second,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		connect.Close()
+	})
+
+	reconcileOnce := func() *metav1.Condition {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateConfirmed)
+	}
+
+	It("confirms a created connector once it is running", func() {
+		startedAfter = 2
+		cond := reconcileOnce()
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("Running"))
+		Expect(polls).To(BeNumerically(">", 2))
+		Expect(recorder.Events).NotTo(Receive(ContainSubstring("CreateNotConfirmed")))
+	})
+
+	It("reports a created connector that does not start in time", func() {
+		startedAfter = -1
+		r.CreateConfirmTimeout = 300 * time.Millisecond
+		start := time.Now()
+		cond := reconcileOnce()
+		Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("ConfirmTimedOut"))
+		Expect(cond.Message).To(ContainSubstring("it is UNASSIGNED"))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("CreateNotConfirmed")))
+
+		// The condition is confirmed once the connector runs.
+		startedAfter = 0
+		cond = reconcileOnce()
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("does not poll the connector when disabled", func() {
+		startedAfter = -1
+		r.CreateConfirmTimeout = 0
+		Expect(reconcileOnce()).To(BeNil())
+		Expect(polls).To(Equal(1))
+	})
+})
@@ -20,64 +20,330 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/kafka"
 	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
 )
 
 // DebeziumConnectorReconciler reconciles a DebeziumConnector object
 type DebeziumConnectorReconciler struct {
 	client.Client
+	// HTTPClient is shared by all reconciles to talk to the Debezium hosts; defaultHTTPClient is
+	// used when nil.
 	HTTPClient *http.Client
+	// ConnectBasePath is the path prefix the Kafka Connect REST API is served below on every
+	// Debezium host, e.g. /kafka-connect behind a gateway; the API is served at the root when empty.
+	ConnectBasePath string
+	Recorder        record.EventRecorder
+	// RestConfig enables impersonation when reading referenced Secrets and ConfigMaps.
+	// When nil, the reconciler's own client is used.
+	RestConfig *rest.Config
+	// PauseReconciliation stops all connector mutations while status is still reported.
+	PauseReconciliation bool
+	// ConfigHistoryLimit is the number of applied configs retained per connector; zero disables history.
+	ConfigHistoryLimit int
+	// ConfigResolvers resolve config references, e.g. to Vault, after ConfigSecretRef is merged.
+	ConfigResolvers []ConfigResolver
+	// ConfigTransformers rewrite the resolved config before it is sent to the Debezium host.
+	ConfigTransformers []ConfigTransformer
+	// OperationTimeout bounds connector create and update calls; 30 seconds when zero.
+	OperationTimeout time.Duration
+	// CreateConfirmTimeout is how long the status of a created connector is polled until it is
+	// RUNNING, see the CreateConfirmed condition; zero does not poll it.
+	CreateConfirmTimeout time.Duration
+	// ProbeSchemaRegistry checks on each reconcile that the schema registries of the connector's
+	// converters answer, and reports the result in the SchemaRegistryReachable condition.
+	ProbeSchemaRegistry bool
+	// SchemaRegistryProbeTimeout bounds the schema registry probe; 2 seconds when zero.
+	SchemaRegistryProbeTimeout time.Duration
+	// SchemaRegistryProbeHosts restricts the probe to registries on these hosts; every host is
+	// probed when empty.
+	SchemaRegistryProbeHosts []string
+	// DeepCheckEvery is how many reconciles may pass before the connector config is compared with
+	// the Debezium host again while the spec is unchanged and the connector is RUNNING. Values
+	// below 2 compare the config on every reconcile.
+	DeepCheckEvery int
+	// RestartUnassignedAfter restarts a connector that stays UNASSIGNED for this long; zero
+	// disables restarts.
+	RestartUnassignedAfter time.Duration
+	// RestartFailedCooldown is the minimum time between two restarts of a connector that is FAILED,
+	// or has FAILED tasks, although its config matches; zero disables restarts.
+	RestartFailedCooldown time.Duration
+	// ListValuedKeys are compared as comma-separated lists when detecting config drift, so
+	// reformatted or reordered lists returned by Connect do not trigger updates; nil compares
+	// values exactly.
+	ListValuedKeys map[string]util.ListNormalization
+	// ValueTypes are the types of config keys whose values are normalized to a canonical form,
+	// e.g. "TRUE" to "true" or "1000.0" to "1000", before the config is sent to the Debezium host
+	// and when detecting config drift. The types the validate endpoint reports for a connector
+	// class take precedence; nil normalizes no values.
+	ValueTypes map[string]util.ValueType
+	// ConfigSizeLimit is the max.message.bytes of the Connect config topic, used to warn about
+	// configs approaching it; 1 MiB when zero.
+	ConfigSizeLimit int
+	// TopicAdmin verifies on the Kafka brokers that the topics a connector refers to, such as its
+	// schema history topic, exist before the connector is created; topics are not checked when nil.
+	TopicAdmin kafka.Admin
+	// CreateMissingTopics creates missing topics through TopicAdmin instead of waiting for them.
+	CreateMissingTopics bool
+	// RequireManagedTag tags the connectors the operator creates with the managedConfigKey, and
+	// refuses to update or delete connectors on the Debezium host that lack the tag.
+	RequireManagedTag bool
+	// StateChangeEvents emits a StateChanged event for each connector and task state transition.
+	StateChangeEvents bool
+	// StateNotifier is told about connector and task state transitions; none when nil.
+	StateNotifier StateNotifier
+	// HostFailureThreshold is the number of consecutive failed requests to a Debezium host after
+	// which requests to it are suspended for HostBreakerCooldown, so the connectors of a host that
+	// is down do not all keep retrying; zero never suspends requests.
+	HostFailureThreshold int
+	// HostBreakerCooldown is how long requests to a failing host are suspended before a single
+	// probe request is sent; 30 seconds when zero.
+	HostBreakerCooldown time.Duration
+	// MaxConcurrentReconciles is the number of DebeziumConnectors reconciled at the same time;
+	// one when zero.
+	MaxConcurrentReconciles int
+	// MaxConcurrentHostOperations bounds the requests that change connectors in flight to each
+	// Debezium host, independently of MaxConcurrentReconciles, so a busy host neither starves the
+	// others nor gets overloaded; zero does not bound them.
+	MaxConcurrentHostOperations int
+	// ValidationCache is the cache of the admission webhook's remote validation results. The
+	// results of a host are dropped when its Connect version or commit changes; none when nil.
+	ValidationCache *apiv1alpha1.ValidationCache
+	// SecretCache reads and watches the Secrets referenced through ConfigSecretRef one by one,
+	// so the operator needs no access to other Secrets; the manager's cache is used when nil.
+	SecretCache *ReferencedSecretCache
+	// WatchSelector limits the reconciler to DebeziumConnectors whose labels match it, so a fleet
+	// can be partitioned across operator instances; nil reconciles all of them.
+	WatchSelector labels.Selector
+
+	serverInfo       serverInfoCache
+	namespaceHosts   namespaceHostCache
+	hostBreakers     hostBreakers
+	hostLimits       hostLimiter
+	pluginValueTypes pluginValueTypes
+	deepChecks       deepChecks
+	impersonated     impersonatedClients
+	// settingsMu is held for reading by each reconcile and for writing while the settings above
+	// are changed at runtime, see UpdateSettings.
+	settingsMu sync.RWMutex
+}
+
+// UpdateSettings calls update to change the settings of r while the operator runs, e.g. after
+// the operator config file was reloaded. It waits for the reconciles in progress, so none of
+// them sees a mix of old and new settings.
+func (r *DebeziumConnectorReconciler) UpdateSettings(update func(r *DebeziumConnectorReconciler)) {
+	r.settingsMu.Lock()
+	defer r.settingsMu.Unlock()
+	update(r)
 }
 
+// defaultHTTPClient is used by reconcilers created without an HTTPClient.
+var defaultHTTPClient = util.NewHTTPClient(util.DefaultHTTPClientOptions)
+
 // Finalizer name for DebeziumConnector
 const debeziumFinalizer = "debeziumconnector.finalizers.api.debezium"
 
+const (
+	// maxDeletionChecks bounds how often a deleted connector is re-checked before the finalizer is removed.
+	maxDeletionChecks = 5
+	// deletionCheckInterval is the delay between deletion checks.
+	deletionCheckInterval = 5 * time.Second
+)
+
 //+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectors/finalizers,verbs=update
+//+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectortemplates,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=impersonate
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;update;patch
 
-func (r *DebeziumConnectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *DebeziumConnectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
 	logger := log.FromContext(ctx)
+	r.settingsMu.RLock()
+	defer r.settingsMu.RUnlock()
 
 	dbc := &apiv1alpha1.DebeziumConnector{}
 	if err := r.Get(ctx, req.NamespacedName, dbc); err != nil {
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			logger.Info("DebeziumConnector resource not found; it may have been deleted.")
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get DebeziumConnector")
 		return ctrl.Result{}, err
 	}
+	// Connectors of another partition are left alone, including their finalizer. Requests mapped
+	// from watched Secrets, templates and namespaces bypass the predicate, so check again here.
+	if !r.watches(dbc) {
+		return ctrl.Result{}, nil
+	}
+	previous := dbc.Status.DeepCopy()
+	ctx, restStatus := withRESTStatus(ctx)
 
-	// Initialize HTTP client if not already set.
+	// Fall back to the shared client, so connections are reused across reconciles.
 	if r.HTTPClient == nil {
-		r.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+		r.HTTPClient = defaultHTTPClient
+	}
+
+	// Tracing is opt-in, so reconciles without the annotation don't collect entries.
+	if dbc.DeletionTimestamp.IsZero() {
+		var trace *reconcileTrace
+		if tracingEnabled(dbc) {
+			trace = &reconcileTrace{Started: time.Now().UTC()}
+			ctx = withReconcileTrace(ctx, trace)
+			traceStep(ctx, "reconciling generation %d", dbc.Generation)
+		}
+		defer func() {
+			if err := r.finishReconcileTrace(ctx, dbc, trace, reconcileResultString(result, reterr)); err != nil {
+				logger.Error(err, "failed to write reconcile trace")
+			}
+		}()
+	}
+
+	// A connector without a host runs on the default host of its namespace. The resolved host is
+	// only kept in memory, so a changed default moves the connector to the new host.
+	if dbc.Spec.DebeziumHost == "" {
+		host, err := r.connectorHost(ctx, dbc)
+		if err != nil {
+			logger.Error(err, "failed to resolve Debezium host")
+			return r.retryAfterFailure(ctx, dbc, err)
+		}
+		if host == "" && dbc.DeletionTimestamp.IsZero() {
+			err := fmt.Errorf("spec.debeziumHost is not set and namespace %s has no %s annotation", dbc.Namespace, apiv1alpha1.DefaultHostAnnotation)
+			logger.Error(err, "failed to resolve Debezium host")
+			r.recordEvent(dbc, corev1.EventTypeWarning, "HostUnresolved", "%s", err.Error())
+			return r.retryAfterFailure(ctx, dbc, err)
+		}
+		traceDecision(ctx, "using host %s for namespace %s", host, dbc.Namespace)
+		dbc.Spec.DebeziumHost = host
+	}
+
+	// Fail fast while the host is known to be down, instead of adding to its load.
+	if until, suspended := r.hostBreakers.suspended(hostBreakerKey(dbc.Spec.DebeziumHost), time.Now()); suspended {
+		return r.suspendForHost(ctx, dbc, until)
 	}
+	meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionHostCircuitOpen)
 
 	// Handle deletion: If the resource is being deleted, remove the connector from Debezium.
 	if !dbc.ObjectMeta.DeletionTimestamp.IsZero() {
+		// Deleting the connector is a mutation too; wait until reconciliation is resumed.
+		if r.isPaused(dbc) && controllerutil.ContainsFinalizer(dbc, debeziumFinalizer) {
+			logger.Info("Reconciliation paused; deferring connector deletion")
+			return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
+		}
 		if controllerutil.ContainsFinalizer(dbc, debeziumFinalizer) {
-			if err := r.deleteDebeziumConnector(dbc.Spec.DebeziumHost, dbc.Spec.Config["name"]); err != nil {
-				logger.Error(err, "failed to delete Debezium connector")
-				return ctrl.Result{}, err
+			until, err := deferredUntil(dbc, time.Now())
+			if err != nil {
+				logger.Error(err, "failed to check the maintenance window")
+				return retryResult(dbc, err)
 			}
-			controllerutil.RemoveFinalizer(dbc, debeziumFinalizer)
-			if err := r.Update(ctx, dbc); err != nil {
+			if !until.IsZero() {
+				logger.Info("Outside the maintenance window; deferring connector deletion", "until", until)
+				return ctrl.Result{RequeueAfter: time.Until(until)}, nil
+			}
+		}
+		if controllerutil.ContainsFinalizer(dbc, debeziumFinalizer) {
+			name := r.connectorName(ctx, dbc)
+			// A duplicate never managed the connector, which belongs to another resource.
+			if name != "" {
+				owner, err := r.connectorNameOwner(ctx, dbc, name)
+				if err != nil {
+					logger.Error(err, "failed to check for duplicate connectors")
+					return retryResult(dbc, err)
+				}
+				if owner != nil {
+					name = ""
+				}
+			}
+			// Without a host the connector was never created.
+			if dbc.Spec.DebeziumHost == "" {
+				name = ""
+			}
+			if name != "" {
+				managed, err := r.connectorManaged(ctx, dbc.Spec.DebeziumHost, name)
+				if err != nil {
+					logger.Error(err, "failed to check whether the connector is managed")
+					return retryResult(dbc, err)
+				}
+				if !managed {
+					logger.Info("Leaving connector not managed by the operator on the Debezium host", "name", name)
+					r.recordEvent(dbc, corev1.EventTypeWarning, "UnmanagedNotDeleted",
+						"Connector %s lacks the %s tag and was not deleted", name, managedConfigKey)
+					name = ""
+				}
+			}
+			if name != "" {
+				if err := r.retainFinalConfig(ctx, dbc, name); err != nil {
+					logger.Error(err, "failed to retain final connector config")
+					r.recordEvent(dbc, corev1.EventTypeWarning, "ConfigNotRetained", "%s", err.Error())
+					return retryResult(dbc, err)
+				}
+				if err := r.deleteDebeziumConnector(ctx, dbc.Spec.DebeziumHost, name); err != nil {
+					logger.Error(err, "failed to delete Debezium connector")
+					return retryResult(dbc, err)
+				}
+				// Connect may still list the connector shortly after DELETE; only release the
+				// finalizer once it is confirmed gone, or after a bounded number of checks.
+				exists, err := r.connectorExists(ctx, dbc.Spec.DebeziumHost, name)
+				if err != nil {
+					logger.Error(err, "failed to verify Debezium connector deletion")
+					return retryResult(dbc, err)
+				}
+				if exists && dbc.Status.DeletionChecks < maxDeletionChecks {
+					dbc.Status.DeletionChecks++
+					if err := r.updateStatus(ctx, dbc); err != nil {
+						return ctrl.Result{}, err
+					}
+					return ctrl.Result{RequeueAfter: deletionCheckInterval}, nil
+				}
+				if exists {
+					logger.Info("Debezium connector still present after deletion checks; removing finalizer", "name", name)
+					r.recordEvent(dbc, corev1.EventTypeWarning, "DeletionUnconfirmed",
+						"Connector %s was still present after %d deletion checks", name, dbc.Status.DeletionChecks)
+				} else if err := r.deleteTopicsOnRemoval(ctx, dbc); err != nil {
+					// Topics are only deleted once the connector is gone, since it would keep using them.
+					logger.Error(err, "failed to delete connector topics")
+					r.recordEvent(dbc, corev1.EventTypeWarning, "TopicDeletionFailed", "Failed to delete topics: %v", err)
+					return retryResult(dbc, err)
+				}
+				forgetDrift(name, dbc.Spec.DebeziumHost)
+			}
+			r.deepChecks.forget(dbc.UID)
+			if err := removeFinalizer(ctx, r.Client, dbc, debeziumFinalizer); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
@@ -86,77 +352,417 @@ func (r *DebeziumConnectorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Ensure our finalizer is present.
 	if !controllerutil.ContainsFinalizer(dbc, debeziumFinalizer) {
-		controllerutil.AddFinalizer(dbc, debeziumFinalizer)
-		if err := r.Update(ctx, dbc); err != nil {
+		if err := addFinalizer(ctx, r.Client, dbc, debeziumFinalizer); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
-	// Check if the connector already exists on the Debezium host.
-	exists, err := r.connectorExists(dbc.Spec.DebeziumHost, dbc.Spec.Config["name"])
+	// Resolve the desired config from the templates, the referenced Secret, Spec.Config and
+	// annotation overrides.
+	config, sources, err := r.resolveLayeredConfig(ctx, dbc)
 	if err != nil {
-		logger.Error(err, "failed to check if connector exists")
-		return ctrl.Result{}, err
+		logger.Error(err, "failed to resolve connector config")
+		return r.retryAfterFailure(ctx, dbc, err)
+	}
+	traceStep(ctx, "resolved config of connector %s with %d keys", config["name"], len(config))
+	if len(dbc.Spec.TemplateRefs) > 0 {
+		dbc.Status.ConfigSources = sources
+	} else {
+		dbc.Status.ConfigSources = nil
 	}
 
-	if !exists {
-		// If the connector doesn't exist, create it.
-		if err := r.createDebeziumConnector(dbc.Spec.DebeziumHost, dbc.Spec.Config); err != nil {
-			logger.Error(err, "failed to create connector")
+	if err := r.syncEffectiveConfig(ctx, dbc, config); err != nil {
+		logger.Error(err, "failed to sync effective config")
+	}
+	r.reconcileConfigSize(dbc, config)
+	r.reconcileTopicNames(dbc, config)
+
+	// Connect applies the whole config in one request; a transform referring to a predicate the
+	// config does not define would fail the connector, so such a config is never sent.
+	if errs := apiv1alpha1.ValidateTransformChain(config); len(errs) > 0 {
+		return r.rejectTransformChain(ctx, dbc, errs)
+	}
+
+	// Two resources managing the same connector would overwrite each other on every reconcile;
+	// only the owner of the connector name applies its config, unless the name is suffixed.
+	name, owner, err := r.resolveConnectorName(ctx, dbc, config["name"])
+	if err != nil {
+		logger.Error(err, "failed to check for duplicate connectors")
+		return r.retryAfterFailure(ctx, dbc, err)
+	}
+	r.recordEffectiveName(dbc, config["name"], name)
+	config["name"] = name
+	r.recordDuplicateConnector(dbc, config["name"], owner)
+	if owner != nil {
+		logger.Info("Connector name already owned by another resource", "name", config["name"], "owner", client.ObjectKeyFromObject(owner))
+		traceDecision(ctx, "skipping connector %s owned by %s", config["name"], client.ObjectKeyFromObject(owner))
+		recordAvailability(dbc, util.ConfigHash(config), nil)
+		if err := r.updateStatus(ctx, dbc); err != nil {
+			logger.Error(err, "failed to update DebeziumConnector status")
 			return ctrl.Result{}, err
 		}
-		logger.Info("Debezium connector created", "name", dbc.Spec.Config["name"])
-	} else {
-		// The connector exists: check if its configuration matches the CR spec.
-		externalConfig, err := r.getDebeziumConnectorConfig(dbc.Spec.DebeziumHost, dbc.Spec.Config["name"])
-		if err != nil {
-			logger.Error(err, "failed to get external connector configuration")
+		return ctrl.Result{RequeueAfter: dbc.ReconcileInterval()}, nil
+	}
+
+	// A preview reports what the reconcile would change on the Debezium host without applying it.
+	if previewRequested(dbc) {
+		if err := r.recordConfigPreview(ctx, dbc, config); err != nil {
+			logger.Error(err, "failed to preview connector config")
+			return r.retryAfterFailure(ctx, dbc, err)
+		}
+		if err := r.updateStatus(ctx, dbc); err != nil {
+			logger.Error(err, "failed to update DebeziumConnector status")
 			return ctrl.Result{}, err
 		}
-		if !util.ConfigsEqual(externalConfig, dbc.Spec.Config) {
-			// External configuration does not match; update it to match the CR.
-			if err := r.updateDebeziumConnector(dbc.Spec.DebeziumHost, dbc.Spec.Config); err != nil {
-				logger.Error(err, "failed to update connector")
-				return ctrl.Result{}, err
+		return ctrl.Result{RequeueAfter: dbc.ReconcileInterval()}, nil
+	}
+	dbc.Status.ConfigPreview = nil
+
+	// Connectors created by another tool are left to it.
+	managed, err := r.connectorManaged(ctx, dbc.Spec.DebeziumHost, config["name"])
+	if err != nil {
+		logger.Error(err, "failed to check whether the connector is managed")
+		return r.retryAfterFailure(ctx, dbc, err)
+	}
+	r.recordUnmanaged(dbc, config["name"], !managed)
+	if !managed {
+		logger.Info("Connector on the Debezium host is not managed by the operator", "name", config["name"])
+		traceDecision(ctx, "skipping connector %s without the %s tag", config["name"], managedConfigKey)
+		recordAvailability(dbc, util.ConfigHash(config), nil)
+		if err := r.updateStatus(ctx, dbc); err != nil {
+			logger.Error(err, "failed to update DebeziumConnector status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: dbc.ReconcileInterval()}, nil
+	}
+
+	// Outside the maintenance window changes wait for the next one, while status reads continue.
+	until, err := deferredUntil(dbc, time.Now())
+	if err != nil {
+		logger.Error(err, "failed to check the maintenance window")
+		return r.retryAfterFailure(ctx, dbc, err)
+	}
+	recordDeferral(dbc, until)
+
+	// Pausing stops the operator from mutating the connector while status reads continue.
+	changed := false
+	if r.isPaused(dbc) {
+		reason := "PausedByAnnotation"
+		if r.PauseReconciliation {
+			reason = "PausedByOperator"
+		}
+		traceDecision(ctx, "reconciliation paused (%s); not modifying the connector", reason)
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:               apiv1alpha1.ConditionPaused,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            "Reconciliation is paused; the connector is not modified",
+			ObservedGeneration: dbc.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:               apiv1alpha1.ConditionPaused,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Reconciling",
+			Message:            "Reconciliation is active",
+			ObservedGeneration: dbc.Generation,
+		})
+		if !until.IsZero() {
+			traceDecision(ctx, "outside the maintenance window; deferring changes until %s", until.Format(time.RFC3339))
+		}
+	}
+	if r.mutationsAllowed(dbc, until) {
+		if dbc.Status.DebeziumHost != "" && dbc.Status.DebeziumHost != dbc.Spec.DebeziumHost {
+			traceDecision(ctx, "migrating connector from %s to %s", dbc.Status.DebeziumHost, dbc.Spec.DebeziumHost)
+			if err := r.migrateConnector(ctx, dbc, config); err != nil {
+				// Check back until the connector runs on the new host, rather than retrying with backoff.
+				if _, ok := err.(*migrationPendingError); ok {
+					logger.Info("Keeping connector on previous host until it runs on the new one", "reason", err.Error())
+					if err := r.updateStatus(ctx, dbc); err != nil {
+						logger.Error(err, "failed to update DebeziumConnector status")
+						return ctrl.Result{}, err
+					}
+					return ctrl.Result{RequeueAfter: migrationPendingRequeue}, nil
+				}
+				logger.Error(err, "failed to migrate connector", "from", dbc.Status.DebeziumHost, "to", dbc.Spec.DebeziumHost)
+				return r.retryAfterFailure(ctx, dbc, err)
+			}
+		}
+		if err := r.reconcileForceReplace(ctx, dbc, config); err != nil {
+			logger.Error(err, "failed to force-replace connector config")
+			return r.retryAfterFailure(ctx, dbc, err)
+		}
+		configHash := util.ConfigHash(config)
+		if r.canSkipDeepCheck(dbc, configHash) {
+			traceDecision(ctx, "skipping deep check; config hash %s is unchanged", configHash)
+			r.deepChecks.skipped(dbc.UID)
+		} else {
+			changed, err = r.reconcileConnector(ctx, dbc, config)
+			if err != nil {
+				// Report a slow create or update and check back, rather than retrying silently.
+				if timeoutErr, ok := err.(*operationTimeoutError); ok {
+					recordOperationTimeout(dbc, timeoutErr)
+					recordAvailability(dbc, configHash, err)
+					resetReconcileInterval(dbc)
+					if err := r.updateStatus(ctx, dbc); err != nil {
+						logger.Error(err, "failed to update DebeziumConnector status")
+						return ctrl.Result{}, err
+					}
+					if interval := dbc.RetryInterval(); interval > 0 {
+						return ctrl.Result{RequeueAfter: interval}, nil
+					}
+					return ctrl.Result{RequeueAfter: operationTimeoutRequeue}, nil
+				}
+				// Report missing topics and check back, rather than retrying with backoff.
+				if _, ok := err.(*missingTopicsError); ok {
+					recordAvailability(dbc, configHash, err)
+					resetReconcileInterval(dbc)
+					if err := r.updateStatus(ctx, dbc); err != nil {
+						logger.Error(err, "failed to update DebeziumConnector status")
+						return ctrl.Result{}, err
+					}
+					if interval := dbc.RetryInterval(); interval > 0 {
+						return ctrl.Result{RequeueAfter: interval}, nil
+					}
+					return ctrl.Result{RequeueAfter: missingTopicsRequeue}, nil
+				}
+				return r.retryAfterFailure(ctx, dbc, err)
+			}
+			// A refused conflict leaves the desired config unapplied.
+			if conflictRefused(dbc) {
+				dbc.Status.ConfigHash = ""
+			} else {
+				dbc.Status.DebeziumHost = dbc.Spec.DebeziumHost
+				dbc.Status.ObservedGeneration = dbc.Generation
+				dbc.Status.ConfigHash = configHash
+				r.deepChecks.checked(dbc.UID, time.Now())
 			}
-			logger.Info("Debezium connector updated to match CR", "name", dbc.Spec.Config["name"])
 		}
 	}
 
 	// Retrieve the connector state.
-	state, err := r.getDebeziumConnectorState(dbc.Spec.DebeziumHost, dbc.Spec.Config["name"])
-	if err != nil {
-		// If state cannot be determined, mark as UNKNOWN.
-		state = "UNKNOWN"
+	state := "UNKNOWN"
+	status, err := r.getDebeziumConnectorStatus(ctx, dbc.Spec.DebeziumHost, config["name"])
+	if err == nil {
+		state = status.Connector.State
+		traceStep(ctx, "connector state is %s with %d tasks", state, len(status.Tasks))
+		recordTaskAssignment(dbc, status)
+		r.reconcileTaskParallelism(dbc, config, len(status.Tasks))
+		r.reconcileUnassigned(ctx, dbc, config["name"], status, until)
+		if err := r.reconcileCircuitBreaker(ctx, dbc, config["name"], status, until); err != nil {
+			logger.Error(err, "failed to apply circuit breaker")
+		}
+		if err := r.reconcileFailed(ctx, dbc, config["name"], status, until); err != nil {
+			logger.Error(err, "failed to restart failed connector")
+		}
+		if err := r.reconcileDesiredState(ctx, dbc, config["name"], status, until); err != nil {
+			logger.Error(err, "failed to enforce desired connector state")
+		}
+		if err := r.reconcileLogLevel(ctx, dbc, config, until); err != nil {
+			logger.Error(err, "failed to apply connector log level")
+		}
+		r.reconcileTopics(ctx, dbc, config["name"])
 	}
 
 	// Update the CR status with the state.
 	dbc.Status.ConnectorStatus = state
+	recordStateHistory(dbc, previous, time.Now())
+	recordTimeToRunning(ctx, dbc, state, time.Now())
+	reconcileCreateConfirmed(dbc, state)
+	dbc.Status.Phase, dbc.Status.SourcePosition = r.connectorPhase(ctx, dbc.Spec.DebeziumHost, config["name"], state)
+	r.notifyStateTransitions(ctx, dbc, previous, config["name"])
+
+	// Re-run remote validation when the current generation and overrides have not been accepted
+	// yet, or were accepted by another Connect version. A Connect host without the validate
+	// endpoint is not asked again for the same generation and overrides.
+	validated := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionValidated)
+	if validated == nil || (validated.Status != metav1.ConditionTrue && validated.Reason != validationUnsupportedReason) ||
+		validated.ObservedGeneration != dbc.Generation || dbc.Status.ValidatedOverridesHash != dbc.ConfigOverridesHash() ||
+		r.connectVersionChanged(dbc) {
+		traceStep(ctx, "validating config of generation %d", dbc.Generation)
+		r.recordValidation(ctx, dbc, config)
+	}
 
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	r.reconcileSchemaRegistry(ctx, dbc, config)
+
+	recordAvailability(dbc, util.ConfigHash(config), nil)
+	interval := recordReconcileInterval(dbc, previous, changed)
+	if interval != dbc.ReconcileInterval() {
+		traceDecision(ctx, "connector is stable; polling again in %s", interval)
+	}
+	// Apply the deferred changes as soon as the maintenance window opens.
+	if wait := time.Until(until); !until.IsZero() && wait < interval {
+		interval = wait
+	}
+	if err := r.reconcileMetadataAnnotations(ctx, dbc, config["name"], restStatus); err != nil {
+		logger.Error(err, "failed to update operator annotations")
+	}
+	if err := r.updateStatus(ctx, dbc); err != nil {
+		logger.Error(err, "failed to update DebeziumConnector status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// updateStatus writes the status of dbc, retrying on conflicts with the latest version. An
+// unchanged status is not written, so a steady-state reconcile does not change the resource.
+func (r *DebeziumConnectorReconciler) updateStatus(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		latest := &apiv1alpha1.DebeziumConnector{}
-		if err := r.Get(ctx, req.NamespacedName, latest); err != nil {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(dbc), latest); err != nil {
 			return err
 		}
-		latest.Status.ConnectorStatus = state
+		if equality.Semantic.DeepEqual(latest.Status, dbc.Status) {
+			return nil
+		}
+		latest.Status = dbc.Status
 		return r.Status().Update(ctx, latest)
 	})
+}
+
+// retryResult returns the result for a retriable failure: a requeue after the connector's
+// retry interval when one is set, otherwise err so the controller's backoff applies.
+// The error has already been logged by the caller.
+func retryResult(dbc *apiv1alpha1.DebeziumConnector, err error) (ctrl.Result, error) {
+	if interval := dbc.RetryInterval(); interval > 0 {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+// watches reports whether obj matches the WatchSelector.
+func (r *DebeziumConnectorReconciler) watches(obj client.Object) bool {
+	return r.WatchSelector == nil || r.WatchSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// connectorPredicates filter the events of the watched DebeziumConnectors. Status updates and
+// the annotations the operator writes itself do not trigger a reconcile; the connector is polled
+// every RequeueAfter instead.
+func (r *DebeziumConnectorReconciler) connectorPredicates() []predicate.Predicate {
+	return []predicate.Predicate{
+		predicate.NewPredicateFuncs(r.watches),
+		predicate.Or(predicate.GenerationChangedPredicate{}, userAnnotationChangedPredicate{}, predicate.LabelChangedPredicate{}),
+	}
+}
+
+// isPaused reports whether connector mutations are paused globally or for dbc.
+func (r *DebeziumConnectorReconciler) isPaused(dbc *apiv1alpha1.DebeziumConnector) bool {
+	return r.PauseReconciliation || dbc.Annotations[apiv1alpha1.ReconcilePausedAnnotation] == "true"
+}
+
+// reconcileConnector creates the connector on the Debezium host, or updates it when its
+// configuration drifted from config. It reports whether the connector was changed.
+func (r *DebeziumConnectorReconciler) reconcileConnector(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	// Check if the connector already exists on the Debezium host.
+	exists, err := r.connectorExists(ctx, dbc.Spec.DebeziumHost, config["name"])
 	if err != nil {
-		logger.Error(err, "failed to update DebeziumConnector status")
-		return ctrl.Result{}, err
+		logger.Error(err, "failed to check if connector exists")
+		return false, err
 	}
 
-	return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
+	changed := false
+	if !exists {
+		traceDecision(ctx, "connector %s does not exist; creating it", config["name"])
+		// A connector created before its topics exist fails, or lets Connect create them with
+		// the wrong settings.
+		if err := r.checkPrerequisiteTopics(ctx, dbc, config); err != nil {
+			logger.Error(err, "prerequisite topics are not ready")
+			return false, err
+		}
+		// If the connector doesn't exist, create it.
+		if err := r.createDebeziumConnector(ctx, dbc.Spec.DebeziumHost, config); err != nil {
+			logger.Error(err, "failed to create connector")
+			return false, err
+		}
+		clearOperationTimeouts(dbc)
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionConflictDetected)
+		dbc.Status.HostConfigHash = r.hostConfigHash(config)
+		recordCreated(dbc, time.Now())
+		logger.Info("Debezium connector created", "name", config["name"])
+		r.confirmCreated(ctx, dbc, config["name"])
+		changed = true
+		if err := r.recordConfigHistory(ctx, dbc, config); err != nil {
+			logger.Error(err, "failed to record config history")
+		}
+	} else {
+		// The connector exists: check if its configuration matches the CR spec.
+		externalConfig, err := r.getDebeziumConnectorConfig(ctx, dbc.Spec.DebeziumHost, config["name"])
+		if err != nil {
+			logger.Error(err, "failed to get external connector configuration")
+			return false, err
+		}
+		inSync := r.configsEqual(externalConfig, config)
+		if inSync {
+			dbc.Status.HostConfigHash = r.hostConfigHash(externalConfig)
+			clearOperationTimeouts(dbc)
+		}
+		// A config changed outside the operator since it was last seen is a conflict.
+		if inSync || !r.changedOnHost(dbc, externalConfig) {
+			meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionConflictDetected)
+		} else if !r.recordHostConflict(ctx, dbc, externalConfig, config) {
+			logger.Info("Connector config was changed outside the operator; not overwriting it", "name", config["name"])
+			return false, nil
+		}
+		if !inSync {
+			// External configuration does not match; update it to match the CR.
+			traceDecision(ctx, "config of connector %s drifted; updating it", config["name"])
+			recordDrift(config["name"], dbc.Spec.DebeziumHost)
+			if err := r.applyConfigUpdate(ctx, dbc, externalConfig, config); err != nil {
+				logger.Error(err, "failed to update connector")
+				return false, err
+			}
+			clearOperationTimeouts(dbc)
+			dbc.Status.HostConfigHash = r.hostConfigHash(config)
+			logger.Info("Debezium connector updated to match CR", "name", config["name"])
+			changed = true
+			if err := r.recordConfigHistory(ctx, dbc, config); err != nil {
+				logger.Error(err, "failed to record config history")
+			}
+		} else if reconfigurationInProgress(dbc) {
+			// A previous stop, update and resume was interrupted after the update.
+			traceDecision(ctx, "resuming connector %s after an interrupted reconfiguration", config["name"])
+			if err := r.resumeReconfiguredConnector(ctx, dbc, config["name"]); err != nil {
+				logger.Error(err, "failed to resume reconfigured connector")
+				return false, err
+			}
+			changed = true
+		} else {
+			traceDecision(ctx, "config of connector %s is up to date", config["name"])
+		}
+	}
+
+	return changed, nil
+}
+
+// configsEqual reports whether the config on the Debezium host matches config, normalizing the
+// values of ListValuedKeys and of typed keys.
+func (r *DebeziumConnectorReconciler) configsEqual(external, config map[string]string) bool {
+	external, config = r.normalizeValues(external), r.normalizeValues(config)
+	if r.ListValuedKeys == nil {
+		return util.ConfigsEqual(external, config)
+	}
+	return util.ConfigsEqual(util.NormalizeConfig(external, r.ListValuedKeys), util.NormalizeConfig(config, r.ListValuedKeys))
+}
+
+// connectURL returns the URL of the Connect REST path on host, formatted from pathFormat and args.
+func (r *DebeziumConnectorReconciler) connectURL(host, pathFormat string, args ...interface{}) string {
+	return util.ConnectURL(host, r.ConnectBasePath, fmt.Sprintf(pathFormat, args...))
 }
 
 // connectorExists checks if a connector with the given name exists on the Debezium host.
-func (r *DebeziumConnectorReconciler) connectorExists(host, name string) (bool, error) {
-	url := fmt.Sprintf("%s/connectors/%s", host, name)
-	resp, err := r.HTTPClient.Get(url)
+func (r *DebeziumConnectorReconciler) connectorExists(ctx context.Context, host, name string) (bool, error) {
+	url := r.connectURL(host, "/connectors/%s", name)
+	resp, err := r.get(ctx, url)
 	if err != nil {
 		return false, err
 	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return false, err
+	}
 
 	// A 200 status indicates the connector exists.
 	if resp.StatusCode == http.StatusOK {
@@ -169,20 +775,23 @@ func (r *DebeziumConnectorReconciler) connectorExists(host, name string) (bool,
 
 	// For any other status, read the response for debugging.
 	body, _ := io.ReadAll(resp.Body)
-	return false, fmt.Errorf("unexpected response: %d, body: %s", resp.StatusCode, string(body))
+	return false, fmt.Errorf("unexpected response: %d, body: %s", resp.StatusCode, util.RedactText(string(body), nil))
 }
 
 // getDebeziumConnectorConfig sends a GET request to retrieves the current configuration.
-func (r *DebeziumConnectorReconciler) getDebeziumConnectorConfig(host, name string) (map[string]string, error) {
-	url := fmt.Sprintf("%s/connectors/%s/config", host, name)
-	resp, err := r.HTTPClient.Get(url)
+func (r *DebeziumConnectorReconciler) getDebeziumConnectorConfig(ctx context.Context, host, name string) (map[string]string, error) {
+	url := r.connectURL(host, "/connectors/%s/config", name)
+	resp, err := r.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to GET connector config: %w", err)
 	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GET connector config returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("GET connector config returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
 	}
 	var config map[string]string
 	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
@@ -192,8 +801,10 @@ func (r *DebeziumConnectorReconciler) getDebeziumConnectorConfig(host, name stri
 }
 
 // createDebeziumConnector sends a POST request to create a new connector.
-func (r *DebeziumConnectorReconciler) createDebeziumConnector(host string, config map[string]string) error {
-	url := fmt.Sprintf("%s/connectors", host)
+// If the response is lost or Connect answers 409, the connector's existence is re-checked so a
+// create that actually succeeded is not reported as a failure and retried.
+func (r *DebeziumConnectorReconciler) createDebeziumConnector(ctx context.Context, host string, config map[string]string) error {
+	url := r.connectURL(host, "/connectors")
 
 	payload := map[string]interface{}{
 		"name":   config["name"],
@@ -203,88 +814,378 @@ func (r *DebeziumConnectorReconciler) createDebeziumConnector(host string, confi
 	if err != nil {
 		return err
 	}
-	resp, err := r.HTTPClient.Post(url, "application/json", bytes.NewBuffer(data))
+	start := time.Now()
+	// The timeout bounds the request only; the existence checks after it run on ctx.
+	reqCtx, cancel := context.WithTimeout(ctx, r.operationTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.do(req)
+	if err != nil {
+		// The request may have reached Connect even though the response was lost.
+		if exists, existsErr := r.connectorExists(ctx, host, config["name"]); existsErr == nil && exists {
+			return nil
+		}
+		return r.asOperationTimeout(err, apiv1alpha1.ConditionCreateTimedOut, "create", start)
+	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return err
+	}
+	// Connect also answers 409 during rebalances, so only treat it as success if the connector exists.
+	if resp.StatusCode == http.StatusConflict {
+		if exists, existsErr := r.connectorExists(ctx, host, config["name"]); existsErr == nil && exists {
+			return nil
+		}
+	}
 	// Accept either 201 (Created) or 200 (OK) as successful responses.
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create connector, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to create connector, status: %d, body: %s", resp.StatusCode, util.RedactText(string(body), config))
 	}
 	return nil
 }
 
 // updateDebeziumConnector sends a PUT request to update the connector configuration.
-func (r *DebeziumConnectorReconciler) updateDebeziumConnector(host string, config map[string]string) error {
-	url := fmt.Sprintf("%s/connectors/%s/config", host, config["name"])
+func (r *DebeziumConnectorReconciler) updateDebeziumConnector(ctx context.Context, host string, config map[string]string) error {
+	url := r.connectURL(host, "/connectors/%s/config", config["name"])
 	data, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
+	start := time.Now()
+	reqCtx, cancel := context.WithTimeout(ctx, r.operationTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPut, url, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.HTTPClient.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
-		return err
+		return r.asOperationTimeout(err, apiv1alpha1.ConditionUpdateTimedOut, "update", start)
 	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return err
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update connector, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to update connector, status: %d, body: %s", resp.StatusCode, util.RedactText(string(body), config))
 	}
 	return nil
 }
 
 // deleteDebeziumConnector sends a DELETE request to remove the connector.
-func (r *DebeziumConnectorReconciler) deleteDebeziumConnector(host, name string) error {
-	url := fmt.Sprintf("%s/connectors/%s", host, name)
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+func (r *DebeziumConnectorReconciler) deleteDebeziumConnector(ctx context.Context, host, name string) error {
+	url := r.connectURL(host, "/connectors/%s", name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
-	resp, err := r.HTTPClient.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return err
+	}
+	// A 404 means the connector is already gone, e.g. when re-checking a previous deletion.
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete connector, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete connector, status: %d, body: %s", resp.StatusCode, util.RedactText(string(body), nil))
 	}
 	return nil
 }
 
-// getDebeziumConnectorState sends an GET to retrieves the connector state.
-func (r *DebeziumConnectorReconciler) getDebeziumConnectorState(host, name string) (string, error) {
-	url := fmt.Sprintf("%s/connectors/%s/status", host, name)
-	resp, err := r.HTTPClient.Get(url)
+// connectorStatus is the response of the Connect connector status endpoint.
+type connectorStatus struct {
+	Connector struct {
+		State    string `json:"state"`
+		WorkerID string `json:"worker_id"`
+	} `json:"connector"`
+	Tasks []taskStatus `json:"tasks"`
+}
+
+// taskStatus is the status of a single connector task.
+type taskStatus struct {
+	ID       int    `json:"id"`
+	State    string `json:"state"`
+	WorkerID string `json:"worker_id"`
+	Trace    string `json:"trace,omitempty"`
+}
+
+// getDebeziumConnectorStatus sends a GET to retrieve the connector and task states.
+func (r *DebeziumConnectorReconciler) getDebeziumConnectorStatus(ctx context.Context, host, name string) (*connectorStatus, error) {
+	url := r.connectURL(host, "/connectors/%s/status", name)
+	resp, err := r.get(ctx, url)
 	if err != nil {
-		return "", fmt.Errorf("failed to GET connector status: %w", err)
+		return nil, fmt.Errorf("failed to GET connector status: %w", err)
 	}
 	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("GET connector status returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("GET connector status returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
 	}
-	var statusResp struct {
-		Connector struct {
-			State string `json:"state"`
-		} `json:"connector"`
+	statusResp := &connectorStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(statusResp); err != nil {
+		return nil, fmt.Errorf("failed to decode connector status response: %w", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
-		return "", fmt.Errorf("failed to decode connector status response: %w", err)
+	return statusResp, nil
+}
+
+// connectorSummary describes a connector from the expanded connector listing.
+type connectorSummary struct {
+	Name   string
+	State  string
+	Type   string
+	Config map[string]string
+}
+
+// listConnectors sends a GET request to list the connectors on the Debezium host. The expanded
+// form includes each connector's status and info, and is decoded entry by entry so large fleets
+// don't have to be buffered in memory.
+func (r *DebeziumConnectorReconciler) listConnectors(ctx context.Context, host string) ([]connectorSummary, error) {
+	url := r.connectURL(host, "/connectors?expand=status&expand=info")
+	resp, err := r.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET connectors: %w", err)
 	}
-	return statusResp.Connector.State, nil
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GET connectors returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode connectors response: %w", err)
+	}
+	var connectors []connectorSummary
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode connectors response: %w", err)
+		}
+		name, _ := tok.(string)
+		var entry struct {
+			Status connectorStatus `json:"status"`
+			Info   struct {
+				Type   string            `json:"type"`
+				Config map[string]string `json:"config"`
+			} `json:"info"`
+		}
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("failed to decode connector %q: %w", name, err)
+		}
+		connectors = append(connectors, connectorSummary{
+			Name:   name,
+			State:  entry.Status.Connector.State,
+			Type:   entry.Info.Type,
+			Config: entry.Info.Config,
+		})
+	}
+	sort.Slice(connectors, func(i, j int) bool { return connectors[i].Name < connectors[j].Name })
+	return connectors, nil
+}
+
+// validationUnsupportedReason is the reason of the Validated condition when the Debezium host
+// does not offer the validate endpoint.
+const validationUnsupportedReason = "Unsupported"
+
+// errValidationUnsupported is returned when the Debezium host does not offer the validate endpoint.
+var errValidationUnsupported = errors.New("config validation is not supported by the Debezium host")
+
+// recordValidation runs the Debezium validate endpoint against the desired config and
+// records the outcome, together with the Connect version, in the Validated condition.
+func (r *DebeziumConnectorReconciler) recordValidation(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) {
+	info, err := r.getConnectServerInfo(ctx, dbc.Spec.DebeziumHost)
+	if err == nil {
+		dbc.Status.ConnectVersion = info.Version
+	}
+
+	cond := metav1.Condition{
+		Type:               apiv1alpha1.ConditionValidated,
+		ObservedGeneration: dbc.Generation,
+	}
+	dbc.Status.ValidatedOverridesHash = dbc.ConfigOverridesHash()
+	validationErrs, err := r.validateConnectorConfig(ctx, dbc.Spec.DebeziumHost, config)
+	switch {
+	case errors.Is(err, errValidationUnsupported):
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = validationUnsupportedReason
+		cond.Message = err.Error()
+	case err != nil:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = "ValidationError"
+		cond.Message = util.RedactText(err.Error(), config)
+	case len(validationErrs) > 0:
+		keys := make([]string, 0, len(validationErrs))
+		for key := range validationErrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ValidationFailed"
+		cond.Message = fmt.Sprintf("Debezium rejected config keys: %s", strings.Join(keys, ", "))
+	default:
+		now := metav1.Now()
+		dbc.Status.LastValidationTime = &now
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Accepted"
+		cond.Message = fmt.Sprintf("Configuration accepted by Kafka Connect %s at %s", dbc.Status.ConnectVersion, now.UTC().Format(time.RFC3339))
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, cond)
+}
+
+// validateConnectorConfig sends a POST request to the plugin validate endpoint and
+// returns the validation errors reported per config key.
+func (r *DebeziumConnectorReconciler) validateConnectorConfig(ctx context.Context, host string, config map[string]string) (map[string]string, error) {
+	url := r.connectURL(host, "/connector-plugins/%s/config/validate", config["connector.class"])
+	payload := map[string]interface{}{
+		"name":   config["name"],
+		"config": config,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.post(ctx, url, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to POST config validation: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, errValidationUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("config validation returned status %d: %s", resp.StatusCode, util.RedactText(string(body), config))
+	}
+	var validationResp struct {
+		Errors  map[string]string  `json:"errors"`
+		Configs []configDefinition `json:"configs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&validationResp); err != nil {
+		return nil, fmt.Errorf("failed to decode validation response: %w", err)
+	}
+	r.recordValueTypes(config["connector.class"], validationResp.Configs)
+	return validationResp.Errors, nil
+}
+
+// configReader returns the client used to read Secrets and ConfigMaps referenced by dbc.
+// When impersonation is enabled and dbc names a service account, reads are performed as that
+// service account so a tenant can only resolve objects its own RBAC grants access to. Otherwise
+// Secrets are read through SecretCache when it is set.
+func (r *DebeziumConnectorReconciler) configReader(dbc *apiv1alpha1.DebeziumConnector) (client.Reader, error) {
+	serviceAccount := dbc.Annotations[apiv1alpha1.ServiceAccountAnnotation]
+	if serviceAccount == "" {
+		if r.SecretCache != nil {
+			return r.SecretCache, nil
+		}
+		return r.Client, nil
+	}
+	if r.RestConfig == nil {
+		return nil, fmt.Errorf("annotation %s is set but impersonation is not enabled", apiv1alpha1.ServiceAccountAnnotation)
+	}
+	return r.impersonated.get(r.RestConfig, r.Scheme(), dbc.Namespace, serviceAccount)
+}
+
+// recordEvent emits an event on dbc when an event recorder is configured.
+func (r *DebeziumConnectorReconciler) recordEvent(dbc *apiv1alpha1.DebeziumConnector, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(dbc, eventtype, reason, messageFmt, args...)
+}
+
+// configSecretRefField indexes DebeziumConnectors by the name of their config Secret.
+const configSecretRefField = ".spec.configSecretRef.name"
+
+// findConnectorsForSecret maps a Secret to the DebeziumConnectors that reference it.
+func (r *DebeziumConnectorReconciler) findConnectorsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list, client.InNamespace(secret.GetNamespace()), client.MatchingFields{configSecretRefField: secret.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DebeziumConnectors for secret", "secret", secret.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.Namespace, Name: item.Name}})
+	}
+	return requests
+}
+
+// secretReferenced reports whether a DebeziumConnector references the Secret key through
+// ConfigSecretRef.
+func (r *DebeziumConnectorReconciler) secretReferenced(ctx context.Context, key types.NamespacedName) (bool, error) {
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list, client.InNamespace(key.Namespace), client.MatchingFields{configSecretRefField: key.Name}); err != nil {
+		return false, err
+	}
+	return len(list.Items) > 0, nil
+}
+
+// indexConfigSecretRef indexes a DebeziumConnector by the name of its config Secret.
+func indexConfigSecretRef(obj client.Object) []string {
+	dbc := obj.(*apiv1alpha1.DebeziumConnector)
+	if dbc.Spec.ConfigSecretRef == nil {
+		return nil
+	}
+	return []string{dbc.Spec.ConfigSecretRef.Name}
+}
+
+// findConnectorsForReferencedSecret maps a Secret watched by SecretCache to the
+// DebeziumConnectors that reference it, and stops watching it once none does.
+func (r *DebeziumConnectorReconciler) findConnectorsForReferencedSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	requests := r.findConnectorsForSecret(ctx, secret)
+	if len(requests) == 0 {
+		r.SecretCache.Forget(client.ObjectKeyFromObject(secret))
+	}
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DebeziumConnectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&apiv1alpha1.DebeziumConnector{}).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1alpha1.DebeziumConnector{}, configSecretRefField, indexConfigSecretRef); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1alpha1.DebeziumConnector{}, connectorNameField, indexConnectorName); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1alpha1.DebeziumConnector{}, templateRefsField, indexTemplateRefs); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1alpha1.DebeziumConnector{}, builder.WithPredicates(r.connectorPredicates()...))
+	if r.SecretCache != nil {
+		if r.SecretCache.Referenced == nil {
+			r.SecretCache.Referenced = r.secretReferenced
+		}
+		if err := mgr.Add(r.SecretCache); err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(&source.Channel{Source: r.SecretCache.Events()}, handler.EnqueueRequestsFromMapFunc(r.findConnectorsForReferencedSecret))
+	} else {
+		b = b.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findConnectorsForSecret))
+	}
+	return b.
+		Watches(&apiv1alpha1.DebeziumConnectorTemplate{}, handler.EnqueueRequestsFromMapFunc(r.findConnectorsForTemplate)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.findConnectorsForNamespace)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Debezium REST helpers", func() {
+	ctx := context.Background()
+	config := map[string]string{
+		"name":            "inventory",
+		"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+	}
+
+	Context("When creating a connector", func() {
+		var (
+			server  *httptest.Server
+			created atomic.Bool
+			posts   atomic.Int32
+		)
+
+		// newServer serves connector existence from the created flag and answers POSTs with onPost.
+		newServer := func(onPost func(w http.ResponseWriter)) *httptest.Server {
+			return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/connectors":
+					posts.Add(1)
+					onPost(w)
+				case r.Method == http.MethodGet && r.URL.Path == "/connectors/inventory":
+					if created.Load() {
+						w.WriteHeader(http.StatusOK)
+						return
+					}
+					w.WriteHeader(http.StatusNotFound)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+		}
+
+		BeforeEach(func() {
+			created.Store(false)
+			posts.Store(0)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("treats a lost response as success when the connector now exists", func() {
+			server = newServer(func(w http.ResponseWriter) {
+				// Connect creates the connector, but the response never reaches the operator.
+				created.Store(true)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				Expect(err).NotTo(HaveOccurred())
+				conn.Close()
+			})
+			r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+
+			Expect(r.createDebeziumConnector(ctx, server.URL, config)).To(Succeed())
+			Expect(posts.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("treats a 409 as success when the connector exists", func() {
+			created.Store(true)
+			server = newServer(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusConflict)
+			})
+			r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+
+			Expect(r.createDebeziumConnector(ctx, server.URL, config)).To(Succeed())
+		})
+
+		It("fails on a 409 when the connector does not exist", func() {
+			server = newServer(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusConflict)
+			})
+			r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+
+			Expect(r.createDebeziumConnector(ctx, server.URL, config)).NotTo(Succeed())
+		})
+	})
+
+	Context("When listing connectors", func() {
+		It("decodes the expanded listing", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Query()["expand"]).To(ConsistOf("status", "info"))
+				_, _ = w.Write([]byte(`{
+					"orders": {"status": {"connector": {"state": "PAUSED"}}, "info": {"type": "source", "config": {"name": "orders"}}},
+					"inventory": {"status": {"connector": {"state": "RUNNING"}}, "info": {"type": "source", "config": {"name": "inventory"}}}
+				}`))
+			}))
+			defer server.Close()
+			r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+
+			connectors, err := r.listConnectors(ctx, server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connectors).To(HaveLen(2))
+			Expect(connectors[0].Name).To(Equal("inventory"))
+			Expect(connectors[0].State).To(Equal("RUNNING"))
+			Expect(connectors[1].Name).To(Equal("orders"))
+			Expect(connectors[1].Config).To(HaveKeyWithValue("name", "orders"))
+		})
+	})
+})
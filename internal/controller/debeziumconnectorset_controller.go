@@ -0,0 +1,370 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// DebeziumConnectorSetReconciler reconciles a DebeziumConnectorSet object
+type DebeziumConnectorSetReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// Connectors provides the Debezium client, config transformers and pause setting shared
+	// with DebeziumConnectors.
+	Connectors *DebeziumConnectorReconciler
+}
+
+// Finalizer name for DebeziumConnectorSet
+const debeziumSetFinalizer = "debeziumconnectorset.finalizers.api.debezium"
+
+// setMember is a connector of a DebeziumConnectorSet with its resolved config.
+type setMember struct {
+	host   string
+	config map[string]string
+}
+
+//+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectorsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectorsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=api.debezium,resources=debeziumconnectorsets/finalizers,verbs=update
+
+func (r *DebeziumConnectorSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	r.Connectors.settingsMu.RLock()
+	defer r.Connectors.settingsMu.RUnlock()
+
+	set := &apiv1alpha1.DebeziumConnectorSet{}
+	if err := r.Get(ctx, req.NamespacedName, set); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("DebeziumConnectorSet resource not found; it may have been deleted.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get DebeziumConnectorSet")
+		return ctrl.Result{}, err
+	}
+
+	if r.Connectors.HTTPClient == nil {
+		r.Connectors.HTTPClient = defaultHTTPClient
+	}
+
+	// Handle deletion: remove every connector of the set before releasing the finalizer.
+	if !set.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(set, debeziumSetFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if r.Connectors.PauseReconciliation {
+			logger.Info("Reconciliation paused; deferring connector deletion")
+			return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+		for _, status := range set.Status.Connectors {
+			if err := r.Connectors.deleteDebeziumConnector(ctx, status.DebeziumHost, status.Name); err != nil {
+				logger.Error(err, "failed to delete Debezium connector", "name", status.Name)
+				return ctrl.Result{}, err
+			}
+			forgetDrift(status.Name, status.DebeziumHost)
+		}
+		// Connectors that never made it into the status may still have been created.
+		for _, spec := range set.Spec.Connectors {
+			host := setMemberHost(set, spec)
+			if err := r.Connectors.deleteDebeziumConnector(ctx, host, spec.Name); err != nil {
+				logger.Error(err, "failed to delete Debezium connector", "name", spec.Name)
+				return ctrl.Result{}, err
+			}
+			forgetDrift(spec.Name, host)
+		}
+		if err := removeFinalizer(ctx, r.Client, set, debeziumSetFinalizer); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present.
+	if !controllerutil.ContainsFinalizer(set, debeziumSetFinalizer) {
+		if err := addFinalizer(ctx, r.Client, set, debeziumSetFinalizer); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Resolve every config before touching the Debezium hosts, so an invalid connector does not
+	// leave the set partially applied.
+	members, err := r.resolveMembers(set)
+	if err != nil {
+		logger.Error(err, "invalid connector set config")
+		r.recordEvent(set, corev1.EventTypeWarning, "InvalidConfig", "%v", err)
+		meta.SetStatusCondition(&set.Status.Conditions, metav1.Condition{
+			Type:    apiv1alpha1.ConditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidConfig",
+			Message: err.Error(),
+		})
+		if err := r.updateStatus(ctx, set); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	var reconcileErr error
+	var rolloutErrs map[string]error
+	rolling := set.Spec.UpdateStrategy == apiv1alpha1.SetUpdateStrategyRollingUpdate
+	if !r.Connectors.PauseReconciliation {
+		if err := r.pruneRemovedMembers(ctx, set, members); err != nil {
+			reconcileErr = err
+		}
+		if rolling {
+			set.Status.Rollout, rolloutErrs = r.rollingUpdate(ctx, set, members)
+		}
+	}
+	if !rolling {
+		set.Status.Rollout = nil
+	}
+
+	statuses := make([]apiv1alpha1.ConnectorSetMemberStatus, 0, len(members))
+	ready := 0
+	// Follow the spec order, so the status does not change between reconciles.
+	for _, spec := range set.Spec.Connectors {
+		member := members[spec.Name]
+		status := apiv1alpha1.ConnectorSetMemberStatus{Name: spec.Name, DebeziumHost: member.host}
+		if !r.Connectors.PauseReconciliation {
+			err := rolloutErrs[spec.Name]
+			if !rolling {
+				err = r.reconcileMember(ctx, member)
+			}
+			if err != nil {
+				logger.Error(err, "failed to reconcile connector", "name", status.Name)
+				status.Message = err.Error()
+				reconcileErr = err
+			}
+		}
+		if state, err := r.Connectors.getDebeziumConnectorStatus(ctx, member.host, status.Name); err != nil {
+			logger.Error(err, "failed to get connector status", "name", status.Name)
+			if status.Message == "" {
+				status.Message = err.Error()
+			}
+		} else {
+			status.ConnectorStatus = state.Connector.State
+		}
+		if status.ConnectorStatus == "RUNNING" {
+			ready++
+		}
+		statuses = append(statuses, status)
+	}
+	// Keep connectors that could not be pruned, so their deletion is retried.
+	for _, previous := range set.Status.Connectors {
+		if _, ok := members[previous.Name]; !ok && previous.Message != "" {
+			statuses = append(statuses, previous)
+		}
+	}
+	set.Status.Connectors = statuses
+	set.Status.ReadyConnectors = fmt.Sprintf("%d/%d", ready, len(members))
+	set.Status.ObservedGeneration = set.Generation
+
+	condition := metav1.Condition{
+		Type:    apiv1alpha1.ConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllRunning",
+		Message: "All connectors are RUNNING",
+	}
+	if ready < len(members) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NotAllRunning"
+		condition.Message = fmt.Sprintf("%d of %d connectors are RUNNING", ready, len(members))
+	}
+	meta.SetStatusCondition(&set.Status.Conditions, condition)
+
+	if err := r.updateStatus(ctx, set); err != nil {
+		return ctrl.Result{}, err
+	}
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+	if rollout := set.Status.Rollout; rollout != nil && (len(rollout.Updating) > 0 || len(rollout.Pending) > 0) &&
+		!r.Connectors.PauseReconciliation && rolloutPollInterval < set.ReconcileInterval() {
+		return ctrl.Result{RequeueAfter: rolloutPollInterval}, nil
+	}
+	return ctrl.Result{RequeueAfter: set.ReconcileInterval()}, nil
+}
+
+// setMemberHost returns the Debezium host of a connector in set.
+func setMemberHost(set *apiv1alpha1.DebeziumConnectorSet, spec apiv1alpha1.NamedConnectorSpec) string {
+	if spec.DebeziumHost != "" {
+		return spec.DebeziumHost
+	}
+	return set.Spec.DebeziumHost
+}
+
+// connectorSetMembers returns the connectors of all DebeziumConnectorSets by Debezium host, with
+// trailing slashes trimmed, and connector name, mapped to the set they belong to. Connectors
+// removed from a set that were not deleted from their host yet are included.
+func connectorSetMembers(ctx context.Context, c client.Reader) (map[string]map[string]*apiv1alpha1.DebeziumConnectorSet, error) {
+	list := &apiv1alpha1.DebeziumConnectorSetList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, err
+	}
+	members := map[string]map[string]*apiv1alpha1.DebeziumConnectorSet{}
+	add := func(set *apiv1alpha1.DebeziumConnectorSet, host, name string) {
+		host = strings.TrimSuffix(host, "/")
+		if host == "" {
+			return
+		}
+		if members[host] == nil {
+			members[host] = map[string]*apiv1alpha1.DebeziumConnectorSet{}
+		}
+		members[host][name] = set
+	}
+	for i := range list.Items {
+		set := &list.Items[i]
+		for _, spec := range set.Spec.Connectors {
+			add(set, setMemberHost(set, spec), spec.Name)
+		}
+		for _, status := range set.Status.Connectors {
+			add(set, status.DebeziumHost, status.Name)
+		}
+	}
+	return members, nil
+}
+
+// resolveMembers builds the config sent to the Debezium host for every connector of set, keyed
+// by connector name. It fails if any connector's config is invalid.
+func (r *DebeziumConnectorSetReconciler) resolveMembers(set *apiv1alpha1.DebeziumConnectorSet) (map[string]setMember, error) {
+	members := make(map[string]setMember, len(set.Spec.Connectors))
+	for _, spec := range set.Spec.Connectors {
+		if _, ok := members[spec.Name]; ok {
+			return nil, fmt.Errorf("connector %s is declared more than once", spec.Name)
+		}
+		config := make(map[string]string, len(spec.Config)+1)
+		for k, v := range spec.Config {
+			config[k] = v
+		}
+		config["name"] = spec.Name
+		if config["connector.class"] == "" {
+			return nil, fmt.Errorf("connector %s: connector.class is required", spec.Name)
+		}
+		config, err := r.Connectors.transformConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("connector %s: %w", spec.Name, err)
+		}
+		members[spec.Name] = setMember{host: setMemberHost(set, spec), config: config}
+	}
+	return members, nil
+}
+
+// reconcileMember creates the connector on its Debezium host, or updates it when its
+// configuration drifted.
+func (r *DebeziumConnectorSetReconciler) reconcileMember(ctx context.Context, member setMember) error {
+	exists, inSync, err := r.memberInSync(ctx, member)
+	if err != nil || inSync {
+		return err
+	}
+	return r.applyMember(ctx, member, exists)
+}
+
+// memberInSync reports whether the connector of member exists on its Debezium host and whether
+// it runs the desired config.
+func (r *DebeziumConnectorSetReconciler) memberInSync(ctx context.Context, member setMember) (exists, inSync bool, err error) {
+	name := member.config["name"]
+	exists, err = r.Connectors.connectorExists(ctx, member.host, name)
+	if err != nil || !exists {
+		return exists, false, err
+	}
+	externalConfig, err := r.Connectors.getDebeziumConnectorConfig(ctx, member.host, name)
+	if err != nil {
+		return true, false, err
+	}
+	return true, r.Connectors.configsEqual(externalConfig, member.config), nil
+}
+
+// applyMember creates the connector of member, or updates an existing one to the desired config.
+func (r *DebeziumConnectorSetReconciler) applyMember(ctx context.Context, member setMember, exists bool) error {
+	name := member.config["name"]
+	if !exists {
+		if err := r.Connectors.createDebeziumConnector(ctx, member.host, member.config); err != nil {
+			return err
+		}
+		log.FromContext(ctx).Info("Debezium connector created", "name", name)
+		return nil
+	}
+	recordDrift(name, member.host)
+	if err := r.Connectors.updateDebeziumConnector(ctx, member.host, member.config); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Debezium connector updated to match CR", "name", name)
+	return nil
+}
+
+// pruneRemovedMembers deletes the connectors that were removed from the set, or moved to another
+// host, since the last reconcile. Connectors that fail to delete are marked in the status.
+func (r *DebeziumConnectorSetReconciler) pruneRemovedMembers(ctx context.Context, set *apiv1alpha1.DebeziumConnectorSet, members map[string]setMember) error {
+	var pruneErr error
+	for i, previous := range set.Status.Connectors {
+		if member, ok := members[previous.Name]; ok && member.host == previous.DebeziumHost {
+			continue
+		}
+		if err := r.Connectors.deleteDebeziumConnector(ctx, previous.DebeziumHost, previous.Name); err != nil {
+			log.FromContext(ctx).Error(err, "failed to delete removed connector", "name", previous.Name)
+			set.Status.Connectors[i].Message = err.Error()
+			pruneErr = err
+			continue
+		}
+		set.Status.Connectors[i].Message = ""
+		forgetDrift(previous.Name, previous.DebeziumHost)
+		log.FromContext(ctx).Info("Debezium connector removed from set deleted", "name", previous.Name)
+	}
+	return pruneErr
+}
+
+// updateStatus writes the status of set, retrying on conflicts with the latest version.
+func (r *DebeziumConnectorSetReconciler) updateStatus(ctx context.Context, set *apiv1alpha1.DebeziumConnectorSet) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &apiv1alpha1.DebeziumConnectorSet{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(set), latest); err != nil {
+			return err
+		}
+		latest.Status = set.Status
+		return r.Status().Update(ctx, latest)
+	})
+}
+
+// recordEvent emits an event on set when an event recorder is configured.
+func (r *DebeziumConnectorSetReconciler) recordEvent(set *apiv1alpha1.DebeziumConnectorSet, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(set, eventtype, reason, messageFmt, args...)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DebeziumConnectorSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1alpha1.DebeziumConnectorSet{}).
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("DebeziumConnectorSet Controller", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "cdc", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newTestSet := func() *apiv1alpha1.DebeziumConnectorSet {
+		return &apiv1alpha1.DebeziumConnectorSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Generation: 1,
+				Finalizers: []string{debeziumSetFinalizer},
+			},
+			Spec: apiv1alpha1.DebeziumConnectorSetSpec{
+				DebeziumHost: connect.URL,
+				Connectors: []apiv1alpha1.NamedConnectorSpec{
+					{Name: "inventory", Config: map[string]string{"connector.class": "io.debezium.connector.mysql.MySqlConnector"}},
+					{Name: "orders", Config: map[string]string{"connector.class": "io.debezium.connector.postgresql.PostgresConnector"}},
+				},
+			},
+		}
+	}
+
+	newReconciler := func(set *apiv1alpha1.DebeziumConnectorSet) *DebeziumConnectorSetReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(set).WithStatusSubresource(set).Build()
+		return &DebeziumConnectorSetReconciler{
+			Client:     c,
+			Connectors: &DebeziumConnectorReconciler{Client: c, HTTPClient: connect.Client()},
+		}
+	}
+
+	latest := func(c client.Client) *apiv1alpha1.DebeziumConnectorSet {
+		set := &apiv1alpha1.DebeziumConnectorSet{}
+		Expect(c.Get(ctx, key, set)).To(Succeed())
+		return set
+	}
+
+	It("creates all connectors and aggregates their status", func() {
+		r := newReconciler(newTestSet())
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(apiv1alpha1.DefaultReconcileInterval))
+
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors", "POST /connectors"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("name", "inventory"))
+		Expect(connect.configs["orders"]).To(HaveKeyWithValue("name", "orders"))
+
+		set := latest(r.Client)
+		Expect(set.Status.Connectors).To(Equal([]apiv1alpha1.ConnectorSetMemberStatus{
+			{Name: "inventory", DebeziumHost: connect.URL, ConnectorStatus: "RUNNING"},
+			{Name: "orders", DebeziumHost: connect.URL, ConnectorStatus: "RUNNING"},
+		}))
+		Expect(set.Status.ReadyConnectors).To(Equal("2/2"))
+		Expect(set.Status.ObservedGeneration).To(Equal(int64(1)))
+		Expect(meta.IsStatusConditionTrue(set.Status.Conditions, apiv1alpha1.ConditionReady)).To(BeTrue())
+	})
+
+	It("reports the set as not ready while a connector is not RUNNING", func() {
+		connect.setConnector(map[string]string{
+			"name":            "orders",
+			"connector.class": "io.debezium.connector.postgresql.PostgresConnector",
+		}, "FAILED")
+		r := newReconciler(newTestSet())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		set := latest(r.Client)
+		Expect(set.Status.ReadyConnectors).To(Equal("1/2"))
+		cond := meta.FindStatusCondition(set.Status.Conditions, apiv1alpha1.ConditionReady)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("NotAllRunning"))
+	})
+
+	It("does not create any connector while one config is invalid", func() {
+		set := newTestSet()
+		set.Spec.Connectors[1].Config = map[string]string{"tasks.max": "1"}
+		r := newReconciler(set)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest(r.Client).Status.Conditions, apiv1alpha1.ConditionReady)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("InvalidConfig"))
+		Expect(cond.Message).To(ContainSubstring("orders"))
+	})
+
+	It("updates drifted connectors", func() {
+		connect.setConnector(map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"tasks.max":       "4",
+		}, "RUNNING")
+		r := newReconciler(newTestSet())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connect.mutations()).To(ConsistOf("PUT /connectors/inventory/config", "POST /connectors"))
+		Expect(connect.configs["inventory"]).NotTo(HaveKey("tasks.max"))
+	})
+
+	It("deletes connectors removed from the set", func() {
+		r := newReconciler(newTestSet())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		set := latest(r.Client)
+		set.Spec.Connectors = set.Spec.Connectors[:1]
+		Expect(r.Update(ctx, set)).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connect.configs).To(HaveKey("inventory"))
+		Expect(connect.configs).NotTo(HaveKey("orders"))
+		set = latest(r.Client)
+		Expect(set.Status.Connectors).To(HaveLen(1))
+		Expect(set.Status.ReadyConnectors).To(Equal("1/1"))
+	})
+
+	It("deletes all connectors when the set is deleted", func() {
+		r := newReconciler(newTestSet())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.configs).To(HaveLen(2))
+
+		Expect(r.Delete(ctx, latest(r.Client))).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connect.configs).To(BeEmpty())
+		Expect(r.Get(ctx, key, &apiv1alpha1.DebeziumConnectorSet{})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// deepCheck is when the config of a connector was last compared with the Debezium host, and how
+// many reconciles only checked its status since.
+type deepCheck struct {
+	time    time.Time
+	skipped int
+}
+
+// deepChecks holds the deep checks of all connectors by UID. They are kept in memory rather than
+// in the status, so a reconcile that skips the deep check does not write the status, which
+// would trigger another reconcile. After a restart every connector is deep checked once.
+type deepChecks struct {
+	mu     sync.Mutex
+	checks map[types.UID]deepCheck
+}
+
+// get returns the last deep check of uid.
+func (d *deepChecks) get(uid types.UID) (deepCheck, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	check, ok := d.checks[uid]
+	return check, ok
+}
+
+// checked records a deep check of uid at now.
+func (d *deepChecks) checked(uid types.UID, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.checks == nil {
+		d.checks = map[types.UID]deepCheck{}
+	}
+	d.checks[uid] = deepCheck{time: now}
+}
+
+// skipped records a reconcile of uid that skipped the deep check.
+func (d *deepChecks) skipped(uid types.UID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if check, ok := d.checks[uid]; ok {
+		check.skipped++
+		d.checks[uid] = check
+	}
+}
+
+// forget drops the deep checks of uid, e.g. once its resource was deleted.
+func (d *deepChecks) forget(uid types.UID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.checks, uid)
+}
+
+// canSkipDeepCheck reports whether the config comparison with the Debezium host can be skipped:
+// the spec and resolved config are unchanged since the last deep check, the connector was
+// RUNNING, and the deep check is neither overdue by count nor by time.
+func (r *DebeziumConnectorReconciler) canSkipDeepCheck(dbc *apiv1alpha1.DebeziumConnector, configHash string) bool {
+	if r.DeepCheckEvery < 2 {
+		return false
+	}
+	check, ok := r.deepChecks.get(dbc.UID)
+	if !ok {
+		return false
+	}
+	window := time.Duration(r.DeepCheckEvery) * dbc.ReconcileInterval()
+	return dbc.Status.ObservedGeneration == dbc.Generation &&
+		dbc.Status.ConfigHash == configHash &&
+		dbc.Status.ConnectorStatus == "RUNNING" &&
+		check.skipped+1 < r.DeepCheckEvery &&
+		time.Since(check.time) < window
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Deep check interval", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		dbc := newTestDebeziumConnector(connect.URL)
+		r = &DebeziumConnectorReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:     connect.Client(),
+			DeepCheckEvery: 3,
+		}
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileOnce := func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	configReads := func() int {
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		n := 0
+		for _, req := range connect.requests {
+			if req == "GET /connectors/inventory/config" {
+				n++
+			}
+		}
+		return n
+	}
+
+	It("only checks the status while the spec is unchanged and RUNNING", func() {
+		reconcileOnce() // creates the connector
+		reconcileOnce()
+		reconcileOnce()
+		Expect(configReads()).To(BeZero())
+
+		reconcileOnce() // deep check after DeepCheckEvery reconciles
+		Expect(configReads()).To(Equal(1))
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+	})
+
+	It("does not write the status of a connector in steady state", func() {
+		reconcileOnce()
+		reconcileOnce()
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		resourceVersion := latest.ResourceVersion
+
+		reconcileOnce() // skips the deep check
+		reconcileOnce() // deep checks
+		Expect(configReads()).To(Equal(1))
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.ResourceVersion).To(Equal(resourceVersion))
+	})
+
+	It("periodically deep checks to revert out-of-band drift", func() {
+		reconcileOnce()
+		connect.setConnector(map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"tasks.max":       "4",
+		}, "RUNNING")
+
+		reconcileOnce()
+		reconcileOnce()
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+
+		reconcileOnce()
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors", "PUT /connectors/inventory/config"))
+	})
+
+	It("deep checks as soon as the spec changes", func() {
+		reconcileOnce()
+		reconcileOnce()
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		latest.Spec.Config["tasks.max"] = "2"
+		latest.Generation++
+		Expect(r.Update(ctx, latest)).To(Succeed())
+
+		reconcileOnce()
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors", "PUT /connectors/inventory/config"))
+	})
+})
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector deletion", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		server    *httptest.Server
+		lingering atomic.Int32
+	)
+
+	BeforeEach(func() {
+		// The connector keeps showing up for a number of GETs after it was deleted.
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodGet:
+				if lingering.Add(-1) >= 0 {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newReconciler := func() *DebeziumConnectorReconciler {
+		now := metav1.Now()
+		dbc := &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              key.Name,
+				Namespace:         key.Namespace,
+				DeletionTimestamp: &now,
+				Finalizers:        []string{debeziumFinalizer},
+			},
+			Spec: apiv1alpha1.DebeziumConnectorSpec{
+				DebeziumHost: server.URL,
+				Config:       map[string]string{"name": "inventory", "connector.class": "io.debezium.connector.mysql.MySqlConnector"},
+			},
+		}
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: server.Client(),
+		}
+	}
+
+	It("keeps the finalizer while the connector lingers, then removes it", func() {
+		lingering.Store(2)
+		r := newReconciler()
+
+		for i := 1; i <= 2; i++ {
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(deletionCheckInterval))
+
+			dbc := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, dbc)).To(Succeed())
+			Expect(dbc.Finalizers).To(ContainElement(debeziumFinalizer))
+			Expect(dbc.Status.DeletionChecks).To(BeEquivalentTo(i))
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errors.IsNotFound(r.Get(ctx, key, &apiv1alpha1.DebeziumConnector{}))).To(BeTrue())
+	})
+
+	It("removes the finalizer after the maximum number of checks", func() {
+		lingering.Store(100)
+		r := newReconciler()
+
+		for i := 0; i < maxDeletionChecks; i++ {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errors.IsNotFound(r.Get(ctx, key, &apiv1alpha1.DebeziumConnector{}))).To(BeTrue())
+	})
+})
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// desiredStateTransition is the Connect state of a desired state and the action that reaches it.
+type desiredStateTransition struct {
+	state  string
+	action string
+}
+
+var desiredStateTransitions = map[string]desiredStateTransition{
+	apiv1alpha1.DesiredStateRunning: {state: "RUNNING", action: "resume"},
+	apiv1alpha1.DesiredStatePaused:  {state: "PAUSED", action: "pause"},
+	apiv1alpha1.DesiredStateStopped: {state: "STOPPED", action: "stop"},
+}
+
+// desiredStateAction returns the action that moves a connector in state to desired, or "" when
+// none is needed. Failed and unassigned connectors are not resumed; restarting them is left to
+// Connect, the circuit breaker and RestartUnassignedAfter.
+func desiredStateAction(desired, state string) string {
+	transition, ok := desiredStateTransitions[desired]
+	if !ok || state == transition.state {
+		return ""
+	}
+	switch state {
+	case "PAUSED", "STOPPED":
+		return transition.action
+	case "RUNNING", "FAILED":
+		if desired != apiv1alpha1.DesiredStateRunning {
+			return transition.action
+		}
+	}
+	return ""
+}
+
+// reconcileDesiredState moves the connector to Spec.DesiredState when it was paused, resumed or
+// stopped out of band. An open circuit and an interrupted reconfiguration take precedence.
+func (r *DebeziumConnectorReconciler) reconcileDesiredState(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *connectorStatus, until time.Time) error {
+	if dbc.Spec.DesiredState == "" || !r.mutationsAllowed(dbc, until) ||
+		meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionCircuitOpen) ||
+		reconfigurationInProgress(dbc) {
+		return nil
+	}
+	state := status.Connector.State
+	action := desiredStateAction(dbc.Spec.DesiredState, state)
+	if action == "" {
+		return nil
+	}
+	if action == "stop" && !r.requireFeature(ctx, dbc, featureStop) {
+		return nil
+	}
+	if err := r.changeConnectorState(ctx, dbc.Spec.DebeziumHost, name, action); err != nil {
+		return fmt.Errorf("failed to %s connector: %w", action, err)
+	}
+	r.recordEvent(dbc, corev1.EventTypeNormal, "DesiredStateEnforced",
+		"Connector %s was %s; sent %s to match the desired state %s", name, state, action, dbc.Spec.DesiredState)
+	log.FromContext(ctx).Info("Connector moved to desired state", "name", name, "from", state, "desiredState", dbc.Spec.DesiredState)
+	return nil
+}
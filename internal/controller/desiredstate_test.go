@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Desired connector state", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileInState := func(desired, state string, mutate func(*apiv1alpha1.DebeziumConnector)) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.DesiredState = desired
+		if mutate != nil {
+			mutate(dbc)
+		}
+		connect.setConnector(dbc.Spec.Config, state)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	DescribeTable("moves the connector to the desired state",
+		func(desired, state, expected string) {
+			reconcileInState(desired, state, nil)
+			if expected == "" {
+				Expect(connect.mutations()).To(BeEmpty())
+				Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			Expect(connect.mutations()).To(Equal([]string{expected}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DesiredStateEnforced")))
+		},
+		Entry("resumes a paused connector", apiv1alpha1.DesiredStateRunning, "PAUSED", "PUT /connectors/inventory/resume"),
+		Entry("resumes a stopped connector", apiv1alpha1.DesiredStateRunning, "STOPPED", "PUT /connectors/inventory/resume"),
+		Entry("leaves a running connector", apiv1alpha1.DesiredStateRunning, "RUNNING", ""),
+		Entry("does not resume a failed connector", apiv1alpha1.DesiredStateRunning, "FAILED", ""),
+		Entry("pauses a running connector", apiv1alpha1.DesiredStatePaused, "RUNNING", "PUT /connectors/inventory/pause"),
+		Entry("pauses a stopped connector", apiv1alpha1.DesiredStatePaused, "STOPPED", "PUT /connectors/inventory/pause"),
+		Entry("leaves a paused connector", apiv1alpha1.DesiredStatePaused, "PAUSED", ""),
+		Entry("stops a running connector", apiv1alpha1.DesiredStateStopped, "RUNNING", "PUT /connectors/inventory/stop"),
+		Entry("stops a paused connector", apiv1alpha1.DesiredStateStopped, "PAUSED", "PUT /connectors/inventory/stop"),
+		Entry("does not enforce an unset state", "", "PAUSED", ""),
+	)
+
+	It("does not override the paused annotation or an open circuit", func() {
+		reconcileInState(apiv1alpha1.DesiredStateRunning, "PAUSED", func(dbc *apiv1alpha1.DebeziumConnector) {
+			dbc.Annotations = map[string]string{apiv1alpha1.ReconcilePausedAnnotation: "true"}
+		})
+		Expect(connect.mutations()).To(BeEmpty())
+
+		reconcileInState(apiv1alpha1.DesiredStateRunning, "PAUSED", func(dbc *apiv1alpha1.DebeziumConnector) {
+			dbc.Spec.CircuitBreaker = &apiv1alpha1.CircuitBreakerSpec{MaxFailures: 3, WindowSeconds: 60}
+			dbc.Status.Conditions = []metav1.Condition{{
+				Type:               apiv1alpha1.ConditionCircuitOpen,
+				Status:             metav1.ConditionTrue,
+				Reason:             "TooManyFailures",
+				LastTransitionTime: metav1.Now(),
+			}}
+		})
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+
+	It("reports that Stopped is unsupported on Connect before 3.5", func() {
+		connect.version = "3.4.1"
+		latest := reconcileInState(apiv1alpha1.DesiredStateStopped, "RUNNING", nil)
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionFeatureSupported)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("StopUnsupported"))
+	})
+})
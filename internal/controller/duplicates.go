@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// connectorNameField indexes DebeziumConnectors by connector name, as derived from the spec.
+// Names that only come from a config Secret are not indexed. The host is not part of the index,
+// since the default host of a namespace cannot be read in an index function; it is resolved for
+// the resources found by name instead.
+const connectorNameField = ".spec.connectorName"
+
+// indexConnectorName is the index function for connectorNameField.
+func indexConnectorName(obj client.Object) []string {
+	dbc := obj.(*apiv1alpha1.DebeziumConnector)
+	config, _ := dbc.DesiredConfig()
+	name := config["name"]
+	if dbc.Status.EffectiveName != "" {
+		name = dbc.Status.EffectiveName
+	}
+	if name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+// sameHost reports whether the Debezium host URLs a and b are the same.
+func sameHost(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// connectorNameOwner returns the DebeziumConnector or DebeziumConnectorSet that owns the connector
+// name of dbc on its host, or nil when dbc owns it. A DebeziumConnectorSet declaring the name
+// always owns it, since sets do not yield to other resources. Otherwise the oldest resource owns
+// the name, with ties broken by namespace and name, so the owner stays stable while duplicates
+// come and go. The hosts of the other resources are resolved like that of dbc, so resources on
+// the default host of their namespace are found before they were first reconciled.
+func (r *DebeziumConnectorReconciler) connectorNameOwner(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) (client.Object, error) {
+	setMembers, err := connectorSetMembers(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if set := setMembers[strings.TrimSuffix(dbc.Spec.DebeziumHost, "/")][name]; set != nil {
+		return set, nil
+	}
+
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list, client.MatchingFields{connectorNameField: name}); err != nil {
+		// Clients without the index, such as a direct API client, list all resources and filter
+		// them in memory.
+		if err := r.List(ctx, list); err != nil {
+			return nil, err
+		}
+	}
+
+	var owner *apiv1alpha1.DebeziumConnector
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.UID == dbc.UID && other.Namespace == dbc.Namespace && other.Name == dbc.Name {
+			continue
+		}
+		names := indexConnectorName(other)
+		if len(names) == 0 || names[0] != name || !ownsBefore(other, dbc) {
+			continue
+		}
+		if owner != nil && !ownsBefore(other, owner) {
+			continue
+		}
+		host, err := r.connectorHost(ctx, other)
+		if err != nil {
+			return nil, err
+		}
+		if sameHost(host, dbc.Spec.DebeziumHost) {
+			owner = other
+		}
+	}
+	if owner == nil {
+		return nil, nil
+	}
+	return owner, nil
+}
+
+// suffixedConnectorName returns name with the suffix NameCollisionPolicy SuffixHash appends for
+// dbc: the first 8 hex digits of the SHA-256 of its namespace and name. The suffix only depends
+// on the resource, so the connector keeps its name across reconciles and operator restarts.
+func suffixedConnectorName(dbc *apiv1alpha1.DebeziumConnector, name string) string {
+	sum := sha256.Sum256([]byte(dbc.Namespace + "/" + dbc.Name))
+	return name + "-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// resolveConnectorName returns the name of the connector of dbc on its host and the resource
+// owning name, if another one does. With NameCollisionPolicy SuffixHash a name owned by another
+// resource is suffixed instead, and a suffix already recorded in the status is kept after the
+// other resource is gone, so the connector is not renamed.
+func (r *DebeziumConnectorReconciler) resolveConnectorName(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) (string, client.Object, error) {
+	suffix := dbc.Spec.NameCollisionPolicy == apiv1alpha1.NameCollisionSuffixHash
+	if suffix && dbc.Status.EffectiveName == suffixedConnectorName(dbc, name) {
+		return dbc.Status.EffectiveName, nil, nil
+	}
+	owner, err := r.connectorNameOwner(ctx, dbc, name)
+	if err != nil || owner == nil || !suffix {
+		return name, owner, err
+	}
+	return suffixedConnectorName(dbc, name), nil, nil
+}
+
+// recordEffectiveName records in Status.EffectiveName the name the connector of dbc uses on its
+// host instead of name, and emits an event when a collision was first resolved.
+func (r *DebeziumConnectorReconciler) recordEffectiveName(dbc *apiv1alpha1.DebeziumConnector, name, effective string) {
+	if effective == name {
+		dbc.Status.EffectiveName = ""
+		return
+	}
+	if dbc.Status.EffectiveName != effective {
+		r.recordEvent(dbc, corev1.EventTypeNormal, "NameSuffixed",
+			"Connector name %s is owned by another resource; using %s", name, effective)
+	}
+	dbc.Status.EffectiveName = effective
+}
+
+// ownsBefore reports whether a takes precedence over b for the same connector name.
+func ownsBefore(a, b *apiv1alpha1.DebeziumConnector) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// recordDuplicateConnector sets the DuplicateConnector condition. owner is nil when dbc owns its
+// connector name.
+func (r *DebeziumConnectorReconciler) recordDuplicateConnector(dbc *apiv1alpha1.DebeziumConnector, name string, owner client.Object) {
+	if owner == nil {
+		if meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector) != nil {
+			meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+				Type:               apiv1alpha1.ConditionDuplicateConnector,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NameOwned",
+				Message:            fmt.Sprintf("Connector name %s is owned by this resource", name),
+				ObservedGeneration: dbc.Generation,
+			})
+		}
+		return
+	}
+	message := fmt.Sprintf("connector name %s already owned by %s/%s", name, owner.GetNamespace(), owner.GetName())
+	if _, ok := owner.(*apiv1alpha1.DebeziumConnectorSet); ok {
+		message = fmt.Sprintf("connector name %s already owned by DebeziumConnectorSet %s/%s", name, owner.GetNamespace(), owner.GetName())
+	}
+	if !isDuplicateConnector(dbc) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "DuplicateConnector", "%s", message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionDuplicateConnector,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NameOwnedByOtherResource",
+		Message:            message,
+		ObservedGeneration: dbc.Generation,
+	})
+}
+
+// isDuplicateConnector reports whether dbc was last found to duplicate another resource's connector.
+func isDuplicateConnector(dbc *apiv1alpha1.DebeziumConnector) bool {
+	return meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)
+}
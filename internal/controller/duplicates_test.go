@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Duplicate connectors", func() {
+	ctx := context.Background()
+	ownerKey := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	duplicateKey := types.NamespacedName{Name: "inventory-copy", Namespace: "team-b"}
+
+	var (
+		connect   *fakeConnect
+		owner     *apiv1alpha1.DebeziumConnector
+		duplicate *apiv1alpha1.DebeziumConnector
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		created := time.Now().Add(-time.Hour)
+		owner = newTestDebeziumConnector(connect.URL)
+		owner.CreationTimestamp = metav1.NewTime(created)
+		duplicate = newTestDebeziumConnector(connect.URL)
+		duplicate.Name, duplicate.Namespace = duplicateKey.Name, duplicateKey.Namespace
+		duplicate.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+		duplicate.Spec.Config["tasks.max"] = "4"
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(objs ...client.Object) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(&apiv1alpha1.DebeziumConnector{}).
+				WithIndex(&apiv1alpha1.DebeziumConnector{}, connectorNameField, indexConnectorName).
+				Build(),
+			HTTPClient: connect.Client(),
+		}
+	}
+
+	reconcileKey := func(r *DebeziumConnectorReconciler, key types.NamespacedName) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("leaves the connector to the oldest resource with the same name", func() {
+		r := newReconciler(owner, duplicate)
+
+		latest := reconcileKey(r, duplicateKey)
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(Equal("connector name inventory already owned by default/inventory"))
+
+		latest = reconcileKey(r, ownerKey)
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeNil())
+
+		// The duplicate does not revert the owner's config.
+		reconcileKey(r, duplicateKey)
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "1"))
+	})
+
+	It("does not treat the same name on another host as a duplicate", func() {
+		other := newFakeConnect()
+		defer other.Close()
+		duplicate.Spec.DebeziumHost = other.URL
+		r := newReconciler(owner, duplicate)
+
+		latest := reconcileKey(r, duplicateKey)
+		Expect(other.mutations()).To(ConsistOf("POST /connectors"))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeNil())
+	})
+
+	It("takes over the connector once the owner is gone", func() {
+		r := newReconciler(owner, duplicate)
+		reconcileKey(r, duplicateKey)
+
+		Expect(r.Delete(ctx, owner)).To(Succeed())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: ownerKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		latest := reconcileKey(r, duplicateKey)
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "4"))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeTrue())
+	})
+
+	It("does not delete the owner's connector when a duplicate is deleted", func() {
+		connect.setConnector(owner.Spec.Config, "RUNNING")
+		now := metav1.Now()
+		duplicate.DeletionTimestamp = &now
+		r := newReconciler(owner, duplicate)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: duplicateKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(connect.configs).To(HaveKey("inventory"))
+	})
+
+	It("rejects a colliding name with the Reject policy", func() {
+		duplicate.Spec.NameCollisionPolicy = apiv1alpha1.NameCollisionReject
+		r := newReconciler(owner, duplicate)
+
+		latest := reconcileKey(r, duplicateKey)
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeTrue())
+		Expect(latest.Status.EffectiveName).To(BeEmpty())
+	})
+
+	It("suffixes a colliding name with the SuffixHash policy", func() {
+		duplicate.Spec.NameCollisionPolicy = apiv1alpha1.NameCollisionSuffixHash
+		r := newReconciler(owner, duplicate)
+		reconcileKey(r, ownerKey)
+
+		latest := reconcileKey(r, duplicateKey)
+		suffixed := suffixedConnectorName(duplicate, "inventory")
+		Expect(latest.Status.EffectiveName).To(Equal(suffixed))
+		Expect(latest.Annotations).To(HaveKeyWithValue(apiv1alpha1.EffectiveNameAnnotation, suffixed))
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeFalse())
+		Expect(connect.configs[suffixed]).To(HaveKeyWithValue("tasks.max", "4"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "1"))
+
+		// The suffix is kept once the owner is gone, so the connector is not renamed.
+		Expect(r.Delete(ctx, owner)).To(Succeed())
+		latest = reconcileKey(r, duplicateKey)
+		Expect(latest.Status.EffectiveName).To(Equal(suffixed))
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors", "POST /connectors"))
+
+		// Deleting the resource deletes the suffixed connector only.
+		Expect(r.Delete(ctx, latest)).To(Succeed())
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: duplicateKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.configs).NotTo(HaveKey(suffixed))
+		Expect(connect.configs).To(HaveKey("inventory"))
+	})
+
+	It("derives a deterministic suffix from the namespace and name", func() {
+		suffixed := suffixedConnectorName(duplicate, "inventory")
+		Expect(suffixed).To(MatchRegexp(`^inventory-[0-9a-f]{8}$`))
+		Expect(suffixedConnectorName(duplicate.DeepCopy(), "inventory")).To(Equal(suffixed))
+		Expect(suffixedConnectorName(owner, "inventory")).NotTo(Equal(suffixed))
+
+		other := duplicate.DeepCopy()
+		other.Namespace = "team-c"
+		Expect(suffixedConnectorName(other, "inventory")).NotTo(Equal(suffixed))
+	})
+
+	It("finds an owner on the default host of its namespace before it was reconciled", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        ownerKey.Namespace,
+			Annotations: map[string]string{apiv1alpha1.DefaultHostAnnotation: connect.URL},
+		}}
+		owner.Spec.DebeziumHost = ""
+		r := newReconciler(ns, owner, duplicate)
+
+		latest := reconcileKey(r, duplicateKey)
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)).To(BeTrue())
+	})
+
+	It("leaves the connector to a DebeziumConnectorSet declaring the same name", func() {
+		set := &apiv1alpha1.DebeziumConnectorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "team-c"},
+			Spec: apiv1alpha1.DebeziumConnectorSetSpec{
+				DebeziumHost: connect.URL,
+				Connectors:   []apiv1alpha1.NamedConnectorSpec{{Name: "inventory"}},
+			},
+		}
+		r := newReconciler(owner, set)
+
+		latest := reconcileKey(r, ownerKey)
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDuplicateConnector)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Message).To(Equal("connector name inventory already owned by DebeziumConnectorSet team-c/pipeline"))
+	})
+
+	It("finds duplicates without the field index", func() {
+		r := &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(owner, duplicate).Build(),
+		}
+		found, err := r.connectorNameOwner(ctx, duplicate, "inventory")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).NotTo(BeNil())
+		Expect(found.GetName()).To(Equal(ownerKey.Name))
+
+		found, err = r.connectorNameOwner(ctx, owner, "inventory")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeNil())
+	})
+})
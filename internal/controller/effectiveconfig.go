@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// effectiveConfigKey is the ConfigMap data key holding the JSON-encoded effective config.
+const effectiveConfigKey = "config.json"
+
+// effectiveConfigName returns the name of the ConfigMap holding the effective config of a DebeziumConnector.
+func effectiveConfigName(dbc *apiv1alpha1.DebeziumConnector) string {
+	return dbc.Name + "-effective-config"
+}
+
+// syncEffectiveConfig writes the resolved config, with sensitive values masked, to the connector's
+// effective config ConfigMap when Spec.ExportEffectiveConfig is set, and removes it otherwise.
+func (r *DebeziumConnectorReconciler) syncEffectiveConfig(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      effectiveConfigName(dbc),
+			Namespace: dbc.Namespace,
+		},
+	}
+	if !dbc.Spec.ExportEffectiveConfig {
+		return r.deleteOwnedConfigMap(ctx, dbc, cm)
+	}
+
+	// Indented JSON with sorted keys keeps the ConfigMap stable and easy to diff.
+	data, err := json.MarshalIndent(util.MaskSensitiveConfig(config), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode effective config: %w", err)
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = util.StandardLabels(dbc.Labels)
+		cm.Data = map[string]string{effectiveConfigKey: string(data)}
+		return controllerutil.SetControllerReference(dbc, cm, r.Scheme())
+	})
+	return err
+}
+
+// deleteOwnedConfigMap removes a ConfigMap previously written for dbc, e.g. its exported effective config.
+func (r *DebeziumConnectorReconciler) deleteOwnedConfigMap(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, cm *corev1.ConfigMap) error {
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(cm, dbc) {
+		return nil
+	}
+	if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
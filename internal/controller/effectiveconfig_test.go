@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Effective config export", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	cmKey := types.NamespacedName{Name: "inventory-effective-config", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.ExportEffectiveConfig = true
+		dbc.Spec.Config["database.password"] = "dbz"
+		dbc.Annotations = map[string]string{apiv1alpha1.ConfigOverrideAnnotationPrefix + "snapshot.mode": "never"}
+		r = &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	It("writes the masked effective config with an owner reference", func() {
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, cmKey, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+
+		var config map[string]string
+		Expect(json.Unmarshal([]byte(cm.Data[effectiveConfigKey]), &config)).To(Succeed())
+		Expect(config).To(HaveKeyWithValue("snapshot.mode", "never"))
+		Expect(config).To(HaveKeyWithValue("database.password", "******"))
+	})
+
+	It("removes the ConfigMap once the export is disabled", func() {
+		dbc := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, dbc)).To(Succeed())
+		dbc.Spec.ExportEffectiveConfig = false
+		Expect(r.Update(ctx, dbc)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, cmKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
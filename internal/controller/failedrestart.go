@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// reconcileFailed restarts a connector that is FAILED, or has FAILED tasks, although its config
+// matches the resource, which leaves nothing for drift detection to repair. Restarts are at
+// least RestartFailedCooldown apart, and are left to the circuit breaker once it is open.
+func (r *DebeziumConnectorReconciler) reconcileFailed(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *connectorStatus, until time.Time) error {
+	if r.RestartFailedCooldown <= 0 || !isFailing(status) || !r.mutationsAllowed(dbc, until) {
+		return nil
+	}
+	// A connector held paused or stopped, or in the middle of a reconfiguration, must not be
+	// restarted behind the operator's back.
+	if meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionCircuitOpen) || reconfigurationInProgress(dbc) {
+		return nil
+	}
+	if dbc.Spec.DesiredState == apiv1alpha1.DesiredStatePaused || dbc.Spec.DesiredState == apiv1alpha1.DesiredStateStopped {
+		return nil
+	}
+	if last := dbc.Status.LastFailedRestartTime; last != nil && time.Since(last.Time) < r.RestartFailedCooldown {
+		return nil
+	}
+
+	url := r.connectURL(dbc.Spec.DebeziumHost, "/connectors/%s/restart?includeTasks=true&onlyFailed=true", name)
+	if err := r.sendJSON(ctx, http.MethodPost, url, nil, nil); err != nil {
+		return err
+	}
+	now := metav1.Now()
+	dbc.Status.LastFailedRestartTime = &now
+	dbc.Status.FailedRestarts++
+	// Count the connector failing again after the restart towards the circuit breaker.
+	dbc.Status.Failing = false
+	r.recordEvent(dbc, corev1.EventTypeNormal, "RestartedFailed", "Restarted failed connector %s; its config matches the resource", name)
+	log.FromContext(ctx).Info("Restarted failed connector", "name", name, "restarts", dbc.Status.FailedRestarts)
+	return nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Failed connectors with a matching config", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	setTaskState := func(state string) {
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		connect.tasks["inventory"] = []taskStatus{{ID: 0, State: state}}
+	}
+
+	reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector, cooldown time.Duration) *apiv1alpha1.DebeziumConnector {
+		r := &DebeziumConnectorReconciler{
+			Client:                fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:            connect.Client(),
+			Recorder:              recorder,
+			RestartFailedCooldown: cooldown,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("restarts a FAILED connector whose config matches", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "FAILED")
+
+		latest := reconcileOnce(dbc, 5*time.Minute)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors/inventory/restart"}))
+		Expect(latest.Status.FailedRestarts).To(Equal(int32(1)))
+		Expect(latest.Status.LastFailedRestartTime).NotTo(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("RestartedFailed")))
+	})
+
+	It("restarts a connector with FAILED tasks", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		setTaskState("FAILED")
+
+		reconcileOnce(dbc, 5*time.Minute)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors/inventory/restart"}))
+	})
+
+	It("does not restart again within the cooldown", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "FAILED")
+		recent := metav1.NewTime(time.Now().Add(-time.Minute))
+		dbc.Status.LastFailedRestartTime = &recent
+
+		latest := reconcileOnce(dbc, 5*time.Minute)
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(latest.Status.FailedRestarts).To(BeZero())
+	})
+
+	It("leaves the connector to an open circuit breaker", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.CircuitBreaker = &apiv1alpha1.CircuitBreakerSpec{MaxFailures: 1, WindowSeconds: 600}
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		setTaskState("FAILED")
+
+		reconcileOnce(dbc, 5*time.Minute)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/pause"}))
+	})
+
+	It("does not restart connectors held paused", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.DesiredState = apiv1alpha1.DesiredStatePaused
+		connect.setConnector(dbc.Spec.Config, "PAUSED")
+		setTaskState("FAILED")
+
+		reconcileOnce(dbc, 5*time.Minute)
+		Expect(connect.mutations()).NotTo(ContainElement("POST /connectors/inventory/restart"))
+	})
+
+	It("does not restart when disabled", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "FAILED")
+
+		reconcileOnce(dbc, 0)
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// fakeConnect is a minimal in-memory Kafka Connect REST API for tests.
+type fakeConnect struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	version  string
+	configs  map[string]map[string]string
+	states   map[string]string
+	tasks    map[string][]taskStatus
+	offsets  map[string][]connectorOffset
+	topics   map[string][]string
+	loggers  map[string]string
+	requests []string
+
+	// loggerScope is the scope of the last logger level change.
+	loggerScope string
+	// validateStatus, when set, is returned by the config validate endpoint instead of a result.
+	validateStatus int
+}
+
+// newFakeConnect starts a fake Connect server with no connectors.
+func newFakeConnect() *fakeConnect {
+	f := &fakeConnect{
+		version: "3.6.0",
+		configs: map[string]map[string]string{},
+		states:  map[string]string{},
+		tasks:   map[string][]taskStatus{},
+		offsets: map[string][]connectorOffset{},
+		topics:  map[string][]string{},
+		loggers: map[string]string{"root": "INFO"},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+// mutations returns the recorded requests that change connectors on the server.
+func (f *fakeConnect) mutations() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var mutations []string
+	for _, req := range f.requests {
+		if !strings.HasPrefix(req, http.MethodGet) && !strings.Contains(req, "/config/validate") {
+			mutations = append(mutations, req)
+		}
+	}
+	return mutations
+}
+
+// setConnector stores a connector config and state on the server.
+func (f *fakeConnect) setConnector(config map[string]string, state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.configs[config["name"]] = config
+	f.states[config["name"]] = state
+}
+
+func (f *fakeConnect) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	writeJSON := func(v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	switch {
+	case r.URL.Path == "/":
+		writeJSON(map[string]string{"version": f.version, "commit": "abc"})
+	case parts[0] == "admin" && len(parts) == 2:
+		loggers := map[string]map[string]string{}
+		for name, level := range f.loggers {
+			loggers[name] = map[string]string{"level": level}
+		}
+		writeJSON(loggers)
+	case parts[0] == "admin" && r.Method == http.MethodPut:
+		var payload struct {
+			Level string `json:"level"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		f.loggers[parts[2]] = payload.Level
+		f.loggerScope = r.URL.Query().Get("scope")
+		writeJSON([]string{parts[2]})
+	case parts[0] == "connector-plugins" && f.validateStatus != 0:
+		w.WriteHeader(f.validateStatus)
+	case parts[0] == "connector-plugins":
+		writeJSON(map[string]interface{}{"errors": map[string]string{}})
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var payload struct {
+			Name         string            `json:"name"`
+			Config       map[string]string `json:"config"`
+			InitialState string            `json:"initial_state"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if _, ok := f.configs[payload.Name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.configs[payload.Name] = payload.Config
+		f.states[payload.Name] = "RUNNING"
+		if payload.InitialState != "" {
+			f.states[payload.Name] = payload.InitialState
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(payload)
+	case len(parts) == 1 && r.URL.Query().Has("expand"):
+		connectors := map[string]interface{}{}
+		for name, config := range f.configs {
+			status := connectorStatus{Tasks: f.tasks[name]}
+			status.Connector.State = f.states[name]
+			connectors[name] = map[string]interface{}{
+				"status": status,
+				"info":   map[string]interface{}{"name": name, "type": "source", "config": config},
+			}
+		}
+		writeJSON(connectors)
+	case len(parts) == 1:
+		names := []string{}
+		for name := range f.configs {
+			names = append(names, name)
+		}
+		writeJSON(names)
+	default:
+		name := parts[1]
+		config, ok := f.configs[name]
+		if !ok && !(len(parts) == 3 && parts[2] == "config" && r.Method == http.MethodPut) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case len(parts) == 2 && r.Method == http.MethodDelete:
+			delete(f.configs, name)
+			delete(f.states, name)
+			w.WriteHeader(http.StatusNoContent)
+		case len(parts) == 2:
+			writeJSON(map[string]interface{}{"name": name, "config": config})
+		case parts[2] == "config" && r.Method == http.MethodPut:
+			var newConfig map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&newConfig)
+			f.configs[name] = newConfig
+			if _, ok := f.states[name]; !ok {
+				f.states[name] = "RUNNING"
+			}
+			writeJSON(newConfig)
+		case parts[2] == "config":
+			writeJSON(config)
+		case parts[2] == "status":
+			status := connectorStatus{Tasks: f.tasks[name]}
+			status.Connector.State = f.states[name]
+			writeJSON(status)
+		case parts[2] == "topics":
+			writeJSON(map[string]interface{}{name: map[string][]string{"topics": append([]string{}, f.topics[name]...)}})
+		case parts[2] == "offsets" && r.Method == http.MethodGet:
+			writeJSON(map[string]interface{}{"offsets": f.offsets[name]})
+		case parts[2] == "offsets" && r.Method == http.MethodPatch:
+			var payload struct {
+				Offsets []connectorOffset `json:"offsets"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			f.offsets[name] = payload.Offsets
+			writeJSON(map[string]string{"message": "offsets altered"})
+		case parts[2] == "pause":
+			f.states[name] = "PAUSED"
+			w.WriteHeader(http.StatusAccepted)
+		case parts[2] == "resume":
+			f.states[name] = "RUNNING"
+			w.WriteHeader(http.StatusAccepted)
+		case parts[2] == "stop":
+			f.states[name] = "STOPPED"
+			w.WriteHeader(http.StatusNoContent)
+		case parts[2] == "restart":
+			f.states[name] = "RUNNING"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// newTestDebeziumConnector returns a DebeziumConnector pointing at host, with the finalizer
+// already present so a single reconcile exercises the connector logic.
+func newTestDebeziumConnector(host string) *apiv1alpha1.DebeziumConnector {
+	return &apiv1alpha1.DebeziumConnector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "inventory",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{debeziumFinalizer},
+		},
+		Spec: apiv1alpha1.DebeziumConnectorSpec{
+			DebeziumHost: host,
+			Config: map[string]string{
+				"name":            "inventory",
+				"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+				"tasks.max":       "1",
+			},
+		},
+	}
+}
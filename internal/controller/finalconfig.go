@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+const (
+	// finalConfigKey is the data key holding the JSON-encoded final config.
+	finalConfigKey = "config.json"
+	// finalConfigRemovedAtKey is the data key holding when the connector was removed.
+	finalConfigRemovedAtKey = "removedAt"
+)
+
+// finalConfigName returns the name of the ConfigMap or Secret holding the final config of a
+// DebeziumConnector.
+func finalConfigName(dbc *apiv1alpha1.DebeziumConnector) string {
+	return dbc.Name + "-final-config"
+}
+
+// retainFinalConfig saves the config of connector name on the Debezium host as requested by
+// Spec.RetainConfigOnRemoval. It is called before the connector is deleted; an error keeps the
+// finalizer, so the connector is not deleted before its config was saved. When the ConfigMap or
+// Secret cannot be created, e.g. in a terminating namespace, the config is kept in an event.
+func (r *DebeziumConnectorReconciler) retainFinalConfig(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) error {
+	if dbc.Spec.RetainConfigOnRemoval == "" {
+		return nil
+	}
+	exists, err := r.connectorExists(ctx, dbc.Spec.DebeziumHost, name)
+	if err != nil || !exists {
+		return err
+	}
+	config, err := r.getDebeziumConnectorConfig(ctx, dbc.Spec.DebeziumHost, name)
+	if err != nil {
+		return err
+	}
+	masked, err := json.Marshal(util.MaskSensitiveConfig(config))
+	if err != nil {
+		return fmt.Errorf("failed to encode final config: %w", err)
+	}
+	removedAt := time.Now().UTC().Format(time.RFC3339)
+
+	var obj client.Object
+	switch dbc.Spec.RetainConfigOnRemoval {
+	case apiv1alpha1.RetainConfigEvent:
+		r.recordEvent(dbc, corev1.EventTypeNormal, "FinalConfig", "Final config of connector %s: %s", name, masked)
+		return nil
+	case apiv1alpha1.RetainConfigConfigMap:
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: finalConfigName(dbc), Namespace: dbc.Namespace}}
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+			cm.Labels = util.StandardLabels(dbc.Labels)
+			cm.Data = map[string]string{finalConfigKey: string(masked), finalConfigRemovedAtKey: removedAt}
+			return nil
+		})
+		obj = cm
+	case apiv1alpha1.RetainConfigSecret:
+		// The Secret keeps the values a ConfigMap masks, so the connector can be recreated from it.
+		data, encodeErr := json.Marshal(config)
+		if encodeErr != nil {
+			return fmt.Errorf("failed to encode final config: %w", encodeErr)
+		}
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: finalConfigName(dbc), Namespace: dbc.Namespace}}
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			secret.Labels = util.StandardLabels(dbc.Labels)
+			secret.Data = map[string][]byte{finalConfigKey: data, finalConfigRemovedAtKey: []byte(removedAt)}
+			return nil
+		})
+		obj = secret
+	default:
+		return fmt.Errorf("unknown retainConfigOnRemoval %q", dbc.Spec.RetainConfigOnRemoval)
+	}
+	if apierrors.IsForbidden(err) || apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+		// Objects cannot be created in a terminating namespace; waiting would block its deletion.
+		log.FromContext(ctx).Info("Cannot save final connector config; recording it in an event instead", "name", name, "reason", err.Error())
+		r.recordEvent(dbc, corev1.EventTypeNormal, "FinalConfig", "Final config of connector %s: %s", name, masked)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save final config: %w", err)
+	}
+	log.FromContext(ctx).Info("Saved final connector config", "name", name, "kind", dbc.Spec.RetainConfigOnRemoval, "object", obj.GetName())
+	r.recordEvent(dbc, corev1.EventTypeNormal, "ConfigRetained", "Saved the final config of connector %s in %s %s",
+		name, dbc.Spec.RetainConfigOnRemoval, obj.GetName())
+	return nil
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Final config retention", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	retainedKey := types.NamespacedName{Name: "inventory-final-config", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		server   *httptest.Server
+		recorder *record.FakeRecorder
+		r        *DebeziumConnectorReconciler
+		// retained is the object expected to hold the final config.
+		retained client.Object
+		// retainedBeforeDelete records whether retained existed when the DELETE arrived.
+		retainedBeforeDelete bool
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		retained, retainedBeforeDelete = nil, false
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodDelete && retained != nil {
+				retainedBeforeDelete = r.Get(ctx, retainedKey, retained.DeepCopyObject().(client.Object)) == nil
+			}
+			connect.serve(w, req)
+		}))
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		connect.Close()
+	})
+
+	reconcileDeleted := func(retain string, funcs ...interceptor.Funcs) {
+		dbc := newTestDebeziumConnector(server.URL)
+		now := metav1.Now()
+		dbc.DeletionTimestamp = &now
+		dbc.Spec.RetainConfigOnRemoval = retain
+		config := map[string]string{}
+		for k, v := range dbc.Spec.Config {
+			config[k] = v
+		}
+		config["database.password"] = "s3cret"
+		connect.setConnector(config, "RUNNING")
+		builder := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc)
+		for _, f := range funcs {
+			builder = builder.WithInterceptorFuncs(f)
+		}
+		r = &DebeziumConnectorReconciler{
+			Client:     builder.Build(),
+			HTTPClient: server.Client(),
+			Recorder:   recorder,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"DELETE /connectors/inventory"}))
+	}
+
+	It("saves the masked config in a ConfigMap before deleting the connector", func() {
+		retained = &corev1.ConfigMap{}
+		reconcileDeleted(apiv1alpha1.RetainConfigConfigMap)
+		Expect(retainedBeforeDelete).To(BeTrue())
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, retainedKey, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(BeEmpty())
+		Expect(cm.Data).To(HaveKey(finalConfigRemovedAtKey))
+		var config map[string]string
+		Expect(json.Unmarshal([]byte(cm.Data[finalConfigKey]), &config)).To(Succeed())
+		Expect(config).To(HaveKeyWithValue("connector.class", "io.debezium.connector.mysql.MySqlConnector"))
+		Expect(config["database.password"]).NotTo(Equal("s3cret"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConfigRetained")))
+	})
+
+	It("saves the unmasked config in a Secret before deleting the connector", func() {
+		retained = &corev1.Secret{}
+		reconcileDeleted(apiv1alpha1.RetainConfigSecret)
+		Expect(retainedBeforeDelete).To(BeTrue())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, retainedKey, secret)).To(Succeed())
+		var config map[string]string
+		Expect(json.Unmarshal(secret.Data[finalConfigKey], &config)).To(Succeed())
+		Expect(config).To(HaveKeyWithValue("database.password", "s3cret"))
+	})
+
+	It("emits the masked config in an event", func() {
+		reconcileDeleted(apiv1alpha1.RetainConfigEvent)
+		var event string
+		Expect(recorder.Events).To(Receive(&event))
+		Expect(event).To(ContainSubstring("FinalConfig"))
+		Expect(event).To(ContainSubstring("io.debezium.connector.mysql.MySqlConnector"))
+		Expect(event).NotTo(ContainSubstring("s3cret"))
+	})
+
+	It("emits the masked config in an event when the namespace is terminating", func() {
+		reconcileDeleted(apiv1alpha1.RetainConfigSecret, interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Secret); ok {
+					return apierrors.NewForbidden(corev1.Resource("secrets"), obj.GetName(),
+						errors.New("namespace default is being terminated"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		Expect(r.Get(ctx, retainedKey, &corev1.Secret{})).NotTo(Succeed())
+		var event string
+		Expect(recorder.Events).To(Receive(&event))
+		Expect(event).To(ContainSubstring("FinalConfig"))
+		Expect(event).NotTo(ContainSubstring("s3cret"))
+	})
+
+	It("saves nothing unless requested", func() {
+		reconcileDeleted("")
+		err := r.Get(ctx, retainedKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).NotTo(Receive())
+	})
+})
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// reconcileForceReplace replaces the connector config on the Debezium host with config when the
+// ForceReplaceAnnotation carries a value not processed yet. Connect replaces the whole config on
+// PUT, so stray keys and values the drift check considers equal, e.g. lists in another order,
+// are overwritten too. The processed value is persisted right away so a later failure in the
+// same reconcile does not replace the config again.
+func (r *DebeziumConnectorReconciler) reconcileForceReplace(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	nonce := dbc.Annotations[apiv1alpha1.ForceReplaceAnnotation]
+	if nonce == "" || nonce == dbc.Status.ForceReplaceNonce {
+		return nil
+	}
+
+	name := config["name"]
+	exists, err := r.connectorExists(ctx, dbc.Spec.DebeziumHost, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = r.updateDebeziumConnector(ctx, dbc.Spec.DebeziumHost, config)
+	} else {
+		err = r.createDebeziumConnector(ctx, dbc.Spec.DebeziumHost, config)
+	}
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	dbc.Status.ForceReplaceNonce = nonce
+	dbc.Status.LastForceReplaceTime = &now
+	r.recordEvent(dbc, corev1.EventTypeNormal, "ForceReplaced", "Replaced the config of connector %s as requested by the %s annotation", name, apiv1alpha1.ForceReplaceAnnotation)
+	log.FromContext(ctx).Info("Replaced connector config", "name", name, "nonce", nonce)
+	if err := r.recordConfigHistory(ctx, dbc, config); err != nil {
+		log.FromContext(ctx).Error(err, "failed to record config history")
+	}
+	return r.updateStatus(ctx, dbc)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Force-replacing the connector config", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("replaces a matching config once per annotation value", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{apiv1alpha1.ForceReplaceAnnotation: "1"}
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		r := newReconciler(dbc)
+
+		latest := reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+		Expect(latest.Status.ForceReplaceNonce).To(Equal("1"))
+		Expect(latest.Status.LastForceReplaceTime).NotTo(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("ForceReplaced")))
+
+		latest = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(1))
+
+		latest.Annotations[apiv1alpha1.ForceReplaceAnnotation] = "2"
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		latest = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(2))
+		Expect(latest.Status.ForceReplaceNonce).To(Equal("2"))
+	})
+
+	It("creates a missing connector", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{apiv1alpha1.ForceReplaceAnnotation: "1"}
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(connect.configs).To(HaveKey("inventory"))
+		Expect(latest.Status.ForceReplaceNonce).To(Equal("1"))
+	})
+
+	It("waits while reconciliation is paused", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{
+			apiv1alpha1.ForceReplaceAnnotation:    "1",
+			apiv1alpha1.ReconcilePausedAnnotation: "true",
+		}
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(latest.Status.ForceReplaceNonce).To(BeEmpty())
+	})
+})
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// defaultHostBreakerCooldown is how long requests to a failing host are suspended when
+// HostBreakerCooldown is zero.
+const defaultHostBreakerCooldown = 30 * time.Second
+
+// hostUnavailableError is returned instead of sending a request to a host whose breaker is open.
+type hostUnavailableError struct {
+	host  string
+	until time.Time
+}
+
+func (e *hostUnavailableError) Error() string {
+	return fmt.Sprintf("requests to %s are suspended after repeated failures until %s", e.host, e.until.Format(time.RFC3339))
+}
+
+// hostBreaker is the circuit breaker of a single Debezium host. It is closed while failures is
+// below the threshold, open until openUntil, and half-open once openUntil passed: a single probe
+// request is then let through, which closes the breaker when it succeeds and opens it again
+// when it fails.
+type hostBreaker struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// hostBreakers holds the breakers of all Debezium hosts, shared by all reconciles.
+type hostBreakers struct {
+	mu       sync.Mutex
+	breakers map[string]*hostBreaker
+}
+
+// hostBreakerKey identifies the host of a request or of a Spec.DebeziumHost.
+func hostBreakerKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// suspended reports whether requests to host are currently failed fast, and until when: while
+// the breaker is open, or while its half-open probe is in flight.
+func (b *hostBreakers) suspended(host string, now time.Time) (time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker := b.breakers[host]
+	if breaker == nil || breaker.openUntil.IsZero() {
+		return time.Time{}, false
+	}
+	return breaker.openUntil, breaker.probing || now.Before(breaker.openUntil)
+}
+
+// acquire returns a *hostUnavailableError when a request to host must not be sent at now. The
+// first request after the breaker's cooldown becomes the half-open probe.
+func (b *hostBreakers) acquire(host string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker := b.breakers[host]
+	if breaker == nil || breaker.openUntil.IsZero() {
+		return nil
+	}
+	if breaker.probing || now.Before(breaker.openUntil) {
+		return &hostUnavailableError{host: host, until: breaker.openUntil}
+	}
+	breaker.probing = true
+	return nil
+}
+
+// release records the outcome of a request to host. The breaker opens for cooldown after
+// threshold consecutive failures, or after a failed probe. It returns whether the breaker
+// opened or closed.
+func (b *hostBreakers) release(host string, failed bool, threshold int, cooldown time.Duration, now time.Time) (opened, closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker := b.breakers[host]
+	if !failed {
+		if breaker != nil {
+			closed = !breaker.openUntil.IsZero()
+			delete(b.breakers, host)
+		}
+		return false, closed
+	}
+	if breaker == nil {
+		if b.breakers == nil {
+			b.breakers = map[string]*hostBreaker{}
+		}
+		breaker = &hostBreaker{}
+		b.breakers[host] = breaker
+	}
+	breaker.failures++
+	switch {
+	case breaker.probing:
+		breaker.probing = false
+		breaker.openUntil = now.Add(cooldown)
+	case breaker.openUntil.IsZero() && breaker.failures >= threshold:
+		breaker.openUntil = now.Add(cooldown)
+		opened = true
+	}
+	return opened, false
+}
+
+// hostRequestFailed reports whether a request failed in a way that indicates the host, rather
+// than the request, is broken.
+func hostRequestFailed(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// hostBreakerCooldown returns how long requests to a failing host are suspended.
+func (r *DebeziumConnectorReconciler) hostBreakerCooldown() time.Duration {
+	if r.HostBreakerCooldown > 0 {
+		return r.HostBreakerCooldown
+	}
+	return defaultHostBreakerCooldown
+}
+
+// doWithHostBreaker sends req unless the breaker of its host is open, and records the outcome.
+func (r *DebeziumConnectorReconciler) doWithHostBreaker(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if r.HostFailureThreshold <= 0 {
+		return send(req)
+	}
+	host := hostBreakerKey(req.URL.String())
+	if err := r.hostBreakers.acquire(host, time.Now()); err != nil {
+		return nil, err
+	}
+	resp, err := send(req)
+	opened, closed := r.hostBreakers.release(host, hostRequestFailed(resp, err), r.HostFailureThreshold, r.hostBreakerCooldown(), time.Now())
+	logger := log.FromContext(req.Context())
+	if opened {
+		logger.Info("Suspending requests to failing Debezium host", "host", host, "failures", r.HostFailureThreshold, "cooldown", r.hostBreakerCooldown())
+	}
+	if closed {
+		logger.Info("Debezium host recovered; resuming requests", "host", host)
+	}
+	return resp, err
+}
+
+// suspendForHost reports in the HostCircuitOpen condition that requests to the connector's host
+// are suspended, and requeues the connector once the host is probed again. The requeue is
+// jittered, so the connectors of a recovered host do not all reconcile at once.
+func (r *DebeziumConnectorReconciler) suspendForHost(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, until time.Time) (ctrl.Result, error) {
+	err := &hostUnavailableError{host: hostBreakerKey(dbc.Spec.DebeziumHost), until: until}
+	traceDecision(ctx, "%s", err.Error())
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionHostCircuitOpen,
+		Status:             metav1.ConditionTrue,
+		Reason:             "HostUnavailable",
+		Message:            err.Error(),
+		ObservedGeneration: dbc.Generation,
+	})
+	recordAvailability(dbc, "", err)
+	if updateErr := r.updateStatus(ctx, dbc); updateErr != nil {
+		log.FromContext(ctx).Error(updateErr, "failed to update DebeziumConnector status")
+		return ctrl.Result{}, updateErr
+	}
+	requeue := time.Until(until)
+	if requeue <= 0 {
+		// The probe is in flight; check again shortly.
+		requeue = r.hostBreakerCooldown() / 10
+	}
+	return ctrl.Result{RequeueAfter: wait.Jitter(requeue, 0.5)}, nil
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Host circuit breaker", func() {
+	const host = "http://connect:8083"
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	It("opens after consecutive failures and closes after a successful probe", func() {
+		b := &hostBreakers{}
+		Expect(b.acquire(host, now)).To(Succeed())
+		opened, _ := b.release(host, true, 2, time.Minute, now)
+		Expect(opened).To(BeFalse())
+		Expect(b.acquire(host, now)).To(Succeed())
+		opened, _ = b.release(host, true, 2, time.Minute, now)
+		Expect(opened).To(BeTrue())
+
+		// Open: requests fail fast until the cooldown passed.
+		err := b.acquire(host, now.Add(30*time.Second))
+		Expect(err).To(BeAssignableToTypeOf(&hostUnavailableError{}))
+		until, suspended := b.suspended(host, now.Add(30*time.Second))
+		Expect(suspended).To(BeTrue())
+		Expect(until).To(Equal(now.Add(time.Minute)))
+
+		// Half-open: a single probe is let through.
+		_, suspended = b.suspended(host, now.Add(time.Minute))
+		Expect(suspended).To(BeFalse())
+		Expect(b.acquire(host, now.Add(time.Minute))).To(Succeed())
+		Expect(b.acquire(host, now.Add(time.Minute))).NotTo(Succeed())
+		_, suspended = b.suspended(host, now.Add(time.Minute))
+		Expect(suspended).To(BeTrue())
+
+		// Closed again once the probe succeeded.
+		_, closed := b.release(host, false, 2, time.Minute, now.Add(time.Minute))
+		Expect(closed).To(BeTrue())
+		Expect(b.acquire(host, now.Add(time.Minute))).To(Succeed())
+		_, suspended = b.suspended(host, now.Add(time.Minute))
+		Expect(suspended).To(BeFalse())
+	})
+
+	It("opens again when the probe fails", func() {
+		b := &hostBreakers{}
+		b.release(host, true, 1, time.Minute, now)
+		probe := now.Add(time.Minute)
+		Expect(b.acquire(host, probe)).To(Succeed())
+		b.release(host, true, 1, time.Minute, probe)
+		Expect(b.acquire(host, probe.Add(30*time.Second))).NotTo(Succeed())
+		Expect(b.acquire(host, probe.Add(time.Minute))).To(Succeed())
+	})
+
+	It("resets the failure count after a success", func() {
+		b := &hostBreakers{}
+		b.release(host, true, 2, time.Minute, now)
+		b.release(host, false, 2, time.Minute, now)
+		opened, _ := b.release(host, true, 2, time.Minute, now)
+		Expect(opened).To(BeFalse())
+		Expect(b.acquire(host, now)).To(Succeed())
+	})
+
+	It("keys hosts by scheme and address", func() {
+		Expect(hostBreakerKey("http://connect:8083/kafka-connect/connectors/inventory")).To(Equal("http://connect:8083"))
+		Expect(hostBreakerKey("http://connect:8083")).To(Equal("http://connect:8083"))
+		Expect(hostBreakerKey("https://connect:8083")).NotTo(Equal(hostBreakerKey("http://connect:8083")))
+	})
+
+	It("counts connection errors and gateway errors only", func() {
+		Expect(hostRequestFailed(nil, context.DeadlineExceeded)).To(BeTrue())
+		Expect(hostRequestFailed(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)).To(BeTrue())
+		Expect(hostRequestFailed(&http.Response{StatusCode: http.StatusInternalServerError}, nil)).To(BeFalse())
+		Expect(hostRequestFailed(&http.Response{StatusCode: http.StatusNotFound}, nil)).To(BeFalse())
+	})
+
+	Context("when reconciling", func() {
+		ctx := context.Background()
+		key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+		var (
+			connect *fakeConnect
+			gateway *httptest.Server
+			down    atomic.Bool
+			r       *DebeziumConnectorReconciler
+		)
+
+		BeforeEach(func() {
+			connect = newFakeConnect()
+			down.Store(false)
+			gateway = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if down.Load() {
+					http.Error(w, "no healthy upstream", http.StatusServiceUnavailable)
+					return
+				}
+				connect.serve(w, req)
+			}))
+			dbc := newTestDebeziumConnector(gateway.URL)
+			r = &DebeziumConnectorReconciler{
+				Client:               fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+				HTTPClient:           gateway.Client(),
+				HostFailureThreshold: 1,
+				HostBreakerCooldown:  time.Minute,
+			}
+		})
+
+		AfterEach(func() {
+			gateway.Close()
+			connect.Close()
+		})
+
+		latest := func() *apiv1alpha1.DebeziumConnector {
+			dbc := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, dbc)).To(Succeed())
+			return dbc
+		}
+
+		// expire ends the cooldown of the gateway's breaker, so the next request probes it.
+		expire := func() {
+			r.hostBreakers.mu.Lock()
+			defer r.hostBreakers.mu.Unlock()
+			r.hostBreakers.breakers[hostBreakerKey(gateway.URL)].openUntil = time.Now().Add(-time.Second)
+		}
+
+		It("suspends the connectors of a failing host until a probe succeeds", func() {
+			down.Store(true)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+
+			// Open: the connector is not reconciled against the host.
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 30*time.Second))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 90*time.Second))
+			cond := meta.FindStatusCondition(latest().Status.Conditions, apiv1alpha1.ConditionHostCircuitOpen)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("HostUnavailable"))
+			Expect(connect.mutations()).To(BeEmpty())
+
+			// Half-open: a failed probe opens the breaker again.
+			expire()
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+			_, suspended := r.hostBreakers.suspended(hostBreakerKey(gateway.URL), time.Now())
+			Expect(suspended).To(BeTrue())
+
+			// Closed: the host recovered and the connector is created.
+			down.Store(false)
+			expire()
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+			dbc := latest()
+			Expect(meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionHostCircuitOpen)).To(BeNil())
+			Expect(dbc.Status.ConnectorStatus).To(Equal("RUNNING"))
+		})
+
+		It("never suspends a host when disabled", func() {
+			r.HostFailureThreshold = 0
+			down.Store(true)
+			for i := 0; i < 3; i++ {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).To(HaveOccurred())
+			}
+			Expect(meta.FindStatusCondition(latest().Status.Conditions, apiv1alpha1.ConditionHostCircuitOpen)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// hostConfigHash returns the hash of a config on the Debezium host recorded in
+// Status.HostConfigHash. List and typed values are normalized, so reordering a list or writing
+// "TRUE" for "true" is not a change.
+func (r *DebeziumConnectorReconciler) hostConfigHash(config map[string]string) string {
+	return util.ConfigHash(util.NormalizeConfig(r.normalizeValues(config), r.ListValuedKeys))
+}
+
+// changedOnHost reports whether external, the config on the Debezium host, was changed since the
+// operator last applied or saw it. Nothing is known about a connector without a recorded hash.
+func (r *DebeziumConnectorReconciler) changedOnHost(dbc *apiv1alpha1.DebeziumConnector, external map[string]string) bool {
+	return dbc.Status.HostConfigHash != "" && dbc.Status.HostConfigHash != r.hostConfigHash(external)
+}
+
+// recordHostConflict reports in the ConflictDetected condition that external, the config on the
+// Debezium host, was changed outside the operator and differs from config. It returns whether
+// the ConflictPolicy allows overwriting it.
+func (r *DebeziumConnectorReconciler) recordHostConflict(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, external, config map[string]string) bool {
+	var keys []string
+	for _, change := range diffConfig(r.normalizeValues(external), config, r.ListValuedKeys) {
+		keys = append(keys, change.Key)
+	}
+	overwrite := dbc.Spec.ConflictPolicy != apiv1alpha1.ConflictPolicyRefuse
+	cond := metav1.Condition{
+		Type:               apiv1alpha1.ConditionConflictDetected,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Overwritten",
+		ObservedGeneration: dbc.Generation,
+	}
+	cond.Message = fmt.Sprintf("The config of connector %s was changed on the Debezium host outside the operator (%s)", config["name"], strings.Join(keys, ", "))
+	if overwrite {
+		cond.Message += " and was overwritten"
+	} else {
+		cond.Reason = "Refused"
+		cond.Message += fmt.Sprintf(" and is not overwritten while spec.conflictPolicy is %s", apiv1alpha1.ConflictPolicyRefuse)
+	}
+	// A refused conflict is found again on every reconcile; report it once.
+	if meta.SetStatusCondition(&dbc.Status.Conditions, cond) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "ConflictDetected", "%s", cond.Message)
+	}
+	traceDecision(ctx, "config of connector %s was changed on the host; overwrite: %t", config["name"], overwrite)
+	return overwrite
+}
+
+// conflictRefused reports whether the last config check left a config changed outside the
+// operator in place.
+func conflictRefused(dbc *apiv1alpha1.DebeziumConnector) bool {
+	cond := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionConflictDetected)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == "Refused"
+}
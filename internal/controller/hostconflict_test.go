@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Config changed on the Debezium host", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	// editOnHost changes the connector config on the Debezium host outside the operator.
+	editOnHost := func(k, v string) {
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		config := map[string]string{}
+		for key, value := range connect.configs["inventory"] {
+			config[key] = value
+		}
+		config[k] = v
+		connect.configs["inventory"] = config
+	}
+
+	It("records the hash of the config on the host", func() {
+		r := newReconciler(newTestDebeziumConnector(connect.URL))
+		latest := reconcileOnce(r)
+		Expect(latest.Status.HostConfigHash).To(Equal(r.hostConfigHash(latest.Spec.Config)))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)).To(BeNil())
+	})
+
+	It("overwrites a conflicting change by default and reports it", func() {
+		r := newReconciler(newTestDebeziumConnector(connect.URL))
+		reconcileOnce(r)
+		editOnHost("tasks.max", "8")
+
+		latest := reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors", "PUT /connectors/inventory/config"}))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "1"))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("Overwritten"))
+		Expect(cond.Message).To(ContainSubstring("tasks.max"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConflictDetected")))
+
+		latest = reconcileOnce(r)
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)).To(BeNil())
+	})
+
+	It("leaves a conflicting change in place with the Refuse policy", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.ConflictPolicy = apiv1alpha1.ConflictPolicyRefuse
+		r := newReconciler(dbc)
+		reconcileOnce(r)
+		editOnHost("tasks.max", "8")
+
+		latest := reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "8"))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("Refused"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConflictDetected")))
+		available := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionAvailable)
+		Expect(available.Reason).To(Equal("ConfigNotApplied"))
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+
+		// The conflict persists, but is reported once.
+		latest = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(1))
+		Expect(recorder.Events).NotTo(Receive(ContainSubstring("ConflictDetected")))
+
+		// Adopting the change in the spec resolves the conflict.
+		latest.Spec.Config["tasks.max"] = "8"
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		latest = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(1))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)).To(BeNil())
+		Expect(latest.Status.ConfigHash).NotTo(BeEmpty())
+	})
+
+	It("applies spec changes when the host config is unchanged", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.ConflictPolicy = apiv1alpha1.ConflictPolicyRefuse
+		r := newReconciler(dbc)
+		latest := reconcileOnce(r)
+
+		latest.Spec.Config["tasks.max"] = "2"
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		latest = reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors", "PUT /connectors/inventory/config"}))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionConflictDetected)).To(BeNil())
+	})
+})
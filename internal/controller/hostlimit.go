@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// hostLimiter bounds the mutation requests in flight to each Debezium host, shared by all
+// reconciles.
+type hostLimiter struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// acquire waits for one of the limit slots of host, or until ctx is done. The returned function
+// releases the slot.
+func (l *hostLimiter) acquire(ctx context.Context, host string, limit int) (func(), error) {
+	l.mu.Lock()
+	if l.slots == nil {
+		l.slots = map[string]chan struct{}{}
+	}
+	slots, ok := l.slots[host]
+	if !ok || cap(slots) != limit {
+		// The limit changed; requests holding a slot of the previous semaphore release it there.
+		slots = make(chan struct{}, limit)
+		l.slots[host] = slots
+	}
+	l.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	default:
+	}
+	log.FromContext(ctx).V(1).Info("Waiting for a free operation slot of the Debezium host", "host", host, "limit", limit)
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// isMutation reports whether req changes connectors on the Debezium host. Config validation is
+// sent with POST but changes nothing.
+func isMutation(req *http.Request) bool {
+	return req.Method != http.MethodGet && req.Method != http.MethodHead &&
+		!strings.HasSuffix(req.URL.Path, "/config/validate")
+}
+
+// doWithHostLimit sends req, waiting first for a free slot of its host when req is a mutation
+// and MaxConcurrentHostOperations is set.
+func (r *DebeziumConnectorReconciler) doWithHostLimit(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if r.MaxConcurrentHostOperations <= 0 || !isMutation(req) {
+		return send(req)
+	}
+	release, err := r.hostLimits.acquire(req.Context(), hostBreakerKey(req.URL.String()), r.MaxConcurrentHostOperations)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return send(req)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// limitedHost is a fake Connect host that records how many connector creations are in flight.
+type limitedHost struct {
+	*httptest.Server
+	connect  *fakeConnect
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+	// release unblocks the creations; nil lets them complete after a short delay.
+	release chan struct{}
+}
+
+func newLimitedHost(release chan struct{}) *limitedHost {
+	h := &limitedHost{connect: newFakeConnect(), release: release}
+	h.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost && req.URL.Path == "/connectors" {
+			n := h.inFlight.Add(1)
+			for {
+				seen := h.maxSeen.Load()
+				if n <= seen || h.maxSeen.CompareAndSwap(seen, n) {
+					break
+				}
+			}
+			if h.release != nil {
+				<-h.release
+			} else {
+				time.Sleep(50 * time.Millisecond)
+			}
+			h.inFlight.Add(-1)
+		}
+		h.connect.serve(w, req)
+	}))
+	return h
+}
+
+func (h *limitedHost) Close() {
+	h.Server.Close()
+	h.connect.Close()
+}
+
+var _ = Describe("Per-host operation limit", func() {
+	ctx := context.Background()
+
+	// newConnectors returns count DebeziumConnectors on host, named prefix-0, prefix-1 and so on.
+	newConnectors := func(host, prefix string, count int) []client.Object {
+		var objs []client.Object
+		for i := 0; i < count; i++ {
+			dbc := newTestDebeziumConnector(host)
+			dbc.Name = fmt.Sprintf("%s-%d", prefix, i)
+			dbc.Spec.Config["name"] = dbc.Name
+			objs = append(objs, dbc)
+		}
+		return objs
+	}
+
+	// reconcileAll reconciles the connectors concurrently and returns when all are done.
+	reconcileAll := func(r *DebeziumConnectorReconciler, objs []client.Object) {
+		var wg sync.WaitGroup
+		for _, obj := range objs {
+			wg.Add(1)
+			go func(key types.NamespacedName) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+			}(client.ObjectKeyFromObject(obj))
+		}
+		wg.Wait()
+	}
+
+	newReconciler := func(limit int, objs ...client.Object) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:                      fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).WithStatusSubresource(&apiv1alpha1.DebeziumConnector{}).Build(),
+			HTTPClient:                  http.DefaultClient,
+			MaxConcurrentHostOperations: limit,
+		}
+	}
+
+	It("bounds the operations in flight to a host", func() {
+		host := newLimitedHost(nil)
+		defer host.Close()
+		objs := newConnectors(host.URL, "inventory", 6)
+		reconcileAll(newReconciler(2, objs...), objs)
+
+		Expect(host.connect.mutations()).To(HaveLen(6))
+		Expect(host.maxSeen.Load()).To(Equal(int32(2)))
+	})
+
+	It("does not bound operations unless configured", func() {
+		host := newLimitedHost(nil)
+		defer host.Close()
+		objs := newConnectors(host.URL, "inventory", 4)
+		reconcileAll(newReconciler(0, objs...), objs)
+
+		Expect(host.maxSeen.Load()).To(BeNumerically(">", 1))
+	})
+
+	It("does not let a busy host hold up the others", func() {
+		release := make(chan struct{})
+		busy := newLimitedHost(release)
+		defer busy.Close()
+		idle := newLimitedHost(nil)
+		defer idle.Close()
+		busyObjs := newConnectors(busy.URL, "busy", 3)
+		idleObjs := newConnectors(idle.URL, "idle", 3)
+		r := newReconciler(1, append(busyObjs, idleObjs...)...)
+
+		busyDone := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			reconcileAll(r, busyObjs)
+			close(busyDone)
+		}()
+		Eventually(busy.inFlight.Load).Should(Equal(int32(1)))
+
+		// The idle host is served while the busy one holds its only slot.
+		reconcileAll(r, idleObjs)
+		Expect(idle.connect.mutations()).To(HaveLen(3))
+		Expect(busy.inFlight.Load()).To(Equal(int32(1)))
+
+		close(release)
+		Eventually(busyDone).Should(BeClosed())
+		Expect(busy.maxSeen.Load()).To(Equal(int32(1)))
+		Expect(busy.connect.mutations()).To(HaveLen(3))
+	})
+
+	It("gives up waiting for a slot when the reconcile is cancelled", func() {
+		l := &hostLimiter{}
+		release, err := l.acquire(ctx, "http://connect:8083", 1)
+		Expect(err).NotTo(HaveOccurred())
+		defer release()
+
+		cancelled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err = l.acquire(cancelled, "http://connect:8083", 1)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		_, err = l.acquire(ctx, "http://other:8083", 1)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
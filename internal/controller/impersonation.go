@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// impersonatedClients holds a client per impersonated service account. Creating a client sets up
+// its own HTTP transport and REST mapper, so clients are reused across reconciles rather than
+// created for every read.
+type impersonatedClients struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]client.Client
+}
+
+// get returns the client impersonating serviceAccount in namespace, creating it on first use.
+func (c *impersonatedClients) get(cfg *rest.Config, scheme *runtime.Scheme, namespace, serviceAccount string) (client.Client, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: serviceAccount}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok := c.clients[key]; ok {
+		return cl, nil
+	}
+	cl, err := client.New(util.ImpersonatedConfig(cfg, namespace, serviceAccount), client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = map[types.NamespacedName]client.Client{}
+	}
+	c.clients[key] = cl
+	return cl, nil
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("Impersonation", func() {
+	var dbc *apiv1alpha1.DebeziumConnector
+
+	BeforeEach(func() {
+		dbc = &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "tenant-a"},
+		}
+	})
+
+	It("impersonates the service account in the resource's namespace", func() {
+		cfg := util.ImpersonatedConfig(&rest.Config{Host: "https://kube"}, "tenant-a", "connectors")
+		Expect(cfg.Host).To(Equal("https://kube"))
+		Expect(cfg.Impersonate.UserName).To(Equal("system:serviceaccount:tenant-a:connectors"))
+	})
+
+	It("uses the reconciler client when no service account is annotated", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		r := &DebeziumConnectorReconciler{Client: c, RestConfig: &rest.Config{Host: "https://kube"}}
+		reader, err := r.configReader(dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader).To(BeIdenticalTo(c))
+	})
+
+	It("reuses the client of an impersonated service account", func() {
+		dbc.Annotations = map[string]string{apiv1alpha1.ServiceAccountAnnotation: "connectors"}
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			RestConfig: &rest.Config{Host: "https://kube"},
+		}
+		first, err := r.configReader(dbc)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := r.configReader(dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		other := dbc.DeepCopy()
+		other.Namespace = "tenant-b"
+		third, err := r.configReader(other)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(third).NotTo(BeIdenticalTo(first))
+	})
+
+	It("refuses an annotated service account when impersonation is disabled", func() {
+		dbc.Annotations = map[string]string{apiv1alpha1.ServiceAccountAnnotation: "connectors"}
+		r := &DebeziumConnectorReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+		_, err := r.configReader(dbc)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// importPlaceholder replaces sensitive values in generated Secrets unless their values are included.
+const importPlaceholder = "REPLACE_ME"
+
+// invalidNameChars matches runs of characters not allowed in a Kubernetes resource name.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ImportOptions configures ImportConnectors.
+type ImportOptions struct {
+	// Host is the Kafka Connect REST endpoint to import connectors from.
+	Host string
+	// BasePath is the path prefix the Kafka Connect REST API is served below on Host.
+	BasePath string
+	// Namespace is the namespace of the generated resources.
+	Namespace string
+	// NamePrefix restricts the import to connectors whose names start with the prefix.
+	NamePrefix string
+	// IncludeSecretValues writes the sensitive values into the generated Secrets instead of
+	// placeholders.
+	IncludeSecretValues bool
+	// HTTPClient talks to Host; the shared default client is used when nil.
+	HTTPClient *http.Client
+}
+
+// ImportedConnector is a DebeziumConnector generated from a connector on a Debezium host, and
+// the Secret holding the connector's sensitive config values, if it has any.
+type ImportedConnector struct {
+	Connector *apiv1alpha1.DebeziumConnector
+	Secret    *corev1.Secret
+}
+
+// ImportConnectors generates a DebeziumConnector for each connector on opts.Host, with the config
+// read from the connector's config endpoint. Sensitive values are moved into a Secret referenced
+// by Spec.ConfigSecretRef, while config provider references such as ${file:...} stay inline.
+func ImportConnectors(ctx context.Context, opts ImportOptions) ([]ImportedConnector, error) {
+	r := &DebeziumConnectorReconciler{HTTPClient: opts.HTTPClient, ConnectBasePath: opts.BasePath}
+	if r.HTTPClient == nil {
+		r.HTTPClient = defaultHTTPClient
+	}
+	host := strings.TrimSuffix(opts.Host, "/")
+	summaries, err := r.listConnectors(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var imported []ImportedConnector
+	for _, summary := range summaries {
+		if !strings.HasPrefix(summary.Name, opts.NamePrefix) {
+			continue
+		}
+		config, err := r.getDebeziumConnectorConfig(ctx, host, summary.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import connector %s: %w", summary.Name, err)
+		}
+		imported = append(imported, importConnector(host, opts, summary.Name, config))
+	}
+	return imported, nil
+}
+
+// importConnector builds the resources for the connector name with config.
+func importConnector(host string, opts ImportOptions, name string, config map[string]string) ImportedConnector {
+	resourceName := importedResourceName(name)
+	dbc := &apiv1alpha1.DebeziumConnector{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiv1alpha1.GroupVersion.String(), Kind: "DebeziumConnector"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName,
+			Namespace: opts.Namespace,
+		},
+		Spec: apiv1alpha1.DebeziumConnectorSpec{
+			DebeziumHost: host,
+			Config:       map[string]string{},
+		},
+	}
+	secretData := map[string]string{}
+	for k, v := range config {
+		if util.IsSensitiveKey(k) && !strings.HasPrefix(v, "${") {
+			if !opts.IncludeSecretValues {
+				v = importPlaceholder
+			}
+			secretData[k] = v
+			continue
+		}
+		dbc.Spec.Config[k] = v
+	}
+	if len(secretData) == 0 {
+		return ImportedConnector{Connector: dbc}
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName + "-config",
+			Namespace: opts.Namespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: secretData,
+	}
+	dbc.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: secret.Name}
+	return ImportedConnector{Connector: dbc, Secret: secret}
+}
+
+// importedResourceName derives a Kubernetes resource name from a connector name.
+func importedResourceName(name string) string {
+	resourceName := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(resourceName) > 253 {
+		resourceName = strings.TrimRight(resourceName[:253], "-")
+	}
+	if resourceName == "" {
+		resourceName = "connector"
+	}
+	return resourceName
+}
+
+// Manifest renders the DebeziumConnector and its Secret as a multi-document YAML manifest.
+func (c ImportedConnector) Manifest() ([]byte, error) {
+	var buf bytes.Buffer
+	objects := []interface{}{c.Connector}
+	if c.Secret != nil {
+		objects = append(objects, c.Secret)
+	}
+	for i, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteImportedConnectors writes the manifest of each connector to <dir>/<name>.yaml and returns
+// the paths written, in order.
+func WriteImportedConnectors(dir string, imported []ImportedConnector) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(imported))
+	for _, c := range imported {
+		data, err := c.Manifest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to render connector %s: %w", c.Connector.Name, err)
+		}
+		path := filepath.Join(dir, c.Connector.Name+".yaml")
+		// The Secret may hold credentials.
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Importing connectors", func() {
+	ctx := context.Background()
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		connect.setConnector(map[string]string{
+			"name":              "inventory",
+			"connector.class":   "io.debezium.connector.mysql.MySqlConnector",
+			"database.user":     "debezium",
+			"database.password": "dbz",
+			"topic.prefix":      "inventory",
+		}, "RUNNING")
+		connect.setConnector(map[string]string{
+			"name":              "Orders_CDC",
+			"connector.class":   "io.debezium.connector.postgresql.PostgresConnector",
+			"database.password": "${file:/secrets/db.properties:password}",
+		}, "PAUSED")
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	importAll := func(opts ImportOptions) []ImportedConnector {
+		opts.Host = connect.URL
+		opts.HTTPClient = connect.Client()
+		imported, err := ImportConnectors(ctx, opts)
+		Expect(err).NotTo(HaveOccurred())
+		return imported
+	}
+
+	It("generates a DebeziumConnector per connector with the sensitive values in a Secret", func() {
+		imported := importAll(ImportOptions{Namespace: "cdc"})
+		Expect(imported).To(HaveLen(2))
+
+		orders := imported[0]
+		Expect(orders.Connector.Name).To(Equal("orders-cdc"))
+		Expect(orders.Connector.Spec.Config).To(HaveKeyWithValue("name", "Orders_CDC"))
+		Expect(orders.Connector.Spec.Config).To(HaveKeyWithValue("database.password", "${file:/secrets/db.properties:password}"))
+		Expect(orders.Connector.Spec.ConfigSecretRef).To(BeNil())
+		Expect(orders.Secret).To(BeNil())
+
+		inventory := imported[1]
+		Expect(inventory.Connector.Namespace).To(Equal("cdc"))
+		Expect(inventory.Connector.Spec.DebeziumHost).To(Equal(connect.URL))
+		Expect(inventory.Connector.Spec.Config).To(HaveKeyWithValue("database.user", "debezium"))
+		Expect(inventory.Connector.Spec.Config).NotTo(HaveKey("database.password"))
+		Expect(inventory.Connector.Spec.ConfigSecretRef).To(Equal(&corev1.LocalObjectReference{Name: "inventory-config"}))
+		Expect(inventory.Secret.Namespace).To(Equal("cdc"))
+		Expect(inventory.Secret.StringData).To(Equal(map[string]string{"database.password": importPlaceholder}))
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+
+	It("includes the sensitive values when asked to", func() {
+		imported := importAll(ImportOptions{NamePrefix: "inv", IncludeSecretValues: true})
+		Expect(imported).To(HaveLen(1))
+		Expect(imported[0].Secret.StringData).To(HaveKeyWithValue("database.password", "dbz"))
+	})
+
+	It("renders manifests that decode into the generated resources", func() {
+		imported := importAll(ImportOptions{Namespace: "default", NamePrefix: "inventory"})
+		dir := GinkgoT().TempDir()
+		paths, err := WriteImportedConnectors(dir, imported)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{filepath.Join(dir, "inventory.yaml")}))
+
+		info, err := os.Stat(paths[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+		data, err := os.ReadFile(paths[0])
+		Expect(err).NotTo(HaveOccurred())
+		docs := strings.Split(string(data), "---\n")
+		Expect(docs).To(HaveLen(2))
+		dbc := &apiv1alpha1.DebeziumConnector{}
+		Expect(yaml.Unmarshal([]byte(docs[0]), dbc)).To(Succeed())
+		Expect(dbc.Kind).To(Equal("DebeziumConnector"))
+		Expect(dbc.Spec.Config).To(Equal(imported[0].Connector.Spec.Config))
+		secret := &corev1.Secret{}
+		Expect(yaml.Unmarshal([]byte(docs[1]), secret)).To(Succeed())
+		Expect(secret.Kind).To(Equal("Secret"))
+		Expect(secret.StringData).To(HaveKey("database.password"))
+	})
+
+	It("derives valid resource names", func() {
+		Expect(importedResourceName("Orders_CDC.v2")).To(Equal("orders-cdc-v2"))
+		Expect(importedResourceName("--inventory--")).To(Equal("inventory"))
+		Expect(importedResourceName("___")).To(Equal("connector"))
+	})
+})
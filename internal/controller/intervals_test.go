@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Requeue intervals", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector, httpClient *http.Client) (reconcile.Result, error) {
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: httpClient,
+		}
+		return r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	}
+
+	It("polls after the reconcile interval on success", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.ReconcileIntervalSeconds = 300
+
+		result, err := reconcileOnce(dbc, connect.Client())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(300 * time.Second))
+	})
+
+	Context("When the Debezium host is unreachable", func() {
+		var host string
+
+		BeforeEach(func() {
+			server := httptest.NewServer(http.NotFoundHandler())
+			host = server.URL
+			server.Close()
+		})
+
+		It("returns the error for backoff when no retry interval is set", func() {
+			result, err := reconcileOnce(newTestDebeziumConnector(host), http.DefaultClient)
+			Expect(err).To(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+		})
+
+		It("requeues after the retry interval without an error", func() {
+			dbc := newTestDebeziumConnector(host)
+			dbc.Spec.RetryIntervalSeconds = 5
+
+			result, err := reconcileOnce(dbc, http.DefaultClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+		})
+	})
+})
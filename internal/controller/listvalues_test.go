@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("List-valued config drift", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileWith := func(desired, onHost string, listKeys map[string]util.ListNormalization) []string {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["table.include.list"] = desired
+		hostConfig := map[string]string{}
+		for k, v := range dbc.Spec.Config {
+			hostConfig[k] = v
+		}
+		hostConfig["table.include.list"] = onHost
+		connect.setConnector(hostConfig, "RUNNING")
+
+		r := &DebeziumConnectorReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:     connect.Client(),
+			ListValuedKeys: listKeys,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		return connect.mutations()
+	}
+
+	It("ignores reordered list items when normalization is enabled", func() {
+		Expect(reconcileWith("a,b", "b,a", util.DefaultListValuedKeys)).To(BeEmpty())
+	})
+
+	It("ignores whitespace around list items when normalization is enabled", func() {
+		Expect(reconcileWith("a,b", "a, b", util.DefaultListValuedKeys)).To(BeEmpty())
+	})
+
+	It("still updates lists with different items", func() {
+		Expect(reconcileWith("a,b", "a,c", util.DefaultListValuedKeys)).To(ConsistOf("PUT /connectors/inventory/config"))
+	})
+
+	It("compares values exactly when normalization is disabled", func() {
+		Expect(reconcileWith("a,b", "b,a", nil)).To(ConsistOf("PUT /connectors/inventory/config"))
+	})
+})
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var (
+	// featureLoggers is GET and PUT /admin/loggers (KIP-495).
+	featureLoggers = connectFeature{name: "logger levels", major: 2, minor: 4, unsupportedReason: "LoggersUnsupported"}
+	// featureClusterLoggers is PUT /admin/loggers/{logger}?scope=cluster (KIP-976).
+	featureClusterLoggers = connectFeature{name: "cluster-wide logger levels", major: 3, minor: 7, unsupportedReason: "ClusterLoggersUnsupported"}
+)
+
+// connectorLogger returns the logger of the connector class's package, or "" when config has no
+// qualified connector class.
+func connectorLogger(config map[string]string) string {
+	class := config["connector.class"]
+	i := strings.LastIndex(class, ".")
+	if i <= 0 {
+		return ""
+	}
+	return class[:i]
+}
+
+// getLoggerLevel returns the effective level of logger on the Debezium host: the level of the
+// logger or of its closest configured ancestor, else the root level.
+func (r *DebeziumConnectorReconciler) getLoggerLevel(ctx context.Context, host, logger string) (string, error) {
+	resp, err := r.get(ctx, r.connectURL(host, "/admin/loggers/"))
+	if err != nil {
+		return "", fmt.Errorf("failed to GET loggers: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GET loggers returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	var loggers map[string]struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&loggers); err != nil {
+		return "", fmt.Errorf("failed to decode loggers: %w", err)
+	}
+	for name := logger; name != ""; {
+		if l, ok := loggers[name]; ok {
+			return l.Level, nil
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return loggers["root"].Level, nil
+}
+
+// setLoggerLevel sends PUT /admin/loggers/{logger}. On Connect 3.7 or later the level is applied
+// to all workers of the cluster, before that only to the worker serving the request.
+func (r *DebeziumConnectorReconciler) setLoggerLevel(ctx context.Context, host, logger, level string) error {
+	url := r.connectURL(host, "/admin/loggers/%s", logger)
+	if r.supportsFeature(ctx, host, featureClusterLoggers) == nil {
+		url += "?scope=cluster"
+	}
+	return r.sendJSON(ctx, http.MethodPut, url, map[string]string{"level": level}, nil)
+}
+
+// reconcileLogLevel applies Spec.LogLevel to the connector's logger and restores the previous
+// level once it is cleared. Levels are kept in memory by the workers, so the level is checked on
+// every reconcile and re-applied after a worker restart.
+func (r *DebeziumConnectorReconciler) reconcileLogLevel(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string, until time.Time) error {
+	if (dbc.Spec.LogLevel == "" && dbc.Status.Logger == nil) || !r.mutationsAllowed(dbc, until) {
+		return nil
+	}
+	if !r.requireFeature(ctx, dbc, featureLoggers) {
+		return nil
+	}
+	host := dbc.Spec.DebeziumHost
+	logger := connectorLogger(config)
+
+	// Restore the previous level when the level is cleared or the connector class changed.
+	if applied := dbc.Status.Logger; applied != nil && (dbc.Spec.LogLevel == "" || applied.Name != logger) {
+		if applied.PreviousLevel != "" {
+			if err := r.setLoggerLevel(ctx, host, applied.Name, applied.PreviousLevel); err != nil {
+				return fmt.Errorf("failed to restore level of logger %s: %w", applied.Name, err)
+			}
+		}
+		dbc.Status.Logger = nil
+		r.recordEvent(dbc, corev1.EventTypeNormal, "LogLevelRestored",
+			"Restored level %s of logger %s", applied.PreviousLevel, applied.Name)
+		log.FromContext(ctx).Info("Restored logger level", "logger", applied.Name, "level", applied.PreviousLevel)
+	}
+	if dbc.Spec.LogLevel == "" || logger == "" {
+		return nil
+	}
+
+	current, err := r.getLoggerLevel(ctx, host, logger)
+	if err != nil {
+		return err
+	}
+	if dbc.Status.Logger == nil {
+		dbc.Status.Logger = &apiv1alpha1.LoggerStatus{Name: logger, PreviousLevel: current}
+	}
+	if !strings.EqualFold(current, dbc.Spec.LogLevel) {
+		if err := r.setLoggerLevel(ctx, host, logger, dbc.Spec.LogLevel); err != nil {
+			return fmt.Errorf("failed to set level of logger %s: %w", logger, err)
+		}
+		r.recordEvent(dbc, corev1.EventTypeNormal, "LogLevelChanged",
+			"Changed level of logger %s from %s to %s", logger, current, dbc.Spec.LogLevel)
+		log.FromContext(ctx).Info("Changed logger level", "logger", logger, "from", current, "to", dbc.Spec.LogLevel)
+	}
+	dbc.Status.Logger.Level = dbc.Spec.LogLevel
+	return nil
+}
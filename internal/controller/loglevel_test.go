@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector log levels", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	const mysqlLogger = "io.debezium.connector.mysql"
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	loggerLevel := func(name string) string {
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		return connect.loggers[name]
+	}
+
+	It("derives the logger from the connector class", func() {
+		Expect(connectorLogger(map[string]string{"connector.class": "io.debezium.connector.mysql.MySqlConnector"})).To(Equal(mysqlLogger))
+		Expect(connectorLogger(map[string]string{"connector.class": "MySqlConnector"})).To(BeEmpty())
+		Expect(connectorLogger(map[string]string{})).To(BeEmpty())
+	})
+
+	It("sets the level and restores the previous one once it is cleared", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.LogLevel = "DEBUG"
+		r := newReconciler(dbc)
+
+		latest := reconcileOnce(r)
+		Expect(loggerLevel(mysqlLogger)).To(Equal("DEBUG"))
+		Expect(latest.Status.Logger).To(Equal(&apiv1alpha1.LoggerStatus{Name: mysqlLogger, Level: "DEBUG", PreviousLevel: "INFO"}))
+		Expect(recorder.Events).To(Receive(ContainSubstring("LogLevelChanged")))
+
+		latest = reconcileOnce(r)
+		Expect(recorder.Events).NotTo(Receive(ContainSubstring("LogLevelChanged")))
+
+		latest.Spec.LogLevel = ""
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		latest = reconcileOnce(r)
+		Expect(loggerLevel(mysqlLogger)).To(Equal("INFO"))
+		Expect(latest.Status.Logger).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("LogLevelRestored")))
+	})
+
+	It("re-applies the level after a worker restart", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.LogLevel = "TRACE"
+		r := newReconciler(dbc)
+		reconcileOnce(r)
+
+		connect.mu.Lock()
+		delete(connect.loggers, mysqlLogger)
+		connect.mu.Unlock()
+		latest := reconcileOnce(r)
+		Expect(loggerLevel(mysqlLogger)).To(Equal("TRACE"))
+		Expect(latest.Status.Logger.PreviousLevel).To(Equal("INFO"))
+	})
+
+	It("uses the level of the closest configured ancestor", func() {
+		connect.loggers["io.debezium"] = "WARN"
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.LogLevel = "DEBUG"
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(latest.Status.Logger.PreviousLevel).To(Equal("WARN"))
+	})
+
+	It("changes the level cluster-wide on Connect 3.7", func() {
+		connect.version = "3.7.0"
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.LogLevel = "DEBUG"
+
+		reconcileOnce(newReconciler(dbc))
+		Expect(connect.loggerScope).To(Equal("cluster"))
+	})
+
+	It("reports Connect versions without the loggers endpoint", func() {
+		connect.version = "2.3.1"
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.LogLevel = "DEBUG"
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(loggerLevel(mysqlLogger)).To(BeEmpty())
+		Expect(latest.Status.Logger).To(BeNil())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionFeatureSupported)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("LoggersUnsupported"))
+	})
+})
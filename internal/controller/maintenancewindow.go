@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// deferredUntil returns the start of the next maintenance window of dbc when changes to the
+// connector have to wait for it at now, or the zero time when they can be applied.
+func deferredUntil(dbc *apiv1alpha1.DebeziumConnector, now time.Time) (time.Time, error) {
+	if dbc.Spec.MaintenanceWindow == nil {
+		return time.Time{}, nil
+	}
+	open, next, err := dbc.Spec.MaintenanceWindow.Open(now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid maintenance window: %w", err)
+	}
+	if open {
+		return time.Time{}, nil
+	}
+	return next, nil
+}
+
+// recordDeferral reports in the DeferredUntil condition that changes wait for the maintenance
+// window opening at until, or removes the condition when until is zero.
+func recordDeferral(dbc *apiv1alpha1.DebeziumConnector, until time.Time) {
+	if until.IsZero() {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionDeferredUntil)
+		return
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionDeferredUntil,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OutsideMaintenanceWindow",
+		Message:            fmt.Sprintf("Changes are deferred until the maintenance window opens at %s", until.Format(time.RFC3339)),
+		ObservedGeneration: dbc.Generation,
+	})
+}
+
+// mutationsAllowed reports whether the connector of dbc may be created, updated, restarted, paused,
+// resumed or stopped: reconciliation is not paused and no maintenance window defers changes until
+// until.
+func (r *DebeziumConnectorReconciler) mutationsAllowed(dbc *apiv1alpha1.DebeziumConnector, until time.Time) bool {
+	return !r.isPaused(dbc) && until.IsZero()
+}
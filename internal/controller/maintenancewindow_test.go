@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Maintenance windows", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	// window returns a daily window opening and closing at the given offsets from now.
+	window := func(start, end time.Duration) *apiv1alpha1.MaintenanceWindow {
+		now := time.Now().UTC()
+		return &apiv1alpha1.MaintenanceWindow{Start: now.Add(start).Format("15:04"), End: now.Add(end).Format("15:04")}
+	}
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) (reconcile.Result, *apiv1alpha1.DebeziumConnector) {
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return result, latest
+	}
+
+	It("defers changes outside the window while reporting the status", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(map[string]string{"name": "inventory", "connector.class": dbc.Spec.Config["connector.class"]}, "RUNNING")
+		dbc.Spec.MaintenanceWindow = window(2*time.Hour, 3*time.Hour)
+		dbc.Spec.ReconcileIntervalSeconds = 4 * 3600
+
+		result, latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Hour))
+
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDeferredUntil)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(ContainSubstring(time.Now().UTC().Add(2 * time.Hour).Format("15:04")))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionPaused)).To(BeTrue())
+	})
+
+	It("does not enforce the desired state outside the window", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		dbc.Spec.MaintenanceWindow = window(2*time.Hour, 3*time.Hour)
+		dbc.Spec.DesiredState = apiv1alpha1.DesiredStatePaused
+
+		_, latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+	})
+
+	It("applies changes inside the window", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.MaintenanceWindow = window(-time.Hour, time.Hour)
+
+		_, latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionDeferredUntil)).To(BeNil())
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionPaused)).To(BeTrue())
+	})
+
+	It("defers deletion outside the window", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		dbc.Spec.MaintenanceWindow = window(2*time.Hour, 3*time.Hour)
+		now := metav1.Now()
+		dbc.DeletionTimestamp = &now
+
+		result, latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(latest.Finalizers).To(ContainElement(debeziumFinalizer))
+		Expect(result.RequeueAfter).To(BeNumerically("~", 2*time.Hour, time.Minute))
+	})
+})
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// managedConfigKey tags the connectors created by the operator when RequireManagedTag is set.
+// Connect stores the key with the connector config, and connectors ignore it.
+const managedConfigKey = "operator.managed"
+
+// connectorManaged reports whether the operator may change the connector name on host: always
+// unless RequireManagedTag is set, otherwise when the connector does not exist yet or carries
+// the managed tag.
+func (r *DebeziumConnectorReconciler) connectorManaged(ctx context.Context, host, name string) (bool, error) {
+	if !r.RequireManagedTag {
+		return true, nil
+	}
+	exists, err := r.connectorExists(ctx, host, name)
+	if err != nil || !exists {
+		return true, err
+	}
+	config, err := r.getDebeziumConnectorConfig(ctx, host, name)
+	if err != nil {
+		return false, err
+	}
+	return config[managedConfigKey] == "true", nil
+}
+
+// recordUnmanaged sets the Unmanaged condition of dbc, emitting an event when the connector is
+// first found unmanaged.
+func (r *DebeziumConnectorReconciler) recordUnmanaged(dbc *apiv1alpha1.DebeziumConnector, name string, unmanaged bool) {
+	if !unmanaged {
+		if meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionUnmanaged) != nil {
+			meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+				Type:               apiv1alpha1.ConditionUnmanaged,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Managed",
+				Message:            fmt.Sprintf("Connector %s is managed by the operator", name),
+				ObservedGeneration: dbc.Generation,
+			})
+		}
+		return
+	}
+	message := fmt.Sprintf("connector %s exists on the Debezium host without the %s tag and is not changed", name, managedConfigKey)
+	if !meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionUnmanaged) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "UnmanagedConnector", "%s", message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionUnmanaged,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MissingManagedTag",
+		Message:            message,
+		ObservedGeneration: dbc.Generation,
+	})
+}
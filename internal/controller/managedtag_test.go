@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Managed tag", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:        connect.Client(),
+			Recorder:          recorder,
+			RequireManagedTag: true,
+		}
+	}
+
+	withConfig := func(config map[string]string, extra map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range config {
+			merged[k] = v
+		}
+		for k, v := range extra {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("tags the connectors it creates", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue(managedConfigKey, "true"))
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUnmanaged)).To(BeNil())
+	})
+
+	It("updates a tagged connector whose config drifted", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(withConfig(dbc.Spec.Config, map[string]string{managedConfigKey: "true", "snapshot.mode": "never"}), "RUNNING")
+
+		reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+	})
+
+	It("leaves a connector without the tag alone", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(withConfig(dbc.Spec.Config, map[string]string{"snapshot.mode": "never"}), "RUNNING")
+
+		latest := reconcileOnce(newReconciler(dbc))
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUnmanaged)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("MissingManagedTag"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("UnmanagedConnector")))
+	})
+
+	It("manages untagged connectors when the tag is not required", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(withConfig(dbc.Spec.Config, map[string]string{"snapshot.mode": "never"}), "RUNNING")
+		r := newReconciler(dbc)
+		r.RequireManagedTag = false
+
+		reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+		Expect(connect.configs["inventory"]).NotTo(HaveKey(managedConfigKey))
+	})
+
+	It("does not delete a connector without the tag", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		now := metav1.Now()
+		dbc.DeletionTimestamp = &now
+
+		_, err := newReconciler(dbc).Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(ContainSubstring("UnmanagedNotDeleted")))
+	})
+})
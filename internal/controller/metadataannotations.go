@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// restStatus holds the HTTP status code of the last Kafka Connect REST response of a reconcile.
+type restStatus struct {
+	code atomic.Int32
+}
+
+type restStatusContextKey struct{}
+
+// withRESTStatus returns a context recording the status code of each REST response in status.
+func withRESTStatus(ctx context.Context) (context.Context, *restStatus) {
+	status := &restStatus{}
+	return context.WithValue(ctx, restStatusContextKey{}, status), status
+}
+
+// recordRESTStatus records code as the last REST status code of the reconcile of ctx.
+func recordRESTStatus(ctx context.Context, code int) {
+	if status, ok := ctx.Value(restStatusContextKey{}).(*restStatus); ok {
+		status.code.Store(int32(code))
+	}
+}
+
+// operatorAnnotations are the annotations the operator computes and writes to DebeziumConnectors.
+var operatorAnnotations = []string{
+	apiv1alpha1.EffectiveNameAnnotation,
+	apiv1alpha1.LastStatusAnnotation,
+	apiv1alpha1.ConnectVersionAnnotation,
+}
+
+// userAnnotationChangedPredicate passes updates that change annotations other than the
+// operatorAnnotations, so the operator's own writes do not trigger another reconcile.
+type userAnnotationChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements predicate.Predicate.
+func (userAnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return !reflect.DeepEqual(userAnnotations(e.ObjectOld), userAnnotations(e.ObjectNew))
+}
+
+// userAnnotations returns the annotations of obj without the operatorAnnotations.
+func userAnnotations(obj client.Object) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	for _, key := range operatorAnnotations {
+		delete(annotations, key)
+	}
+	return annotations
+}
+
+// metadataAnnotations returns the operator-computed annotations of dbc. Values that are not
+// known, e.g. the last status code of a reconcile that got no response, are left out, so the
+// previous value is kept.
+func (r *DebeziumConnectorReconciler) metadataAnnotations(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *restStatus) map[string]string {
+	annotations := map[string]string{}
+	if name != "" {
+		annotations[apiv1alpha1.EffectiveNameAnnotation] = name
+	}
+	if code := status.code.Load(); code != 0 {
+		annotations[apiv1alpha1.LastStatusAnnotation] = strconv.Itoa(int(code))
+	}
+	if info, err := r.getConnectServerInfo(ctx, dbc.Spec.DebeziumHost); err == nil && info.Version != "" {
+		annotations[apiv1alpha1.ConnectVersionAnnotation] = info.Version
+	}
+	return annotations
+}
+
+// reconcileMetadataAnnotations writes the operator-computed annotations to dbc. The resource is
+// only updated when one of them changed.
+func (r *DebeziumConnectorReconciler) reconcileMetadataAnnotations(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *restStatus) error {
+	annotations := r.metadataAnnotations(ctx, dbc, name, status)
+	changed := false
+	for key, value := range annotations {
+		if dbc.Annotations[key] != value {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	traceStep(ctx, "updating operator annotations")
+	return updateOnConflict(ctx, r.Client, dbc, func(o client.Object) {
+		current := o.GetAnnotations()
+		if current == nil {
+			current = map[string]string{}
+		}
+		for key, value := range annotations {
+			current[key] = value
+		}
+		o.SetAnnotations(current)
+	})
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Operator annotations", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		updates int
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		updates = 0
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["name"] = "inventory-v2"
+		r = &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).
+				WithInterceptorFuncs(interceptor.Funcs{
+					// Count the updates of the resource itself, not of its status.
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						updates++
+						return c.Update(ctx, obj, opts...)
+					},
+				}).Build(),
+			HTTPClient: connect.Client(),
+		}
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileOnce := func() *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("sets the annotations after a reconcile", func() {
+		latest := reconcileOnce()
+		Expect(latest.Annotations).To(HaveKeyWithValue(apiv1alpha1.EffectiveNameAnnotation, "inventory-v2"))
+		Expect(latest.Annotations).To(HaveKeyWithValue(apiv1alpha1.ConnectVersionAnnotation, "3.6.0"))
+		Expect(latest.Annotations).To(HaveKeyWithValue(apiv1alpha1.LastStatusAnnotation, "200"))
+	})
+
+	It("does not rewrite unchanged annotations", func() {
+		reconcileOnce()
+		written := updates
+		Expect(written).To(BeNumerically(">", 0))
+
+		first := reconcileOnce()
+		second := reconcileOnce()
+		Expect(updates).To(Equal(written))
+		Expect(second.Annotations).To(Equal(first.Annotations))
+	})
+
+	It("updates an annotation that changed", func() {
+		reconcileOnce()
+		written := updates
+		connect.mu.Lock()
+		connect.version = "3.7.1"
+		connect.mu.Unlock()
+		r.serverInfo.mu.Lock()
+		r.serverInfo.entries = nil
+		r.serverInfo.mu.Unlock()
+
+		latest := reconcileOnce()
+		Expect(latest.Annotations).To(HaveKeyWithValue(apiv1alpha1.ConnectVersionAnnotation, "3.7.1"))
+		Expect(updates).To(Equal(written + 1))
+	})
+
+	It("is not reconciled again by its own annotation updates", func() {
+		old := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		latest := reconcileOnce()
+		Expect(latest.Annotations).NotTo(Equal(old.Annotations))
+		Expect(userAnnotationChangedPredicate{}.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: latest})).To(BeFalse())
+
+		changed := latest.DeepCopy()
+		changed.Annotations[apiv1alpha1.ReconcilePausedAnnotation] = "true"
+		Expect(userAnnotationChangedPredicate{}.Update(event.UpdateEvent{ObjectOld: latest, ObjectNew: changed})).To(BeTrue())
+	})
+})
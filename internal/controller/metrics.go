@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// orphanedConnectors reports connectors on a Debezium host that have no backing DebeziumConnector.
+	orphanedConnectors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "debezium_orphaned_connectors",
+		Help: "Number of connectors on a Debezium host without a backing DebeziumConnector resource.",
+	}, []string{"host"})
+
+	// prunedOrphansTotal counts orphaned connectors deleted by the operator.
+	prunedOrphansTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "debezium_orphaned_connectors_pruned_total",
+		Help: "Total number of orphaned connectors deleted from a Debezium host.",
+	}, []string{"host"})
+
+	// connectorDriftTotal counts updates issued because the connector config on the Debezium host
+	// differed from the desired config. A connector that drifts on every reconcile usually points
+	// to a value the comparison does not normalize.
+	connectorDriftTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "debezium_connector_drift_total",
+		Help: "Total number of connector updates issued because the config on the Debezium host drifted from the desired config.",
+	}, []string{"connector", "host"})
+
+	// lastDrift reports the time since each connector last drifted.
+	lastDrift = newDriftCollector()
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphanedConnectors, prunedOrphansTotal, connectorDriftTotal, lastDrift)
+}
+
+// driftKey identifies a connector in the drift metrics.
+type driftKey struct {
+	connector string
+	host      string
+}
+
+// driftCollector exports the seconds since each connector last drifted, computed at scrape time.
+type driftCollector struct {
+	desc *prometheus.Desc
+	now  func() time.Time
+
+	mu   sync.Mutex
+	last map[driftKey]time.Time
+}
+
+func newDriftCollector() *driftCollector {
+	return &driftCollector{
+		desc: prometheus.NewDesc("debezium_connector_seconds_since_last_drift",
+			"Seconds since the connector config on the Debezium host last drifted from the desired config.",
+			[]string{"connector", "host"}, nil),
+		now:  time.Now,
+		last: map[driftKey]time.Time{},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *driftCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *driftCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, at := range c.last {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(at).Seconds(), key.connector, key.host)
+	}
+}
+
+func (c *driftCollector) set(connector, host string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last[driftKey{connector: connector, host: host}] = at
+}
+
+func (c *driftCollector) delete(connector, host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.last, driftKey{connector: connector, host: host})
+}
+
+// recordDrift counts a drift of the connector on host.
+func recordDrift(connector, host string) {
+	connectorDriftTotal.WithLabelValues(connector, host).Inc()
+	lastDrift.set(connector, host, lastDrift.now())
+}
+
+// forgetDrift removes the drift metrics of a connector that is no longer managed on host.
+func forgetDrift(connector, host string) {
+	connectorDriftTotal.DeleteLabelValues(connector, host)
+	lastDrift.delete(connector, host)
+}
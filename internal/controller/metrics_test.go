@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Drift metrics", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	// sinceLastDrift returns the exported seconds since the connector last drifted, or -1.
+	sinceLastDrift := func(c *driftCollector, connector, host string) float64 {
+		// Other specs leave drift entries behind, so collect without bounding the channel.
+		ch := make(chan prometheus.Metric)
+		go func() {
+			c.Collect(ch)
+			close(ch)
+		}()
+		value := -1.0
+		for m := range ch {
+			var metric dto.Metric
+			Expect(m.Write(&metric)).To(Succeed())
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["connector"] == connector && labels["host"] == host {
+				value = metric.GetGauge().GetValue()
+			}
+		}
+		return value
+	}
+
+	It("counts updates issued for drifted configs only", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		DeferCleanup(forgetDrift, "inventory", connect.URL)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(connectorDriftTotal.WithLabelValues("inventory", connect.URL))).To(BeZero())
+		Expect(sinceLastDrift(lastDrift, "inventory", connect.URL)).To(Equal(-1.0))
+
+		// The connector is changed on the host.
+		drifted := map[string]string{}
+		for k, v := range dbc.Spec.Config {
+			drifted[k] = v
+		}
+		drifted["tasks.max"] = "4"
+		connect.setConnector(drifted, "RUNNING")
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+		Expect(testutil.ToFloat64(connectorDriftTotal.WithLabelValues("inventory", connect.URL))).To(Equal(1.0))
+		Expect(sinceLastDrift(lastDrift, "inventory", connect.URL)).To(BeNumerically(">=", 0))
+
+		forgetDrift("inventory", connect.URL)
+		Expect(sinceLastDrift(lastDrift, "inventory", connect.URL)).To(Equal(-1.0))
+	})
+
+	It("reports the seconds since the last drift at collection time", func() {
+		now := time.Now()
+		c := newDriftCollector()
+		c.now = func() time.Time { return now }
+		c.set("inventory", "http://connect", now.Add(-90*time.Second))
+		Expect(sinceLastDrift(c, "inventory", "http://connect")).To(Equal(90.0))
+
+		now = now.Add(30 * time.Second)
+		Expect(sinceLastDrift(c, "inventory", "http://connect")).To(Equal(120.0))
+	})
+})
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// featureInitialState is the initial_state field of POST /connectors (KIP-980).
+var featureInitialState = connectFeature{name: "initial state on create", major: 3, minor: 7, unsupportedReason: "InitialStateUnsupported"}
+
+// migrationPendingRequeue is how soon a migration waiting for the connector on the new host to
+// become RUNNING is checked again.
+const migrationPendingRequeue = 10 * time.Second
+
+// migrationPendingError is returned while the connector on the new host is not RUNNING yet, so
+// the connector on the previous host is kept.
+type migrationPendingError struct {
+	host  string
+	state string
+}
+
+func (e *migrationPendingError) Error() string {
+	return fmt.Sprintf("waiting for the connector on %s to become RUNNING before deleting the previous one; it is %s", e.host, e.state)
+}
+
+// migrateConnector moves the connector from the host it was last reconciled on to
+// Spec.DebeziumHost: it stops the old connector, creates the connector on the new host, carrying
+// over committed offsets when both hosts support it, and then deletes the old connector once the
+// new one is RUNNING, unless the migration policy retains it. The progress is recorded in
+// Status.Migration and every step is idempotent, so a failed migration is resumed where it
+// stopped: a connector created stopped on the new host gets its offsets and is started before
+// the old connector is deleted.
+func (r *DebeziumConnectorReconciler) migrateConnector(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	logger := log.FromContext(ctx)
+	oldHost, newHost, name := dbc.Status.DebeziumHost, dbc.Spec.DebeziumHost, config["name"]
+	progress := dbc.Status.Migration
+	if progress == nil || progress.From != oldHost || progress.To != newHost {
+		progress = &apiv1alpha1.MigrationStatus{From: oldHost, To: newHost}
+		dbc.Status.Migration = progress
+	}
+
+	oldExists, err := r.connectorExists(ctx, oldHost, name)
+	if err != nil {
+		return fmt.Errorf("failed to check connector on previous host %s: %w", oldHost, err)
+	}
+
+	// Stop the old connector first so the two never capture changes at the same time.
+	var offsets []connectorOffset
+	if oldExists {
+		action := "pause"
+		if r.supportsFeature(ctx, oldHost, featureStop) == nil {
+			action = "stop"
+		}
+		if err := r.changeConnectorState(ctx, oldHost, name, action); err != nil {
+			return fmt.Errorf("failed to %s connector on previous host %s: %w", action, oldHost, err)
+		}
+		if r.supportsFeature(ctx, oldHost, featureOffsetsRead) == nil {
+			if offsets, err = r.getConnectorOffsets(ctx, oldHost, name); err != nil {
+				return fmt.Errorf("failed to export offsets from previous host %s: %w", oldHost, err)
+			}
+		}
+	}
+	importOffsets := len(offsets) > 0 &&
+		r.supportsFeature(ctx, newHost, featureInitialState) == nil &&
+		r.supportsFeature(ctx, newHost, featureOffsetReset) == nil
+
+	newExists, err := r.connectorExists(ctx, newHost, name)
+	if err != nil {
+		return err
+	}
+	if !newExists {
+		if importOffsets {
+			if err := r.createStoppedConnector(ctx, newHost, config); err != nil {
+				return err
+			}
+			progress.Phase = apiv1alpha1.MigrationPhaseCreated
+		} else {
+			if err := r.createDebeziumConnector(ctx, newHost, config); err != nil {
+				return err
+			}
+			progress.Phase = apiv1alpha1.MigrationPhaseResumed
+		}
+		logger.Info("Debezium connector created on new host", "name", name, "host", newHost, "offsetsImported", importOffsets)
+		if len(offsets) > 0 && !importOffsets {
+			r.recordEvent(dbc, corev1.EventTypeWarning, "OffsetsNotMigrated",
+				"Host %s cannot import offsets; connector %s starts according to its snapshot.mode", newHost, name)
+		}
+	} else if progress.Phase != apiv1alpha1.MigrationPhaseResumed {
+		// A previous attempt may have created the connector stopped and failed before it was
+		// started, possibly without recording that in the status.
+		status, err := r.getDebeziumConnectorStatus(ctx, newHost, name)
+		if err != nil {
+			return fmt.Errorf("failed to get connector status on new host %s: %w", newHost, err)
+		}
+		if status.Connector.State == "STOPPED" {
+			progress.Phase = apiv1alpha1.MigrationPhaseCreated
+		}
+	}
+	if progress.Phase == apiv1alpha1.MigrationPhaseCreated {
+		if importOffsets {
+			if err := r.importConnectorOffsets(ctx, newHost, config, offsets); err != nil {
+				return err
+			}
+		}
+		if err := r.changeConnectorState(ctx, newHost, name, "resume"); err != nil {
+			return fmt.Errorf("failed to resume connector on new host %s: %w", newHost, err)
+		}
+		progress.Phase = apiv1alpha1.MigrationPhaseResumed
+	}
+
+	if oldExists && dbc.Spec.MigrationPolicy != apiv1alpha1.MigrationPolicyRetain {
+		status, err := r.getDebeziumConnectorStatus(ctx, newHost, name)
+		if err != nil {
+			return fmt.Errorf("failed to get connector status on new host %s: %w", newHost, err)
+		}
+		if status.Connector.State != "RUNNING" {
+			return &migrationPendingError{host: newHost, state: status.Connector.State}
+		}
+		if err := r.deleteDebeziumConnector(ctx, oldHost, name); err != nil {
+			return fmt.Errorf("failed to delete connector from previous host %s: %w", oldHost, err)
+		}
+	}
+	forgetDrift(name, oldHost)
+	dbc.Status.Migration = nil
+	if oldExists || !newExists {
+		r.recordEvent(dbc, corev1.EventTypeNormal, "Migrated", "Connector %s moved from %s to %s", name, oldHost, newHost)
+	}
+	return nil
+}
+
+// createStoppedConnector creates a connector that does not start, so offsets can be imported
+// before it runs.
+func (r *DebeziumConnectorReconciler) createStoppedConnector(ctx context.Context, host string, config map[string]string) error {
+	payload := map[string]interface{}{
+		"name":          config["name"],
+		"config":        config,
+		"initial_state": "STOPPED",
+	}
+	if err := r.sendJSON(ctx, http.MethodPost, r.connectURL(host, "/connectors"), payload, config); err != nil {
+		return fmt.Errorf("failed to create stopped connector: %w", err)
+	}
+	return nil
+}
+
+// importConnectorOffsets replaces the offsets of a stopped connector, so it continues where it
+// left off on another host.
+func (r *DebeziumConnectorReconciler) importConnectorOffsets(ctx context.Context, host string, config map[string]string, offsets []connectorOffset) error {
+	offsetsURL := r.connectURL(host, "/connectors/%s/offsets", config["name"])
+	if err := r.sendJSON(ctx, http.MethodPatch, offsetsURL, map[string]interface{}{"offsets": offsets}, config); err != nil {
+		return fmt.Errorf("failed to import offsets: %w", err)
+	}
+	return nil
+}
+
+// changeConnectorState sends PUT /connectors/{name}/{action}, e.g. pause, resume or stop.
+func (r *DebeziumConnectorReconciler) changeConnectorState(ctx context.Context, host, name, action string) error {
+	return r.sendJSON(ctx, http.MethodPut, r.connectURL(host, "/connectors/%s/%s", name, action), nil, nil)
+}
+
+// sendJSON sends a request with an optional JSON body and checks for a 2xx response. Sensitive
+// values of config are redacted from error bodies.
+func (r *DebeziumConnectorReconciler) sendJSON(ctx context.Context, method, url string, body interface{}, config map[string]string) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.operationTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, util.RedactText(string(respBody), config))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector migration", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	offsets := []connectorOffset{{
+		Partition: map[string]interface{}{"server": "inventory"},
+		Offset:    map[string]interface{}{"file": "binlog.000003", "pos": float64(1024)},
+	}}
+
+	var oldConnect, newConnect *fakeConnect
+
+	BeforeEach(func() {
+		oldConnect = newFakeConnect()
+		newConnect = newFakeConnect()
+		newConnect.version = "3.7.0"
+	})
+
+	AfterEach(func() {
+		oldConnect.Close()
+		newConnect.Close()
+	})
+
+	// migrate reconciles a connector that was last reconciled on the old host and now targets the new one.
+	migrate := func(policy string) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(newConnect.URL)
+		dbc.Spec.MigrationPolicy = policy
+		dbc.Status.DebeziumHost = oldConnect.URL
+		oldConnect.setConnector(dbc.Spec.Config, "RUNNING")
+		oldConnect.offsets["inventory"] = offsets
+
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: oldConnect.Client(),
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("creates the connector on the new host with its offsets and deletes the old one", func() {
+		latest := migrate("")
+		Expect(latest.Status.DebeziumHost).To(Equal(newConnect.URL))
+
+		Expect(oldConnect.mutations()).To(Equal([]string{
+			"PUT /connectors/inventory/stop",
+			"DELETE /connectors/inventory",
+		}))
+		Expect(newConnect.mutations()).To(Equal([]string{
+			"POST /connectors",
+			"PATCH /connectors/inventory/offsets",
+			"PUT /connectors/inventory/resume",
+		}))
+		Expect(newConnect.offsets["inventory"]).To(Equal(offsets))
+		Expect(newConnect.states["inventory"]).To(Equal("RUNNING"))
+	})
+
+	It("keeps the stopped connector on the old host with the Retain policy", func() {
+		migrate(apiv1alpha1.MigrationPolicyRetain)
+		Expect(oldConnect.mutations()).To(Equal([]string{"PUT /connectors/inventory/stop"}))
+		Expect(oldConnect.states["inventory"]).To(Equal("STOPPED"))
+		Expect(newConnect.configs).To(HaveKey("inventory"))
+	})
+
+	It("creates the connector without offsets when the new host cannot import them", func() {
+		newConnect.version = "3.6.0"
+		migrate("")
+		Expect(newConnect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(oldConnect.configs).NotTo(HaveKey("inventory"))
+	})
+
+	It("resumes a migration whose offset import failed without deleting the old connector", func() {
+		var failed atomic.Bool
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPatch && !failed.Swap(true) {
+				http.Error(w, "rebalance in progress", http.StatusInternalServerError)
+				return
+			}
+			newConnect.serve(w, req)
+		}))
+		defer gateway.Close()
+		dbc := newTestDebeziumConnector(gateway.URL)
+		dbc.Status.DebeziumHost = oldConnect.URL
+		oldConnect.setConnector(dbc.Spec.Config, "RUNNING")
+		oldConnect.offsets["inventory"] = offsets
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: gateway.Client(),
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).To(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.DebeziumHost).To(Equal(oldConnect.URL))
+		Expect(latest.Status.Migration).To(Equal(&apiv1alpha1.MigrationStatus{
+			From: oldConnect.URL, To: gateway.URL, Phase: apiv1alpha1.MigrationPhaseCreated,
+		}))
+		Expect(oldConnect.configs).To(HaveKey("inventory"))
+		Expect(newConnect.states["inventory"]).To(Equal("STOPPED"))
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.DebeziumHost).To(Equal(gateway.URL))
+		Expect(latest.Status.Migration).To(BeNil())
+		Expect(newConnect.offsets["inventory"]).To(Equal(offsets))
+		Expect(newConnect.states["inventory"]).To(Equal("RUNNING"))
+		Expect(oldConnect.configs).NotTo(HaveKey("inventory"))
+	})
+
+	It("keeps the old connector until the new one is RUNNING", func() {
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/resume") {
+				// Connect accepts the resume, but the connector does not start yet.
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			newConnect.serve(w, req)
+		}))
+		defer gateway.Close()
+		dbc := newTestDebeziumConnector(gateway.URL)
+		dbc.Status.DebeziumHost = oldConnect.URL
+		oldConnect.setConnector(dbc.Spec.Config, "RUNNING")
+		oldConnect.offsets["inventory"] = offsets
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: gateway.Client(),
+		}
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(migrationPendingRequeue))
+		Expect(oldConnect.configs).To(HaveKey("inventory"))
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.Migration.Phase).To(Equal(apiv1alpha1.MigrationPhaseResumed))
+
+		newConnect.mu.Lock()
+		newConnect.states["inventory"] = "RUNNING"
+		newConnect.mu.Unlock()
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(oldConnect.configs).NotTo(HaveKey("inventory"))
+		Expect(newConnect.mutations()).To(Equal([]string{
+			"POST /connectors",
+			"PATCH /connectors/inventory/offsets",
+		}))
+	})
+
+	It("records the host on the first reconcile without migrating", func() {
+		dbc := newTestDebeziumConnector(newConnect.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: newConnect.Client(),
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.DebeziumHost).To(Equal(newConnect.URL))
+		Expect(oldConnect.mutations()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// namespaceHostCache caches the default Debezium host per namespace, including namespaces
+// without one. Entries are invalidated when the Namespace changes. The zero value is ready to use.
+type namespaceHostCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+func (c *namespaceHostCache) get(namespace string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	host, ok := c.entries[namespace]
+	return host, ok
+}
+
+func (c *namespaceHostCache) set(namespace, host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]string{}
+	}
+	c.entries[namespace] = host
+}
+
+func (c *namespaceHostCache) invalidate(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, namespace)
+}
+
+// namespaceDefaultHost returns the host named by the DefaultHostAnnotation of namespace, or ""
+// when the namespace does not name one.
+func (r *DebeziumConnectorReconciler) namespaceDefaultHost(ctx context.Context, namespace string) (string, error) {
+	if host, ok := r.namespaceHosts.get(namespace); ok {
+		return host, nil
+	}
+	host, err := readNamespaceDefaultHost(ctx, r.Client, namespace)
+	if err != nil {
+		return "", err
+	}
+	r.namespaceHosts.set(namespace, host)
+	return host, nil
+}
+
+// readNamespaceDefaultHost reads the DefaultHostAnnotation of namespace with c.
+func readNamespaceDefaultHost(ctx context.Context, c client.Reader, namespace string) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return ns.Annotations[apiv1alpha1.DefaultHostAnnotation], nil
+}
+
+// connectorHost returns the Debezium host of dbc: Spec.DebeziumHost, else the default host of its
+// namespace, else the host it was last reconciled on. It returns "" when dbc has no host.
+func (r *DebeziumConnectorReconciler) connectorHost(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) (string, error) {
+	if dbc.Spec.DebeziumHost != "" {
+		return dbc.Spec.DebeziumHost, nil
+	}
+	host, err := r.namespaceDefaultHost(ctx, dbc.Namespace)
+	if err != nil || host != "" {
+		return host, err
+	}
+	return dbc.Status.DebeziumHost, nil
+}
+
+// findConnectorsForNamespace invalidates the cached default host of a changed Namespace and maps
+// it to the DebeziumConnectors in it that use the default host.
+func (r *DebeziumConnectorReconciler) findConnectorsForNamespace(ctx context.Context, ns client.Object) []reconcile.Request {
+	r.namespaceHosts.invalidate(ns.GetName())
+
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list, client.InNamespace(ns.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DebeziumConnectors for namespace", "namespace", ns.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.DebeziumHost != "" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.Namespace, Name: item.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace default hosts", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newNamespace := func(name, host string) *corev1.Namespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if host != "" {
+			ns.Annotations = map[string]string{apiv1alpha1.DefaultHostAnnotation: host}
+		}
+		return ns
+	}
+
+	newReconciler := func(objs ...client.Object) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).WithStatusSubresource(&apiv1alpha1.DebeziumConnector{}).Build(),
+			HTTPClient: connect.Client(),
+			Recorder:   recorder,
+		}
+	}
+
+	It("prefers the host in the spec", func() {
+		dbc := newTestDebeziumConnector("http://explicit:8083")
+		r := newReconciler(newNamespace("default", "http://tenant:8083"))
+
+		host, err := r.connectorHost(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://explicit:8083"))
+	})
+
+	It("resolves the default host of the namespace", func() {
+		dbc := newTestDebeziumConnector("")
+		r := newReconciler(newNamespace("default", "http://tenant:8083"), newNamespace("other", "http://other:8083"))
+
+		host, err := r.connectorHost(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://tenant:8083"))
+
+		dbc.Namespace = "other"
+		host, err = r.connectorHost(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://other:8083"))
+	})
+
+	It("falls back to the host the connector was last reconciled on", func() {
+		dbc := newTestDebeziumConnector("")
+		dbc.Status.DebeziumHost = "http://previous:8083"
+		r := newReconciler(newNamespace("default", ""))
+
+		host, err := r.connectorHost(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://previous:8083"))
+	})
+
+	It("caches the default host until the namespace changes", func() {
+		ns := newNamespace("default", "http://tenant:8083")
+		explicit := newTestDebeziumConnector("http://explicit:8083")
+		explicit.Name = "explicit"
+		r := newReconciler(ns, newTestDebeziumConnector(""), explicit)
+
+		host, err := r.namespaceDefaultHost(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://tenant:8083"))
+
+		ns.Annotations[apiv1alpha1.DefaultHostAnnotation] = "http://moved:8083"
+		Expect(r.Update(ctx, ns)).To(Succeed())
+		host, err = r.namespaceDefaultHost(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://tenant:8083"))
+
+		requests := r.findConnectorsForNamespace(ctx, ns)
+		Expect(requests).To(Equal([]reconcile.Request{{NamespacedName: key}}))
+		host, err = r.namespaceDefaultHost(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host).To(Equal("http://moved:8083"))
+	})
+
+	It("creates the connector on the default host", func() {
+		r := newReconciler(newNamespace("default", connect.URL), newTestDebeziumConnector(""))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Spec.DebeziumHost).To(BeEmpty())
+		Expect(latest.Status.DebeziumHost).To(Equal(connect.URL))
+	})
+
+	It("reports a connector without a host", func() {
+		r := newReconciler(newNamespace("default", ""), newTestDebeziumConnector(""))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).To(MatchError(ContainSubstring(apiv1alpha1.DefaultHostAnnotation)))
+		Expect(recorder.Events).To(Receive(ContainSubstring("HostUnresolved")))
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,347 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// networkPolicyResync is how often the policy is recomputed, so changed DNS records of external
+// Connect hosts are picked up.
+const networkPolicyResync = 5 * time.Minute
+
+// NetworkPolicyReconciler maintains a NetworkPolicy that allows the operator pods egress to every
+// Debezium host referenced by a DebeziumConnector, and to the other endpoints the operator talks
+// to. The policy also allows DNS and the Kubernetes API server, so it does not cut the operator
+// off when no other egress policy selects its pods.
+// It is owned by the operator Deployment and removed together with it.
+type NetworkPolicyReconciler struct {
+	client.Client
+	// APIReader reads Deployments, Services and Endpoints without starting cluster-wide informers;
+	// Client is used when nil.
+	APIReader client.Reader
+	// Policy is the name and namespace of the managed NetworkPolicy.
+	Policy types.NamespacedName
+	// DeploymentName is the operator Deployment in Policy.Namespace. Its pod selector selects the
+	// pods the policy applies to, and it owns the policy.
+	DeploymentName string
+	// LookupIP resolves hosts outside the cluster; net.DefaultResolver is used when nil.
+	LookupIP func(ctx context.Context, host string) ([]net.IP, error)
+	// ExtraHosts are further endpoints the operator reaches, e.g. the Kafka bootstrap servers,
+	// Vault and the state change webhook, as URLs or host:port pairs.
+	ExtraHosts []string
+	// SchemaRegistries allows egress to the schema registries of the connectors' converters,
+	// which the validating webhook probes.
+	SchemaRegistries bool
+}
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get
+//+kubebuilder:rbac:groups="",resources=services;endpoints,verbs=get
+
+// Reconcile computes the set of Debezium hosts and creates or updates the NetworkPolicy.
+func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	deployment := &appsv1.Deployment{}
+	if err := r.reader().Get(ctx, types.NamespacedName{Namespace: r.Policy.Namespace, Name: r.DeploymentName}, deployment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get operator deployment %s: %w", r.DeploymentName, err)
+	}
+
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list); err != nil {
+		return ctrl.Result{}, err
+	}
+	hosts := map[string]bool{}
+	for i := range list.Items {
+		dbc := &list.Items[i]
+		host := dbc.Spec.DebeziumHost
+		if host == "" {
+			// Connectors without a host run on the default host of their namespace.
+			defaultHost, err := readNamespaceDefaultHost(ctx, r.Client, dbc.Namespace)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			host = defaultHost
+			if host == "" {
+				host = dbc.Status.DebeziumHost
+			}
+		}
+		if host != "" {
+			hosts[host] = true
+		}
+		// The validating webhook validates the config against the validation host, if set.
+		if dbc.Spec.ValidationHost != "" {
+			hosts[dbc.Spec.ValidationHost] = true
+		}
+		if r.SchemaRegistries {
+			config, _ := dbc.DesiredConfig()
+			for _, registry := range schemaRegistryURLs(config) {
+				hosts[registry] = true
+			}
+		}
+	}
+	// Connectors of DebeziumConnectorSets run on the hosts of their sets.
+	setMembers, err := connectorSetMembers(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for host := range setMembers {
+		hosts[host] = true
+	}
+	for _, host := range r.ExtraHosts {
+		if host != "" {
+			hosts[host] = true
+		}
+	}
+
+	rules, err := r.apiServerEgress(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rules = append(rules, dnsEgress())
+	for _, host := range sortedKeys(hosts) {
+		rule, err := r.connectEgress(ctx, host)
+		if err != nil {
+			// An unresolvable host is unreachable anyway; keep allowing the others.
+			logger.Error(err, "failed to compute egress for host", "host", host)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: r.Policy.Name, Namespace: r.Policy.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Labels = util.StandardLabels(policy.Labels)
+		policy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: *deployment.Spec.Selector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		}
+		return controllerutil.SetControllerReference(deployment, policy, r.Scheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("NetworkPolicy reconciled", "policy", r.Policy, "operation", op, "hosts", len(hosts))
+	}
+	return ctrl.Result{RequeueAfter: networkPolicyResync}, nil
+}
+
+// reader returns the reader for objects the reconciler does not watch.
+func (r *NetworkPolicyReconciler) reader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// connectEgress returns the egress rule for a Debezium host or another endpoint. In-cluster Service hosts
+// (<service>.<namespace>.svc) are matched by the Service's pods and target port; other hosts
+// are resolved to IP blocks.
+func (r *NetworkPolicyReconciler) connectEgress(ctx context.Context, host string) (networkingv1.NetworkPolicyEgressRule, error) {
+	hostname, port, err := parseHostPort(host)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, err
+	}
+
+	if svcName, namespace, ok := serviceHost(hostname); ok {
+		return r.serviceEgress(ctx, svcName, namespace, port)
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(hostname); ip != nil {
+		ips = []net.IP{ip}
+	} else if ips, err = r.lookupIP(ctx, hostname); err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, fmt.Errorf("failed to resolve %s: %w", hostname, err)
+	}
+	rule := networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{tcpPort(intstr.FromInt32(port))}}
+	for _, ip := range ips {
+		rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(ip)}})
+	}
+	return rule, nil
+}
+
+// serviceEgress returns the egress rule for the pods behind a Service. Policies apply after the
+// Service address is translated, so the rule uses the Service's selector and target port.
+func (r *NetworkPolicyReconciler) serviceEgress(ctx context.Context, name, namespace string, port int32) (networkingv1.NetworkPolicyEgressRule, error) {
+	peer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}},
+	}
+	target := intstr.FromInt32(port)
+
+	svc := &corev1.Service{}
+	if err := r.reader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, svc); err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, fmt.Errorf("failed to get Service %s/%s: %w", namespace, name, err)
+	}
+	if len(svc.Spec.Selector) > 0 {
+		peer.PodSelector = &metav1.LabelSelector{MatchLabels: svc.Spec.Selector}
+	}
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port && p.TargetPort != (intstr.IntOrString{}) {
+			target = p.TargetPort
+		}
+	}
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{tcpPort(target)},
+		To:    []networkingv1.NetworkPolicyPeer{peer},
+	}, nil
+}
+
+// apiServerEgress allows egress to the Kubernetes API server endpoints.
+func (r *NetworkPolicyReconciler) apiServerEgress(ctx context.Context) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	endpoints := &corev1.Endpoints{}
+	if err := r.reader().Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "kubernetes"}, endpoints); err != nil {
+		return nil, fmt.Errorf("failed to get API server endpoints: %w", err)
+	}
+	var rules []networkingv1.NetworkPolicyEgressRule
+	for _, subset := range endpoints.Subsets {
+		rule := networkingv1.NetworkPolicyEgressRule{}
+		for _, port := range subset.Ports {
+			rule.Ports = append(rule.Ports, tcpPort(intstr.FromInt32(port.Port)))
+		}
+		for _, addr := range subset.Addresses {
+			if ip := net.ParseIP(addr.IP); ip != nil {
+				rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(ip)}})
+			}
+		}
+		if len(rule.To) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// lookupIP resolves hostname with LookupIP or the default resolver.
+func (r *NetworkPolicyReconciler) lookupIP(ctx context.Context, hostname string) ([]net.IP, error) {
+	if r.LookupIP != nil {
+		return r.LookupIP(ctx, hostname)
+	}
+	return net.DefaultResolver.LookupIP(ctx, "ip", hostname)
+}
+
+// dnsEgress allows DNS lookups, which the operator needs to reach Debezium hosts by name.
+func dnsEgress() networkingv1.NetworkPolicyEgressRule {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	port := intstr.FromInt32(53)
+	return networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{
+		{Protocol: &udp, Port: &port},
+		{Protocol: &tcp, Port: &port},
+	}}
+}
+
+// tcpPort returns a TCP NetworkPolicyPort.
+func tcpPort(port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	tcp := corev1.ProtocolTCP
+	return networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port}
+}
+
+// hostCIDR returns the single-address CIDR of ip.
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// parseHostPort returns the hostname and port of a Debezium host URL. Hosts without a scheme are
+// treated as http, and the port defaults to that of the scheme.
+func parseHostPort(host string) (string, int32, error) {
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", 0, err
+	}
+	if u.Hostname() == "" {
+		return "", 0, fmt.Errorf("debezium host %q has no hostname", host)
+	}
+	port := int32(80)
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if p := u.Port(); p != "" {
+		n, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid port in debezium host %q: %w", host, err)
+		}
+		port = int32(n)
+	}
+	return u.Hostname(), port, nil
+}
+
+// schemaRegistryURLs returns the schema registry URLs of the converters in config. Values with
+// unresolved ${...} references are left out.
+func schemaRegistryURLs(config map[string]string) []string {
+	var urls []string
+	for _, key := range util.SchemaRegistryURLKeys {
+		for _, raw := range strings.Split(config[key], ",") {
+			if raw = strings.TrimSpace(raw); raw != "" && !strings.Contains(raw, "${") {
+				urls = append(urls, raw)
+			}
+		}
+	}
+	return urls
+}
+
+// serviceHost splits an in-cluster Service hostname such as connect.kafka.svc or
+// connect.kafka.svc.cluster.local into the Service name and namespace.
+func serviceHost(hostname string) (name, namespace string, ok bool) {
+	parts := strings.Split(hostname, ".")
+	if len(parts) < 3 || parts[2] != "svc" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// sortedKeys returns the keys of m in order, so the generated policy is stable.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetupWithManager sets up the controller with the Manager. Every DebeziumConnector and
+// DebeziumConnectorSet spec change triggers a recomputation of the single managed policy.
+func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueuePolicy := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: r.Policy}}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("networkpolicy").
+		For(&networkingv1.NetworkPolicy{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.Policy.Namespace && obj.GetName() == r.Policy.Name
+		}))).
+		Watches(&apiv1alpha1.DebeziumConnector{}, enqueuePolicy, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&apiv1alpha1.DebeziumConnectorSet{}, enqueuePolicy, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, enqueuePolicy, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
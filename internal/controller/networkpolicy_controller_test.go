@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("NetworkPolicy reconciliation", func() {
+	ctx := context.Background()
+	policyKey := types.NamespacedName{Namespace: "debezium-operator-ns", Name: "debezium-operator-egress"}
+
+	var c client.Client
+
+	connector := func(name, host string) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(host)
+		dbc.Name = name
+		dbc.Finalizers = nil
+		return dbc
+	}
+
+	reconcilePolicy := func(configure ...func(*NetworkPolicyReconciler)) *networkingv1.NetworkPolicy {
+		r := &NetworkPolicyReconciler{
+			Client:         c,
+			Policy:         policyKey,
+			DeploymentName: "debezium-operator",
+			LookupIP: func(_ context.Context, host string) ([]net.IP, error) {
+				if host == "connect.example.com" {
+					return []net.IP{net.ParseIP("192.0.2.10"), net.ParseIP("2001:db8::10")}, nil
+				}
+				return nil, errors.New("no such host")
+			},
+		}
+		for _, f := range configure {
+			f(r)
+		}
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: policyKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(networkPolicyResync))
+
+		policy := &networkingv1.NetworkPolicy{}
+		Expect(c.Get(ctx, policyKey, policy)).To(Succeed())
+		return policy
+	}
+
+	BeforeEach(func() {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"control-plane": "controller-manager"}}
+		c = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "debezium-operator", Namespace: policyKey.Namespace, UID: "deployment-uid"},
+				Spec:       appsv1.DeploymentSpec{Selector: selector},
+			},
+			&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+				Subsets: []corev1.EndpointSubset{{
+					Addresses: []corev1.EndpointAddress{{IP: "172.18.0.2"}},
+					Ports:     []corev1.EndpointPort{{Port: 6443}},
+				}},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "connect", Namespace: "kafka"},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": "connect"},
+					Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromString("rest")}},
+				},
+			},
+			connector("in-cluster", "http://connect.kafka.svc.cluster.local"),
+			connector("in-cluster-2", "http://connect.kafka.svc.cluster.local"),
+			connector("external", "https://connect.example.com:8443"),
+			connector("by-ip", "http://10.1.2.3:8083"),
+			connector("unresolvable", "http://missing.example.com:8083"),
+		).Build()
+	})
+
+	It("allows egress to each distinct Debezium host, DNS and the API server", func() {
+		policy := reconcilePolicy()
+
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"control-plane": "controller-manager"}))
+		Expect(policy.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeEgress}))
+		Expect(policy.OwnerReferences).To(HaveLen(1))
+		Expect(policy.OwnerReferences[0].Kind).To(Equal("Deployment"))
+		Expect(policy.OwnerReferences[0].Name).To(Equal("debezium-operator"))
+
+		rules := policy.Spec.Egress
+		Expect(rules).To(HaveLen(5))
+		Expect(rules[0].To[0].IPBlock.CIDR).To(Equal("172.18.0.2/32"))
+		Expect(rules[0].Ports[0].Port.IntValue()).To(Equal(6443))
+		Expect(rules[1].To).To(BeEmpty())
+		Expect(rules[1].Ports[0].Port.IntValue()).To(Equal(53))
+
+		// Hosts are sorted: by-ip, in-cluster, external; the unresolvable host is skipped.
+		Expect(rules[2].To[0].IPBlock.CIDR).To(Equal("10.1.2.3/32"))
+		Expect(rules[2].Ports[0].Port.IntValue()).To(Equal(8083))
+
+		Expect(rules[3].To[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{corev1.LabelMetadataName: "kafka"}))
+		Expect(rules[3].To[0].PodSelector.MatchLabels).To(Equal(map[string]string{"app": "connect"}))
+		Expect(*rules[3].Ports[0].Port).To(Equal(intstr.FromString("rest")))
+
+		Expect(rules[4].To).To(HaveLen(2))
+		Expect(rules[4].To[0].IPBlock.CIDR).To(Equal("192.0.2.10/32"))
+		Expect(rules[4].To[1].IPBlock.CIDR).To(Equal("2001:db8::10/128"))
+		Expect(rules[4].Ports[0].Port.IntValue()).To(Equal(8443))
+	})
+
+	It("drops hosts no longer referenced by any connector", func() {
+		reconcilePolicy()
+		for _, name := range []string{"in-cluster", "in-cluster-2", "by-ip", "unresolvable"} {
+			Expect(c.Delete(ctx, connector(name, ""))).To(Succeed())
+		}
+
+		policy := reconcilePolicy()
+		Expect(policy.Spec.Egress).To(HaveLen(3))
+		Expect(policy.Spec.Egress[2].To[0].IPBlock.CIDR).To(Equal("192.0.2.10/32"))
+	})
+
+	It("allows egress to the operator's other endpoints and the schema registries", func() {
+		registry := connector("avro", "http://10.1.2.3:8083")
+		registry.Spec.Config["value.converter.schema.registry.url"] = "http://10.1.2.5:8081, ${env:DBZ_REGISTRY}"
+		Expect(c.Create(ctx, registry)).To(Succeed())
+
+		policy := reconcilePolicy(func(r *NetworkPolicyReconciler) {
+			r.ExtraHosts = []string{"10.1.2.4:9092", "https://connect.example.com:8200"}
+			r.SchemaRegistries = true
+		})
+		var endpoints []string
+		for _, rule := range policy.Spec.Egress[2:] {
+			for _, peer := range rule.To {
+				if peer.IPBlock != nil {
+					endpoints = append(endpoints, fmt.Sprintf("%s:%d", peer.IPBlock.CIDR, rule.Ports[0].Port.IntValue()))
+				}
+			}
+		}
+		Expect(endpoints).To(ContainElements("10.1.2.4/32:9092", "10.1.2.5/32:8081", "192.0.2.10/32:8200"))
+	})
+
+	It("allows egress to the validation hosts of connectors", func() {
+		validated := connector("validated", "http://10.1.2.3:8083")
+		validated.Spec.ValidationHost = "http://10.1.2.8:8083"
+		Expect(c.Create(ctx, validated)).To(Succeed())
+
+		var cidrs []string
+		for _, rule := range reconcilePolicy().Spec.Egress[2:] {
+			for _, peer := range rule.To {
+				if peer.IPBlock != nil {
+					cidrs = append(cidrs, peer.IPBlock.CIDR)
+				}
+			}
+		}
+		Expect(cidrs).To(ContainElement("10.1.2.8/32"))
+	})
+
+	It("allows egress to the hosts of DebeziumConnectorSets", func() {
+		Expect(c.Create(ctx, &apiv1alpha1.DebeziumConnectorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "default"},
+			Spec: apiv1alpha1.DebeziumConnectorSetSpec{
+				DebeziumHost: "http://10.1.2.6:8083",
+				Connectors: []apiv1alpha1.NamedConnectorSpec{
+					{Name: "inventory"},
+					{Name: "orders", DebeziumHost: "http://10.1.2.7:8083"},
+				},
+			},
+		})).To(Succeed())
+
+		var cidrs []string
+		for _, rule := range reconcilePolicy().Spec.Egress[2:] {
+			for _, peer := range rule.To {
+				if peer.IPBlock != nil {
+					cidrs = append(cidrs, peer.IPBlock.CIDR)
+				}
+			}
+		}
+		Expect(cidrs).To(ContainElements("10.1.2.6/32", "10.1.2.7/32"))
+	})
+
+	It("parses Debezium host URLs", func() {
+		for host, expected := range map[string]struct {
+			hostname string
+			port     int32
+		}{
+			"http://connect:8083":         {"connect", 8083},
+			"https://connect.example.com": {"connect.example.com", 443},
+			"debezium.local":              {"debezium.local", 80},
+			"http://[2001:db8::1]:8083":   {"2001:db8::1", 8083},
+		} {
+			hostname, port, err := parseHostPort(host)
+			Expect(err).NotTo(HaveOccurred(), host)
+			Expect(hostname).To(Equal(expected.hostname), host)
+			Expect(port).To(Equal(expected.port), host)
+		}
+
+		name, namespace, ok := serviceHost("connect.kafka.svc")
+		Expect(ok).To(BeTrue())
+		Expect([]string{name, namespace}).To(Equal([]string{"connect", "kafka"}))
+		_, _, ok = serviceHost("connect.example.com")
+		Expect(ok).To(BeFalse())
+	})
+})
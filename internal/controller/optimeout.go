@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+const (
+	// defaultOperationTimeout bounds connector create and update calls when OperationTimeout is unset.
+	defaultOperationTimeout = 30 * time.Second
+	// operationTimeoutRequeue is the delay before retrying a timed-out operation when the connector
+	// has no retry interval.
+	operationTimeoutRequeue = 15 * time.Second
+)
+
+// operationTimeoutError reports a connector create or update that did not complete in time, as
+// opposed to one that failed outright.
+type operationTimeoutError struct {
+	// conditionType is the condition reporting the timeout.
+	conditionType string
+	operation     string
+	timeout       time.Duration
+	elapsed       time.Duration
+}
+
+func (e *operationTimeoutError) Error() string {
+	return fmt.Sprintf("connector %s did not complete within %s (elapsed %s)", e.operation, e.timeout, e.elapsed.Round(time.Millisecond))
+}
+
+// operationTimeout returns the deadline for connector create and update calls.
+func (r *DebeziumConnectorReconciler) operationTimeout() time.Duration {
+	if r.OperationTimeout > 0 {
+		return r.OperationTimeout
+	}
+	return defaultOperationTimeout
+}
+
+// asOperationTimeout converts err into an *operationTimeoutError when it was caused by the
+// operation's deadline or a network timeout, and returns err unchanged otherwise.
+func (r *DebeziumConnectorReconciler) asOperationTimeout(err error, conditionType, operation string, start time.Time) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &operationTimeoutError{
+			conditionType: conditionType,
+			operation:     operation,
+			timeout:       r.operationTimeout(),
+			elapsed:       time.Since(start),
+		}
+	}
+	return err
+}
+
+// recordOperationTimeout sets the condition for a timed-out create or update.
+func recordOperationTimeout(dbc *apiv1alpha1.DebeziumConnector, err *operationTimeoutError) {
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               err.conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DeadlineExceeded",
+		Message:            err.Error(),
+		ObservedGeneration: dbc.Generation,
+	})
+}
+
+// clearOperationTimeouts removes the conditions of timed-out creates and updates once the
+// connector exists with the desired config, including when a timed-out operation completed on
+// the Debezium host after the operator gave up on it.
+func clearOperationTimeouts(dbc *apiv1alpha1.DebeziumConnector) {
+	meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)
+	meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionUpdateTimedOut)
+}
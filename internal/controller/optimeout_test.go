@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector operation timeouts", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		server *httptest.Server
+		hang   atomic.Bool
+	)
+
+	BeforeEach(func() {
+		hang.Store(true)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && hang.Load():
+				// A slow source database keeps Connect from answering the create.
+				select {
+				case <-time.After(300 * time.Millisecond):
+				case <-r.Context().Done():
+				}
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reports a timed-out create in a condition and requeues", func() {
+		dbc := newTestDebeziumConnector(server.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:       server.Client(),
+			OperationTimeout: 50 * time.Millisecond,
+		}
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(operationTimeoutRequeue))
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("DeadlineExceeded"))
+		Expect(cond.Message).To(ContainSubstring("connector create did not complete within 50ms"))
+
+		// The condition is cleared once the create succeeds.
+		hang.Store(false)
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)).To(BeNil())
+	})
+
+	It("accepts a create whose response was lost after Connect applied it", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPost && req.URL.Path == "/connectors" {
+				// Connect creates the connector but answers only after the operator gave up.
+				connect.serve(httptest.NewRecorder(), req)
+				<-req.Context().Done()
+				return
+			}
+			connect.serve(w, req)
+		}))
+		defer gateway.Close()
+		dbc := newTestDebeziumConnector(gateway.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:       gateway.Client(),
+			OperationTimeout: 50 * time.Millisecond,
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)).To(BeNil())
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+	})
+
+	It("clears the conditions once timed-out operations completed on the host", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if (req.Method == http.MethodPost && req.URL.Path == "/connectors") || (req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/config")) {
+				// Connect is still working on the operation when the operator gives up.
+				_, _ = io.Copy(io.Discard, req.Body)
+				<-req.Context().Done()
+				return
+			}
+			connect.serve(w, req)
+		}))
+		defer gateway.Close()
+		dbc := newTestDebeziumConnector(gateway.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:       gateway.Client(),
+			OperationTimeout: 50 * time.Millisecond,
+		}
+		latest := &apiv1alpha1.DebeziumConnector{}
+		reconcileOnce := func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+		}
+
+		reconcileOnce()
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)).To(BeTrue())
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		reconcileOnce()
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)).To(BeNil())
+
+		latest.Spec.Config["tasks.max"] = "2"
+		latest.Generation++
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		reconcileOnce()
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionUpdateTimedOut)).To(BeTrue())
+
+		connect.setConnector(latest.Spec.Config, "RUNNING")
+		reconcileOnce()
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionCreateTimedOut)).To(BeNil())
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUpdateTimedOut)).To(BeNil())
+	})
+
+	It("returns connection failures as errors", func() {
+		r := &DebeziumConnectorReconciler{HTTPClient: http.DefaultClient, OperationTimeout: time.Second}
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		err := r.createDebeziumConnector(ctx, closed.URL, map[string]string{"name": "inventory"})
+		Expect(err).To(HaveOccurred())
+		_, isTimeout := err.(*operationTimeoutError)
+		Expect(isTimeout).To(BeFalse())
+	})
+})
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// OrphanDetector periodically looks for connectors on the Debezium hosts referenced by
+// DebeziumConnectors that have no backing resource. Orphans are reported through logs and
+// metrics, and are only deleted when Prune is set.
+type OrphanDetector struct {
+	// Reconciler provides the Kubernetes and Debezium clients.
+	Reconciler *DebeziumConnectorReconciler
+	// Interval is the time between two detection runs.
+	Interval time.Duration
+	// Prune deletes detected orphans instead of only reporting them.
+	Prune bool
+	// NamePrefix restricts detection to connectors whose names start with the prefix, so
+	// connectors managed by other tools are left alone.
+	NamePrefix string
+}
+
+// NeedLeaderElection makes sure only the leader prunes connectors.
+func (d *OrphanDetector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs orphan detection every Interval until ctx is cancelled.
+func (d *OrphanDetector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.detect(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "orphan detection failed")
+			}
+		}
+	}
+}
+
+// detect runs a single detection pass over all managed Debezium hosts.
+func (d *OrphanDetector) detect(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphans")
+	d.Reconciler.settingsMu.RLock()
+	defer d.Reconciler.settingsMu.RUnlock()
+
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := d.Reconciler.List(ctx, list); err != nil {
+		return err
+	}
+	// Connectors of DebeziumConnectorSets have no DebeziumConnector but are managed as well.
+	setMembers, err := connectorSetMembers(ctx, d.Reconciler)
+	if err != nil {
+		return err
+	}
+	managed := map[string]map[string]bool{}
+	// Hosts with a connector whose name cannot be resolved are never pruned, since that
+	// connector would otherwise look orphaned.
+	unresolved := map[string]bool{}
+	for i := range list.Items {
+		dbc := &list.Items[i]
+		host, err := d.Reconciler.connectorHost(ctx, dbc)
+		if err != nil {
+			return err
+		}
+		// A connector without a host was never created.
+		if host == "" {
+			continue
+		}
+		if managed[host] == nil {
+			managed[host] = map[string]bool{}
+		}
+		name := d.Reconciler.connectorName(ctx, dbc)
+		if name == "" {
+			unresolved[host] = true
+			continue
+		}
+		managed[host][name] = true
+	}
+
+	// Hosts no connector runs on anymore, or that could not be listed, drop out of the gauge.
+	orphansByHost := map[string]int{}
+	for host, names := range managed {
+		connectors, err := d.Reconciler.listConnectors(ctx, host)
+		if err != nil {
+			logger.Error(err, "failed to list connectors", "host", host)
+			continue
+		}
+		orphans := 0
+		for _, connector := range connectors {
+			if names[connector.Name] || setMembers[strings.TrimSuffix(host, "/")][connector.Name] != nil || !strings.HasPrefix(connector.Name, d.NamePrefix) {
+				continue
+			}
+			orphans++
+			if !d.Prune || unresolved[host] {
+				logger.Info("Found orphaned connector", "host", host, "name", connector.Name, "state", connector.State)
+				continue
+			}
+			if err := d.Reconciler.deleteDebeziumConnector(ctx, host, connector.Name); err != nil {
+				logger.Error(err, "failed to prune orphaned connector", "host", host, "name", connector.Name)
+				continue
+			}
+			logger.Info("Pruned orphaned connector", "host", host, "name", connector.Name)
+			prunedOrphansTotal.WithLabelValues(host).Inc()
+		}
+		orphansByHost[host] = orphans
+	}
+	orphanedConnectors.Reset()
+	for host, orphans := range orphansByHost {
+		orphanedConnectors.WithLabelValues(host).Set(float64(orphans))
+	}
+	return nil
+}
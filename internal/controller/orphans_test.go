@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Orphan detection", func() {
+	ctx := context.Background()
+
+	var (
+		server  *httptest.Server
+		mu      sync.Mutex
+		deleted []string
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		deleted = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{
+					"team-inventory": {"status": {"connector": {"state": "RUNNING"}}},
+					"team-leftover": {"status": {"connector": {"state": "FAILED"}}},
+					"other-tool": {"status": {"connector": {"state": "RUNNING"}}}
+				}`))
+			case http.MethodDelete:
+				mu.Lock()
+				deleted = append(deleted, req.URL.Path)
+				mu.Unlock()
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		dbc := &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+			Spec: apiv1alpha1.DebeziumConnectorSpec{
+				DebeziumHost: server.URL,
+				Config:       map[string]string{"name": "team-inventory", "connector.class": "io.debezium.connector.mysql.MySqlConnector"},
+			},
+		}
+		r = &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).Build(),
+			HTTPClient: server.Client(),
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("only reports orphans in dry-run mode", func() {
+		d := &OrphanDetector{Reconciler: r, NamePrefix: "team-"}
+		Expect(d.detect(ctx)).To(Succeed())
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("prunes only unmanaged connectors matching the prefix", func() {
+		d := &OrphanDetector{Reconciler: r, NamePrefix: "team-", Prune: true}
+		Expect(d.detect(ctx)).To(Succeed())
+		Expect(deleted).To(ConsistOf("/connectors/team-leftover"))
+	})
+
+	It("does not prune the connectors of a DebeziumConnectorSet", func() {
+		set := &apiv1alpha1.DebeziumConnectorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "leftovers", Namespace: "default"},
+			Spec: apiv1alpha1.DebeziumConnectorSetSpec{
+				DebeziumHost: server.URL + "/",
+				Connectors:   []apiv1alpha1.NamedConnectorSpec{{Name: "team-leftover"}},
+			},
+		}
+		Expect(r.Create(ctx, set)).To(Succeed())
+
+		d := &OrphanDetector{Reconciler: r, NamePrefix: "team-", Prune: true}
+		Expect(d.detect(ctx)).To(Succeed())
+		Expect(deleted).To(BeEmpty())
+		Expect(testutil.ToFloat64(orphanedConnectors.WithLabelValues(server.URL))).To(BeZero())
+	})
+
+	It("drops hosts without connectors from the orphan gauge", func() {
+		d := &OrphanDetector{Reconciler: r, NamePrefix: "team-"}
+		Expect(d.detect(ctx)).To(Succeed())
+		Expect(testutil.ToFloat64(orphanedConnectors.WithLabelValues(server.URL))).To(Equal(1.0))
+
+		dbc := &apiv1alpha1.DebeziumConnector{ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"}}
+		Expect(r.Delete(ctx, dbc)).To(Succeed())
+		Expect(d.detect(ctx)).To(Succeed())
+		Expect(testutil.CollectAndCount(orphanedConnectors)).To(BeZero())
+	})
+})
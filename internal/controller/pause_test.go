@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Reconcile pausing", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcilePaused := func(dbc *apiv1alpha1.DebeziumConnector, pauseAll bool) *apiv1alpha1.DebeziumConnector {
+		r := &DebeziumConnectorReconciler{
+			Client:              fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:          connect.Client(),
+			PauseReconciliation: pauseAll,
+		}
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("skips mutations for a resource with the paused annotation", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{apiv1alpha1.ReconcilePausedAnnotation: "true"}
+
+		latest := reconcilePaused(dbc, false)
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionPaused)).To(BeTrue())
+	})
+
+	It("skips mutations for all resources when paused globally", func() {
+		latest := reconcilePaused(newTestDebeziumConnector(connect.URL), true)
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionPaused)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("PausedByOperator"))
+	})
+
+	It("resumes mutations once the annotation is removed", func() {
+		latest := reconcilePaused(newTestDebeziumConnector(connect.URL), false)
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors"))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionPaused)).To(BeTrue())
+	})
+})
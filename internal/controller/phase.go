@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// featureOffsetsRead is GET /connectors/{name}/offsets (KIP-875).
+var featureOffsetsRead = connectFeature{name: "offsets read", major: 3, minor: 5, unsupportedReason: "OffsetsReadUnsupported"}
+
+// connectorOffset is a source partition and its committed offset.
+type connectorOffset struct {
+	Partition map[string]interface{} `json:"partition"`
+	Offset    map[string]interface{} `json:"offset"`
+}
+
+// getConnectorOffsets sends a GET request to retrieve the committed offsets of a connector.
+func (r *DebeziumConnectorReconciler) getConnectorOffsets(ctx context.Context, host, name string) ([]connectorOffset, error) {
+	resp, err := r.get(ctx, r.connectURL(host, "/connectors/%s/offsets", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET connector offsets: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GET connector offsets returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	var offsets struct {
+		Offsets []connectorOffset `json:"offsets"`
+	}
+	// Keep numbers as they are: LSNs and SCNs exceed the precision of a float64.
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&offsets); err != nil {
+		return nil, fmt.Errorf("failed to decode connector offsets: %w", err)
+	}
+	return offsets.Offsets, nil
+}
+
+// isSnapshotOffset reports whether a Debezium source offset was committed during the initial
+// snapshot. Relational connectors flag snapshot offsets with "snapshot" until the last snapshot
+// record; MongoDB uses "initsync".
+func isSnapshotOffset(offset map[string]interface{}) bool {
+	truthy := func(key string) bool {
+		switch v := offset[key].(type) {
+		case bool:
+			return v
+		case string:
+			return v == "true"
+		}
+		return false
+	}
+	if truthy("initsync") {
+		return true
+	}
+	return truthy("snapshot") && !truthy("last_snapshot_record") && !truthy("snapshot_completed")
+}
+
+// connectorPhase derives whether the connector is snapshotting or streaming from its state and
+// committed offsets, and returns the source position of the offsets. When the phase cannot be
+// derived, e.g. because Connect does not expose offsets or none were committed yet, the generic
+// connector state and an empty position are returned.
+func (r *DebeziumConnectorReconciler) connectorPhase(ctx context.Context, host, name, state string) (phase, position string) {
+	switch state {
+	case "RUNNING", "PAUSED", "STOPPED":
+	default:
+		return state, ""
+	}
+	var offsets []connectorOffset
+	if r.supportsFeature(ctx, host, featureOffsetsRead) == nil {
+		offsets, _ = r.getConnectorOffsets(ctx, host, name)
+	}
+	position = sourcePosition(offsets)
+	if state != "RUNNING" {
+		return apiv1alpha1.PhaseStopped, position
+	}
+	if len(offsets) == 0 {
+		return state, position
+	}
+	for _, o := range offsets {
+		if isSnapshotOffset(o.Offset) {
+			return apiv1alpha1.PhaseSnapshotting, position
+		}
+	}
+	return apiv1alpha1.PhaseStreaming, position
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector phase", func() {
+	ctx := context.Background()
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		connect.setConnector(map[string]string{"name": "inventory"}, "RUNNING")
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	phase := func(state string) string {
+		r := &DebeziumConnectorReconciler{HTTPClient: connect.Client()}
+		phase, _ := r.connectorPhase(ctx, connect.URL, "inventory", state)
+		return phase
+	}
+
+	setOffset := func(offset map[string]interface{}) {
+		connect.offsets["inventory"] = []connectorOffset{{Partition: map[string]interface{}{"server": "inventory"}, Offset: offset}}
+	}
+
+	It("reports snapshotting while snapshot offsets are committed", func() {
+		setOffset(map[string]interface{}{"file": "binlog.000003", "pos": 154, "snapshot": true})
+		Expect(phase("RUNNING")).To(Equal(apiv1alpha1.PhaseSnapshotting))
+	})
+
+	It("reports streaming once the snapshot completed", func() {
+		setOffset(map[string]interface{}{"lsn": 2345, "snapshot": true, "last_snapshot_record": true})
+		Expect(phase("RUNNING")).To(Equal(apiv1alpha1.PhaseStreaming))
+		setOffset(map[string]interface{}{"file": "binlog.000003", "pos": 1024})
+		Expect(phase("RUNNING")).To(Equal(apiv1alpha1.PhaseStreaming))
+	})
+
+	It("reports stopped for paused and stopped connectors", func() {
+		Expect(phase("PAUSED")).To(Equal(apiv1alpha1.PhaseStopped))
+		Expect(phase("STOPPED")).To(Equal(apiv1alpha1.PhaseStopped))
+	})
+
+	It("falls back to the connector state when the phase cannot be derived", func() {
+		Expect(phase("RUNNING")).To(Equal("RUNNING"), "no offsets committed yet")
+		Expect(phase("FAILED")).To(Equal("FAILED"))
+
+		connect.version = "3.4.0"
+		setOffset(map[string]interface{}{"snapshot": true})
+		Expect(phase("RUNNING")).To(Equal("RUNNING"), "offsets API unavailable")
+	})
+
+	It("records the phase in the status", func() {
+		setOffset(map[string]interface{}{"snapshot": "true"})
+		dbc := newTestDebeziumConnector(connect.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.Phase).To(Equal(apiv1alpha1.PhaseSnapshotting))
+		Expect(latest.Status.SourcePosition).To(BeEmpty())
+
+		setOffset(map[string]interface{}{"lsn": 97390608456, "txId": 1043})
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.SourcePosition).To(Equal("lsn=16/ACEEC048"))
+	})
+})
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// previewRequested reports whether the PreviewAnnotation asks for a preview instead of a reconcile
+// that applies the config.
+func previewRequested(dbc *apiv1alpha1.DebeziumConnector) bool {
+	return dbc.Annotations[apiv1alpha1.PreviewAnnotation] == "true"
+}
+
+// diffConfig returns the changes that replace current with desired, in key order. Values of
+// listKeys are compared as lists, and sensitive values are masked.
+func diffConfig(current, desired map[string]string, listKeys map[string]util.ListNormalization) []apiv1alpha1.ConfigChange {
+	normalizedCurrent := util.NormalizeConfig(current, listKeys)
+	normalizedDesired := util.NormalizeConfig(desired, listKeys)
+	maskedCurrent := util.MaskSensitiveConfig(current)
+	maskedDesired := util.MaskSensitiveConfig(desired)
+
+	var changes []apiv1alpha1.ConfigChange
+	for k, v := range normalizedDesired {
+		cur, ok := normalizedCurrent[k]
+		switch {
+		case !ok:
+			changes = append(changes, apiv1alpha1.ConfigChange{Key: k, Operation: apiv1alpha1.ConfigChangeAdd, Desired: maskedDesired[k]})
+		case cur != v:
+			changes = append(changes, apiv1alpha1.ConfigChange{Key: k, Operation: apiv1alpha1.ConfigChangeUpdate, Current: maskedCurrent[k], Desired: maskedDesired[k]})
+		}
+	}
+	// Connect replaces the whole config on update, so keys missing from desired are removed.
+	for k := range normalizedCurrent {
+		if _, ok := normalizedDesired[k]; !ok {
+			changes = append(changes, apiv1alpha1.ConfigChange{Key: k, Operation: apiv1alpha1.ConfigChangeRemove, Current: maskedCurrent[k]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// recordConfigPreview compares the connector config on the Debezium host with config and records
+// the changes a reconcile would make in Status.ConfigPreview. Nothing is changed on the host.
+func (r *DebeziumConnectorReconciler) recordConfigPreview(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	host, name := dbc.Spec.DebeziumHost, config["name"]
+	exists, err := r.connectorExists(ctx, host, name)
+	if err != nil {
+		return err
+	}
+	var current map[string]string
+	if exists {
+		if current, err = r.getDebeziumConnectorConfig(ctx, host, name); err != nil {
+			return err
+		}
+	}
+
+	preview := &apiv1alpha1.ConfigPreview{
+		ObservedGeneration: dbc.Generation,
+		Time:               metav1.Now(),
+		DebeziumHost:       host,
+		Changes:            diffConfig(r.normalizeValues(current), config, r.ListValuedKeys),
+	}
+	switch {
+	case !exists:
+		preview.Action = apiv1alpha1.PreviewActionCreate
+	case len(preview.Changes) > 0:
+		preview.Action = apiv1alpha1.PreviewActionUpdate
+	default:
+		preview.Action = apiv1alpha1.PreviewActionNone
+	}
+	traceDecision(ctx, "previewing connector %s: %s with %d changes", name, preview.Action, len(preview.Changes))
+	dbc.Status.ConfigPreview = preview
+	return nil
+}
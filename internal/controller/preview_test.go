@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("Config preview", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	It("diffs the current config against the desired config", func() {
+		current := map[string]string{"name": "inventory", "snapshot.mode": "initial", "database.password": "old", "stray": "x"}
+		desired := map[string]string{"name": "inventory", "snapshot.mode": "never", "database.password": "new", "tasks.max": "1"}
+
+		Expect(diffConfig(current, desired, nil)).To(Equal([]apiv1alpha1.ConfigChange{
+			{Key: "database.password", Operation: apiv1alpha1.ConfigChangeUpdate, Current: "******", Desired: "******"},
+			{Key: "snapshot.mode", Operation: apiv1alpha1.ConfigChangeUpdate, Current: "initial", Desired: "never"},
+			{Key: "stray", Operation: apiv1alpha1.ConfigChangeRemove, Current: "x"},
+			{Key: "tasks.max", Operation: apiv1alpha1.ConfigChangeAdd, Desired: "1"},
+		}))
+	})
+
+	It("compares list values as lists", func() {
+		current := map[string]string{"table.include.list": "inv.b, inv.a"}
+		desired := map[string]string{"table.include.list": "inv.a,inv.b"}
+		Expect(diffConfig(current, desired, util.DefaultListValuedKeys)).To(BeEmpty())
+		Expect(diffConfig(current, desired, nil)).To(HaveLen(1))
+	})
+
+	Context("when reconciling", func() {
+		var connect *fakeConnect
+
+		BeforeEach(func() {
+			connect = newFakeConnect()
+		})
+
+		AfterEach(func() {
+			connect.Close()
+		})
+
+		previewOnce := func(dbc *apiv1alpha1.DebeziumConnector) (*DebeziumConnectorReconciler, *apiv1alpha1.DebeziumConnector) {
+			dbc.Annotations = map[string]string{apiv1alpha1.PreviewAnnotation: "true"}
+			r := &DebeziumConnectorReconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+				HTTPClient: connect.Client(),
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			latest := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			return r, latest
+		}
+
+		It("previews creating a missing connector without creating it", func() {
+			_, latest := previewOnce(newTestDebeziumConnector(connect.URL))
+
+			Expect(connect.mutations()).To(BeEmpty())
+			Expect(latest.Status.ConfigPreview).NotTo(BeNil())
+			Expect(latest.Status.ConfigPreview.Action).To(Equal(apiv1alpha1.PreviewActionCreate))
+			Expect(latest.Status.ConfigPreview.Changes).To(HaveLen(len(latest.Spec.Config)))
+		})
+
+		It("previews an update and applies it once the annotation is removed", func() {
+			dbc := newTestDebeziumConnector(connect.URL)
+			drifted := map[string]string{}
+			for k, v := range dbc.Spec.Config {
+				drifted[k] = v
+			}
+			drifted["snapshot.mode"] = "never"
+			connect.setConnector(drifted, "RUNNING")
+
+			r, latest := previewOnce(dbc)
+			Expect(connect.mutations()).To(BeEmpty())
+			Expect(latest.Status.ConfigPreview.Action).To(Equal(apiv1alpha1.PreviewActionUpdate))
+			Expect(latest.Status.ConfigPreview.Changes).To(Equal([]apiv1alpha1.ConfigChange{
+				{Key: "snapshot.mode", Operation: apiv1alpha1.ConfigChangeRemove, Current: "never"},
+			}))
+
+			delete(latest.Annotations, apiv1alpha1.PreviewAnnotation)
+			Expect(r.Update(ctx, latest)).To(Succeed())
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			Expect(latest.Status.ConfigPreview).To(BeNil())
+		})
+
+		It("reports an up-to-date connector", func() {
+			dbc := newTestDebeziumConnector(connect.URL)
+			connect.setConnector(dbc.Spec.Config, "RUNNING")
+
+			_, latest := previewOnce(dbc)
+			Expect(latest.Status.ConfigPreview.Action).To(Equal(apiv1alpha1.PreviewActionNone))
+			Expect(latest.Status.ConfigPreview.Changes).To(BeEmpty())
+		})
+	})
+})
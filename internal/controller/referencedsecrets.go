@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultSecretSyncTimeout bounds the wait for the informer of a newly referenced Secret.
+const defaultSecretSyncTimeout = 10 * time.Second
+
+// defaultSecretSweepInterval is how often Secrets no connector references anymore are forgotten.
+const defaultSecretSweepInterval = 5 * time.Minute
+
+// ReferencedSecretCache reads the Secrets DebeziumConnectors reference through ConfigSecretRef.
+// Each Secret is watched by its own informer, restricted by a metadata.name field selector, and
+// only once a connector first reads it. Kubernetes authorizes list and watch requests for a
+// single name against the resourceNames of a Role, so the operator needs access to just these
+// Secrets instead of to all Secrets of the cluster. Changes of a watched Secret are sent on
+// Events.
+type ReferencedSecretCache struct {
+	// Clientset lists and watches the referenced Secrets.
+	Clientset kubernetes.Interface
+	// SyncTimeout bounds the wait for the first list of a newly referenced Secret;
+	// defaultSecretSyncTimeout when zero.
+	SyncTimeout time.Duration
+	// Referenced reports whether a connector still references the Secret key. Every
+	// SweepInterval, watched Secrets it reports as unreferenced are forgotten, so a Secret a
+	// connector stopped referencing is not watched until its next change. Secrets are only
+	// forgotten on their own changes when nil.
+	Referenced func(ctx context.Context, key types.NamespacedName) (bool, error)
+	// SweepInterval is how often unreferenced Secrets are forgotten; defaultSecretSweepInterval
+	// when zero.
+	SweepInterval time.Duration
+
+	mu      sync.Mutex
+	ctx     context.Context
+	secrets map[types.NamespacedName]*referencedSecret
+	events  chan event.GenericEvent
+}
+
+// referencedSecret is the informer of a single Secret.
+type referencedSecret struct {
+	informer toolscache.SharedIndexInformer
+	stop     chan struct{}
+}
+
+// ensure ReferencedSecretCache reads Secrets like the manager's client.
+var _ client.Reader = &ReferencedSecretCache{}
+
+// NewReferencedSecretCache returns a cache reading Secrets through clientset.
+func NewReferencedSecretCache(clientset kubernetes.Interface) *ReferencedSecretCache {
+	return &ReferencedSecretCache{
+		Clientset: clientset,
+		secrets:   map[types.NamespacedName]*referencedSecret{},
+		events:    make(chan event.GenericEvent, 100),
+	}
+}
+
+// Events receives a Secret whenever a watched Secret is added, changed or deleted.
+func (c *ReferencedSecretCache) Events() <-chan event.GenericEvent {
+	return c.events
+}
+
+// Start implements manager.Runnable. It forgets unreferenced Secrets every SweepInterval and
+// stops the informers once ctx is done.
+func (c *ReferencedSecretCache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
+	interval := c.SweepInterval
+	if interval <= 0 {
+		interval = defaultSecretSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for done := false; !done; {
+		select {
+		case <-ticker.C:
+			c.sweep(ctx)
+		case <-ctx.Done():
+			done = true
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, s := range c.secrets {
+		close(s.stop)
+		delete(c.secrets, key)
+	}
+	return nil
+}
+
+// Get implements client.Reader for Secrets. The first Get of a Secret starts watching it and
+// waits until it was listed.
+func (c *ReferencedSecretCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return fmt.Errorf("referenced secret cache cannot read %T", obj)
+	}
+	s := c.watch(key)
+	timeout := c.SyncTimeout
+	if timeout <= 0 {
+		timeout = defaultSecretSyncTimeout
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if !toolscache.WaitForCacheSync(syncCtx.Done(), s.informer.HasSynced) {
+		return fmt.Errorf("timed out listing Secret %s; check that the operator may get, list and watch it", key)
+	}
+	item, exists, err := s.informer.GetStore().GetByKey(key.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+	}
+	item.(*corev1.Secret).DeepCopyInto(secret)
+	return nil
+}
+
+// List implements client.Reader. Listing is not supported, since only single Secrets are watched.
+func (c *ReferencedSecretCache) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return fmt.Errorf("referenced secret cache cannot list")
+}
+
+// Forget stops watching the Secret key, e.g. once no connector references it anymore.
+func (c *ReferencedSecretCache) Forget(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.secrets[key]; ok {
+		close(s.stop)
+		delete(c.secrets, key)
+	}
+}
+
+// sweep forgets the watched Secrets that Referenced reports as no longer referenced.
+func (c *ReferencedSecretCache) sweep(ctx context.Context) {
+	if c.Referenced == nil {
+		return
+	}
+	c.mu.Lock()
+	keys := make([]types.NamespacedName, 0, len(c.secrets))
+	for key := range c.secrets {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+	for _, key := range keys {
+		referenced, err := c.Referenced(ctx, key)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to check whether Secret is still referenced", "secret", key)
+			continue
+		}
+		if !referenced {
+			c.Forget(key)
+		}
+	}
+}
+
+// watching reports whether the Secret key is watched.
+func (c *ReferencedSecretCache) watching(key types.NamespacedName) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.secrets[key]
+	return ok
+}
+
+// watch returns the informer of the Secret key, starting it if needed.
+func (c *ReferencedSecretCache) watch(key types.NamespacedName) *referencedSecret {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.secrets[key]; ok {
+		return s
+	}
+	if c.secrets == nil {
+		c.secrets = map[types.NamespacedName]*referencedSecret{}
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	selector := fields.OneTermEqualSelector("metadata.name", key.Name).String()
+	secrets := c.Clientset.CoreV1().Secrets(key.Namespace)
+	informer := toolscache.NewSharedIndexInformer(&toolscache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return secrets.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return secrets.Watch(ctx, options)
+		},
+	}, &corev1.Secret{}, 0, toolscache.Indexers{})
+	notify := func(obj interface{}) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if secret, ok := obj.(*corev1.Secret); ok && secret.Name == key.Name {
+			select {
+			case c.events <- event.GenericEvent{Object: secret}:
+			default:
+				log.FromContext(ctx).Info("Dropping change of referenced Secret; event queue is full", "secret", key)
+			}
+		}
+	}
+	_, _ = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, obj interface{}) { notify(obj) },
+		DeleteFunc: notify,
+	})
+	s := &referencedSecret{informer: informer, stop: make(chan struct{})}
+	c.secrets[key] = s
+	go informer.Run(s.stop)
+	return s
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Referenced Secret cache", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory-config", Namespace: "default"}
+
+	var (
+		clientset *clientsetfake.Clientset
+		c         *ReferencedSecretCache
+		stop      context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		clientset = clientsetfake.NewSimpleClientset(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: "default"},
+				Data:       map[string][]byte{"database.password": []byte("secret")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("other")},
+			},
+		)
+		c = NewReferencedSecretCache(clientset)
+		c.SyncTimeout = 5 * time.Second
+		var runCtx context.Context
+		runCtx, stop = context.WithCancel(ctx)
+		go func(c *ReferencedSecretCache) { _ = c.Start(runCtx) }(c)
+	})
+
+	AfterEach(func() {
+		stop()
+	})
+
+	It("lists and watches each referenced Secret by name only", func() {
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, key, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("database.password", []byte("secret")))
+		Expect(c.watching(key)).To(BeTrue())
+		Expect(c.watching(types.NamespacedName{Name: "unrelated", Namespace: "default"})).To(BeFalse())
+
+		Eventually(func() []string {
+			var verbs []string
+			for _, action := range clientset.Actions() {
+				verbs = append(verbs, action.GetVerb())
+			}
+			return verbs
+		}).Should(ContainElements("list", "watch"))
+		for _, action := range clientset.Actions() {
+			Expect(action.GetNamespace()).To(Equal("default"))
+			switch a := action.(type) {
+			case k8stesting.ListAction:
+				Expect(a.GetListRestrictions().Fields.String()).To(Equal("metadata.name=inventory-config"))
+			case k8stesting.WatchAction:
+				Expect(a.GetWatchRestrictions().Fields.String()).To(Equal("metadata.name=inventory-config"))
+			}
+		}
+	})
+
+	It("reports a missing Secret as not found", func() {
+		err := c.Get(ctx, types.NamespacedName{Name: "missing", Namespace: "default"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("reads only Secrets", func() {
+		Expect(c.Get(ctx, key, &corev1.ConfigMap{})).NotTo(Succeed())
+		Expect(c.List(ctx, &corev1.SecretList{})).NotTo(Succeed())
+	})
+
+	It("sends changes of a watched Secret and serves the new data", func() {
+		Expect(c.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+		Eventually(c.Events()).Should(Receive())
+
+		updated := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: "default"},
+			Data:       map[string][]byte{"database.password": []byte("rotated")},
+		}
+		_, err := clientset.CoreV1().Secrets("default").Update(ctx, updated, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(c.Events()).Should(Receive())
+		Eventually(func() []byte {
+			secret := &corev1.Secret{}
+			Expect(c.Get(ctx, key, secret)).To(Succeed())
+			return secret.Data["database.password"]
+		}).Should(Equal([]byte("rotated")))
+	})
+
+	It("stops watching a forgotten Secret", func() {
+		Expect(c.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+		c.Forget(key)
+		Expect(c.watching(key)).To(BeFalse())
+	})
+
+	It("forgets Secrets no connector references anymore", func() {
+		dbc := newTestDebeziumConnector("http://unused")
+		dbc.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: "inventory-config"}
+		r := &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).
+				WithIndex(&apiv1alpha1.DebeziumConnector{}, configSecretRefField, indexConfigSecretRef).Build(),
+		}
+		c.Referenced = r.secretReferenced
+		Expect(c.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+
+		c.sweep(ctx)
+		Expect(c.watching(key)).To(BeTrue())
+
+		dbc.Spec.ConfigSecretRef = nil
+		Expect(r.Update(ctx, dbc)).To(Succeed())
+		c.sweep(ctx)
+		Expect(c.watching(key)).To(BeFalse())
+	})
+
+	It("resolves the config Secret of a connector through the cache", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: "inventory-config"}
+		r := &DebeziumConnectorReconciler{
+			// The manager's client has no Secrets; they are read through the cache only.
+			Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:  connect.Client(),
+			SecretCache: c,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "inventory", Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("database.password", "secret"))
+
+		// The Secret is no longer referenced once the connector is gone.
+		dbcs := &apiv1alpha1.DebeziumConnectorList{}
+		Expect(r.List(ctx, dbcs)).To(Succeed())
+		Expect(dbcs.Items).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// schemaChangeConnectorClasses write the schema changes of the captured database to a topic
+// named after topic.prefix, unless include.schema.changes is false.
+var schemaChangeConnectorClasses = map[string]bool{
+	"io.debezium.connector.mysql.MySqlConnector":         true,
+	"io.debezium.connector.mariadb.MariaDbConnector":     true,
+	"io.debezium.connector.sqlserver.SqlServerConnector": true,
+	"io.debezium.connector.oracle.OracleConnector":       true,
+	"io.debezium.connector.db2.Db2Connector":             true,
+}
+
+// internalTopics returns the sorted names of the internal Debezium topics config refers to: the
+// schema history topic, the signal topic and the schema change topic. Topics holding captured
+// data and the dead letter queue, which may be shared, are not included, and neither are names
+// starting with "__", which Kafka and Connect reserve for their own topics.
+func internalTopics(config map[string]string) []string {
+	set := map[string]bool{}
+	for _, key := range []string{"schema.history.internal.kafka.topic", "database.history.kafka.topic", "signal.kafka.topic"} {
+		set[strings.TrimSpace(config[key])] = true
+	}
+	if schemaChangeConnectorClasses[config["connector.class"]] && !strings.EqualFold(strings.TrimSpace(config["include.schema.changes"]), "false") {
+		prefix := config["topic.prefix"]
+		if prefix == "" {
+			// Debezium 1.x
+			prefix = config["database.server.name"]
+		}
+		set[strings.TrimSpace(prefix)] = true
+	}
+	var names []string
+	for name := range set {
+		if name != "" && !strings.HasPrefix(name, "__") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// topicConfig returns the config of dbc to read its internal topics from. When the config cannot
+// be resolved, for instance since a referenced Secret was deleted along with the namespace, the
+// unresolved config is used, which still names the topics unless they come from a reference.
+func (r *DebeziumConnectorReconciler) topicConfig(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) map[string]string {
+	config, _, err := r.resolveLayeredConfig(ctx, dbc)
+	if err != nil {
+		log.FromContext(ctx).Info("Reading topics from the unresolved config", "connector", client.ObjectKeyFromObject(dbc), "reason", err.Error())
+		config, _ = dbc.DesiredConfig()
+	}
+	return config
+}
+
+// removableTopics returns the internal topics of dbc that no other DebeziumConnector refers to.
+func (r *DebeziumConnectorReconciler) removableTopics(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) ([]string, error) {
+	topics := internalTopics(r.topicConfig(ctx, dbc))
+	if len(topics) == 0 {
+		return nil, nil
+	}
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, err
+	}
+	shared := map[string]bool{}
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == dbc.Name && other.Namespace == dbc.Namespace {
+			continue
+		}
+		for _, name := range internalTopics(r.topicConfig(ctx, other)) {
+			shared[name] = true
+		}
+	}
+	var removable []string
+	for _, name := range topics {
+		if shared[name] {
+			log.FromContext(ctx).Info("Keeping topic another DebeziumConnector refers to", "topic", name)
+			continue
+		}
+		removable = append(removable, name)
+	}
+	return removable, nil
+}
+
+// deleteTopicsOnRemoval deletes the internal topics of dbc from Kafka for
+// Spec.DeleteTopicsOnRemoval, once its connector was removed from the Debezium host. An error is
+// returned for failures that may be transient, so the finalizer is kept and deletion retried.
+func (r *DebeziumConnectorReconciler) deleteTopicsOnRemoval(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector) error {
+	if !dbc.Spec.DeleteTopicsOnRemoval {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	if r.TopicAdmin == nil {
+		logger.Info("Not deleting topics without a Kafka admin client; start the operator with --kafka-bootstrap-servers")
+		r.recordEvent(dbc, corev1.EventTypeWarning, "TopicsNotDeleted",
+			"Topics were not deleted since the operator has no Kafka bootstrap servers configured")
+		return nil
+	}
+	topics, err := r.removableTopics(ctx, dbc)
+	if err != nil {
+		return err
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+	if err := r.TopicAdmin.DeleteTopics(ctx, topics); err != nil {
+		return err
+	}
+	for _, name := range topics {
+		logger.Info("Deleted Debezium topic", "topic", name)
+	}
+	r.recordEvent(dbc, corev1.EventTypeNormal, "TopicsDeleted", "Deleted topics %s", strings.Join(topics, ", "))
+	return nil
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/kafka"
+)
+
+var _ = Describe("Topic deletion on removal", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	DescribeTable("derives the internal topics of a connector",
+		func(config map[string]string, topics []string) {
+			Expect(internalTopics(config)).To(Equal(topics))
+		},
+		Entry("MySQL with schema history, signal and schema change topics", map[string]string{
+			"connector.class":                     "io.debezium.connector.mysql.MySqlConnector",
+			"topic.prefix":                        "inventory",
+			"schema.history.internal.kafka.topic": "schema-changes.inventory",
+			"signal.kafka.topic":                  "signals.inventory",
+		}, []string{"inventory", "schema-changes.inventory", "signals.inventory"}),
+		Entry("schema changes disabled", map[string]string{
+			"connector.class":                     "io.debezium.connector.mysql.MySqlConnector",
+			"topic.prefix":                        "inventory",
+			"include.schema.changes":              "False",
+			"schema.history.internal.kafka.topic": "schema-changes.inventory",
+		}, []string{"schema-changes.inventory"}),
+		Entry("Debezium 1.x names", map[string]string{
+			"connector.class":              "io.debezium.connector.sqlserver.SqlServerConnector",
+			"database.server.name":         "orders",
+			"database.history.kafka.topic": "history.orders",
+		}, []string{"history.orders", "orders"}),
+		Entry("PostgreSQL has no schema change topic", map[string]string{
+			"connector.class":                   "io.debezium.connector.postgresql.PostgresConnector",
+			"topic.prefix":                      "inventory",
+			"errors.deadletterqueue.topic.name": "dlq",
+		}, nil),
+		Entry("reserved names", map[string]string{
+			"connector.class":                     "io.debezium.connector.mysql.MySqlConnector",
+			"schema.history.internal.kafka.topic": "__consumer_offsets",
+			"signal.kafka.topic":                  " ",
+		}, nil),
+	)
+
+	Context("when the connector is deleted", func() {
+		var (
+			connect  *fakeConnect
+			admin    *fakeTopicAdmin
+			recorder *record.FakeRecorder
+		)
+
+		BeforeEach(func() {
+			connect = newFakeConnect()
+			admin = &fakeTopicAdmin{topics: map[string]kafka.Topic{}}
+			for _, name := range []string{"inventory", "schema-changes.inventory", "signals.shared"} {
+				admin.topics[name] = kafka.Topic{Name: name}
+			}
+			recorder = record.NewFakeRecorder(10)
+		})
+
+		AfterEach(func() {
+			connect.Close()
+		})
+
+		newDeleted := func() *apiv1alpha1.DebeziumConnector {
+			dbc := newTestDebeziumConnector(connect.URL)
+			now := metav1.Now()
+			dbc.DeletionTimestamp = &now
+			dbc.Spec.DeleteTopicsOnRemoval = true
+			dbc.Spec.Config["topic.prefix"] = "inventory"
+			dbc.Spec.Config["schema.history.internal.kafka.topic"] = "schema-changes.inventory"
+			dbc.Spec.Config["signal.kafka.topic"] = "signals.shared"
+			connect.setConnector(dbc.Spec.Config, "RUNNING")
+			return dbc
+		}
+
+		newReconciler := func(topicAdmin kafka.Admin, objs ...client.Object) *DebeziumConnectorReconciler {
+			return &DebeziumConnectorReconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).WithStatusSubresource(&apiv1alpha1.DebeziumConnector{}).Build(),
+				HTTPClient: connect.Client(),
+				Recorder:   recorder,
+				TopicAdmin: topicAdmin,
+			}
+		}
+
+		It("deletes the topics no other connector refers to", func() {
+			other := newTestDebeziumConnector(connect.URL)
+			other.Name = "orders"
+			other.Finalizers = nil
+			other.Spec.Config = map[string]string{
+				"name":               "orders",
+				"connector.class":    "io.debezium.connector.postgresql.PostgresConnector",
+				"signal.kafka.topic": "signals.shared",
+			}
+			r := newReconciler(admin, newDeleted(), other)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connect.mutations()).To(Equal([]string{"DELETE /connectors/inventory"}))
+			Expect(admin.deleted).To(Equal([]string{"inventory", "schema-changes.inventory"}))
+			Expect(admin.topics).To(HaveKey("signals.shared"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("TopicsDeleted")))
+		})
+
+		It("falls back to the unresolved config when a referenced Secret is gone", func() {
+			dbc := newDeleted()
+			dbc.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: "inventory-config"}
+			other := newTestDebeziumConnector(connect.URL)
+			other.Name = "orders"
+			other.Finalizers = nil
+			other.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: "orders-config"}
+			other.Spec.Config = map[string]string{
+				"name":               "orders",
+				"connector.class":    "io.debezium.connector.postgresql.PostgresConnector",
+				"signal.kafka.topic": "signals.shared",
+			}
+			r := newReconciler(admin, dbc, other)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admin.deleted).To(Equal([]string{"inventory", "schema-changes.inventory"}))
+			Expect(admin.topics).To(HaveKey("signals.shared"))
+		})
+
+		It("keeps the topics unless requested", func() {
+			dbc := newDeleted()
+			dbc.Spec.DeleteTopicsOnRemoval = false
+			r := newReconciler(admin, dbc)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admin.deleted).To(BeEmpty())
+		})
+
+		It("reports that topics cannot be deleted without Kafka access", func() {
+			r := newReconciler(nil, newDeleted())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("TopicsNotDeleted")))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// reconcileSchemaRegistry reports in the SchemaRegistryReachable condition whether the schema
+// registries of the connector's converters answer. The condition is removed when the config
+// names no registry or probing is disabled. An unreachable registry does not stop the reconcile.
+func (r *DebeziumConnectorReconciler) reconcileSchemaRegistry(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) {
+	if !r.ProbeSchemaRegistry {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionSchemaRegistryReachable)
+		return
+	}
+	timeout := r.SchemaRegistryProbeTimeout
+	if timeout <= 0 {
+		timeout = apiv1alpha1.DefaultSchemaRegistryProbeTimeout
+	}
+	probed, problems := util.ProbeSchemaRegistries(ctx, nil, config, timeout, r.SchemaRegistryProbeHosts)
+	if len(probed) == 0 {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionSchemaRegistryReachable)
+		return
+	}
+	if len(problems) > 0 {
+		traceStep(ctx, "schema registry unreachable")
+		log.FromContext(ctx).Info("Schema registry is unreachable", "problems", problems)
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:               apiv1alpha1.ConditionSchemaRegistryReachable,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Unreachable",
+			Message:            strings.Join(problems, "; "),
+			ObservedGeneration: dbc.Generation,
+		})
+		return
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionSchemaRegistryReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reachable",
+		Message:            "The schema registries of " + strings.Join(probed, " and ") + " answer",
+		ObservedGeneration: dbc.Generation,
+	})
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Schema registry probe", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect  *fakeConnect
+		registry *httptest.Server
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	})
+
+	AfterEach(func() {
+		registry.Close()
+		connect.Close()
+	})
+
+	reconcileOnce := func(registryURL string, probe bool) *metav1.Condition {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["value.converter"] = "io.confluent.connect.avro.AvroConverter"
+		dbc.Spec.Config["value.converter.schema.registry.url"] = registryURL
+		r := &DebeziumConnectorReconciler{
+			Client:              fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:          connect.Client(),
+			ProbeSchemaRegistry: probe,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred(), "an unreachable registry does not fail the reconcile")
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+		return meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionSchemaRegistryReachable)
+	}
+
+	It("reports a reachable registry", func() {
+		cond := reconcileOnce(registry.URL, true)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("reports an unreachable registry", func() {
+		url := registry.URL
+		registry.Close()
+		cond := reconcileOnce(url, true)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("Unreachable"))
+		Expect(cond.Message).To(ContainSubstring("value.converter.schema.registry.url"))
+	})
+
+	It("does not probe the registry unless enabled", func() {
+		url := registry.URL
+		registry.Close()
+		Expect(reconcileOnce(url, false)).To(BeNil())
+	})
+})
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// rolloutPollInterval is how soon a DebeziumConnectorSet is reconciled again while a rolling
+// update waits for connectors to return to RUNNING.
+const rolloutPollInterval = 10 * time.Second
+
+// rollingUpdate creates and updates the connectors of set in list order, while at most
+// MaxUnavailableConnectors of them are not RUNNING. A connector changed by this reconcile counts
+// as unavailable until a later reconcile sees it RUNNING, so the next one waits for it. Connectors
+// that are already not RUNNING are changed without waiting, as that does not make any other
+// connector unavailable. It returns the progress of the rollout and the errors per connector name.
+func (r *DebeziumConnectorSetReconciler) rollingUpdate(ctx context.Context, set *apiv1alpha1.DebeziumConnectorSet, members map[string]setMember) (*apiv1alpha1.ConnectorSetRolloutStatus, map[string]error) {
+	type outdatedMember struct {
+		setMember
+		exists, running bool
+	}
+	errs := map[string]error{}
+	rollout := &apiv1alpha1.ConnectorSetRolloutStatus{}
+	unavailable, updated := 0, 0
+	var outdated []outdatedMember
+	for _, spec := range set.Spec.Connectors {
+		member := members[spec.Name]
+		exists, inSync, err := r.memberInSync(ctx, member)
+		if err != nil {
+			// A connector in an unknown state may be down.
+			errs[spec.Name] = err
+			unavailable++
+			rollout.Pending = append(rollout.Pending, spec.Name)
+			continue
+		}
+		running := false
+		if exists {
+			state, err := r.Connectors.getDebeziumConnectorStatus(ctx, member.host, spec.Name)
+			running = err == nil && state.Connector.State == "RUNNING"
+			if !running {
+				unavailable++
+			}
+		}
+		if !inSync {
+			outdated = append(outdated, outdatedMember{setMember: member, exists: exists, running: running})
+			continue
+		}
+		updated++
+		if !running {
+			rollout.Updating = append(rollout.Updating, spec.Name)
+		}
+	}
+
+	for _, member := range outdated {
+		name := member.config["name"]
+		// Changing a connector that is already down does not take another one down.
+		takesDown := !member.exists || member.running
+		if takesDown && unavailable >= set.MaxUnavailableConnectors() {
+			rollout.Pending = append(rollout.Pending, name)
+			continue
+		}
+		if err := r.applyMember(ctx, member.setMember, member.exists); err != nil {
+			errs[name] = err
+			rollout.Pending = append(rollout.Pending, name)
+			continue
+		}
+		if takesDown {
+			unavailable++
+		}
+		updated++
+		rollout.Updating = append(rollout.Updating, name)
+		r.recordEvent(set, corev1.EventTypeNormal, "RollingUpdate", "Applied the desired config to connector %s", name)
+		log.FromContext(ctx).Info("Rolling update applied", "name", name, "unavailable", unavailable)
+	}
+	rollout.UpdatedConnectors = fmt.Sprintf("%d/%d", updated, len(members))
+	return rollout, errs
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("DebeziumConnectorSet rolling updates", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "cdc", Namespace: "default"}
+	const mysql = "io.debezium.connector.mysql.MySqlConnector"
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newRollingSet := func(maxUnavailable int32) *apiv1alpha1.DebeziumConnectorSet {
+		set := &apiv1alpha1.DebeziumConnectorSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Generation: 1,
+				Finalizers: []string{debeziumSetFinalizer},
+			},
+			Spec: apiv1alpha1.DebeziumConnectorSetSpec{
+				DebeziumHost:   connect.URL,
+				UpdateStrategy: apiv1alpha1.SetUpdateStrategyRollingUpdate,
+				MaxUnavailable: maxUnavailable,
+			},
+		}
+		for _, name := range []string{"inventory", "orders", "customers"} {
+			set.Spec.Connectors = append(set.Spec.Connectors, apiv1alpha1.NamedConnectorSpec{
+				Name:   name,
+				Config: map[string]string{"connector.class": mysql, "tasks.max": "2"},
+			})
+		}
+		return set
+	}
+
+	// setOutdatedConnector stores name on the fake with a config that differs from the set's.
+	setOutdatedConnector := func(name, state string) {
+		connect.setConnector(map[string]string{"name": name, "connector.class": mysql, "tasks.max": "1"}, state)
+	}
+
+	newReconciler := func(set *apiv1alpha1.DebeziumConnectorSet) *DebeziumConnectorSetReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(set).WithStatusSubresource(set).Build()
+		return &DebeziumConnectorSetReconciler{
+			Client:     c,
+			Connectors: &DebeziumConnectorReconciler{Client: c, HTTPClient: connect.Client()},
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorSetReconciler) (reconcile.Result, *apiv1alpha1.DebeziumConnectorSet) {
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		set := &apiv1alpha1.DebeziumConnectorSet{}
+		Expect(r.Get(ctx, key, set)).To(Succeed())
+		return result, set
+	}
+
+	It("creates the connectors one at a time in list order", func() {
+		r := newReconciler(newRollingSet(0))
+
+		result, set := reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(connect.configs).To(HaveKey("inventory"))
+		Expect(set.Status.Rollout).To(Equal(&apiv1alpha1.ConnectorSetRolloutStatus{
+			UpdatedConnectors: "1/3",
+			Updating:          []string{"inventory"},
+			Pending:           []string{"orders", "customers"},
+		}))
+		Expect(result.RequeueAfter).To(Equal(rolloutPollInterval))
+
+		reconcileOnce(r)
+		Expect(connect.configs).To(HaveKey("orders"))
+		Expect(connect.configs).NotTo(HaveKey("customers"))
+
+		reconcileOnce(r)
+		result, set = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(3))
+		Expect(set.Status.Rollout).To(Equal(&apiv1alpha1.ConnectorSetRolloutStatus{UpdatedConnectors: "3/3"}))
+		Expect(set.Status.ReadyConnectors).To(Equal("3/3"))
+		Expect(result.RequeueAfter).To(Equal(apiv1alpha1.DefaultReconcileInterval))
+	})
+
+	It("waits for an updated connector to return to RUNNING", func() {
+		for _, name := range []string{"inventory", "orders", "customers"} {
+			setOutdatedConnector(name, "RUNNING")
+		}
+		r := newReconciler(newRollingSet(0))
+
+		reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+
+		connect.mu.Lock()
+		connect.states["inventory"] = "FAILED"
+		connect.mu.Unlock()
+		_, set := reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(1))
+		Expect(set.Status.Rollout.Updating).To(Equal([]string{"inventory"}))
+		Expect(set.Status.Rollout.Pending).To(Equal([]string{"orders", "customers"}))
+
+		connect.mu.Lock()
+		connect.states["inventory"] = "RUNNING"
+		connect.mu.Unlock()
+		reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{
+			"PUT /connectors/inventory/config",
+			"PUT /connectors/orders/config",
+		}))
+	})
+
+	It("changes up to maxUnavailable connectors at once", func() {
+		r := newReconciler(newRollingSet(2))
+
+		_, set := reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(2))
+		Expect(set.Status.Rollout.Updating).To(Equal([]string{"inventory", "orders"}))
+		Expect(set.Status.Rollout.Pending).To(Equal([]string{"customers"}))
+	})
+
+	It("updates connectors that are already down without waiting", func() {
+		setOutdatedConnector("inventory", "RUNNING")
+		setOutdatedConnector("orders", "FAILED")
+		setOutdatedConnector("customers", "RUNNING")
+		r := newReconciler(newRollingSet(0))
+
+		_, set := reconcileOnce(r)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/orders/config"}))
+		Expect(set.Status.Rollout.Pending).To(Equal([]string{"inventory", "customers"}))
+	})
+
+	It("applies all changes at once by default", func() {
+		set := newRollingSet(0)
+		set.Spec.UpdateStrategy = ""
+		r := newReconciler(set)
+
+		_, set = reconcileOnce(r)
+		Expect(connect.mutations()).To(HaveLen(3))
+		Expect(set.Status.Rollout).To(BeNil())
+	})
+})
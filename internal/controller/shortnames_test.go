@@ -0,0 +1,48 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("When resolving resource short names", func() {
+	It("maps the short names to the Debezium resources", func() {
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		cached := memory.NewMemCacheClient(dc)
+		mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached, nil)
+
+		for shortName, plural := range map[string]string{
+			"dbc":  "debeziumconnectors",
+			"dzc":  "debeziumconnectors",
+			"dbcs": "debeziumconnectorsets",
+		} {
+			gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: shortName})
+			Expect(err).NotTo(HaveOccurred(), shortName)
+			Expect(gvr).To(Equal(apiv1alpha1.GroupVersion.WithResource(plural)), shortName)
+		}
+	})
+
+	It("lists the Debezium resources in the debezium category", func() {
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		resources, err := dc.ServerResourcesForGroupVersion(apiv1alpha1.GroupVersion.String())
+		Expect(err).NotTo(HaveOccurred())
+
+		var categorized []string
+		for _, resource := range resources.APIResources {
+			for _, category := range resource.Categories {
+				if category == "debezium" {
+					categorized = append(categorized, resource.Name)
+				}
+			}
+		}
+		Expect(categorized).To(ConsistOf("debeziumconnectors", "debeziumconnectorsets"))
+	})
+})
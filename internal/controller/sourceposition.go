@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sourcePosition formats the position in the source database recorded in the committed
+// offsets of a connector:
+//
+//	PostgreSQL         lsn=16/B374D848
+//	MySQL, MariaDB     binlog=mysql-bin.000003:154 gtids=<GTID set>
+//	SQL Server, Db2    commit_lsn=0000002b:00000298:0003
+//	Oracle             scn=3005291
+//	MongoDB            ts=1714564800:3
+//
+// The positions of several source partitions, e.g. the databases of a SQL Server connector,
+// are joined with "; ". An empty string is returned when no offset holds a known position.
+func sourcePosition(offsets []connectorOffset) string {
+	var positions []string
+	for _, o := range offsets {
+		if position := offsetPosition(o.Offset); position != "" {
+			positions = append(positions, position)
+		}
+	}
+	sort.Strings(positions)
+	return strings.Join(positions, "; ")
+}
+
+// offsetPosition formats the position of a single Debezium source offset.
+func offsetPosition(offset map[string]interface{}) string {
+	var parts []string
+	if lsn, ok := offsetValue(offset, "lsn"); ok {
+		parts = append(parts, "lsn="+postgresLSN(lsn))
+	}
+	if file, ok := offsetValue(offset, "file"); ok {
+		pos, _ := offsetValue(offset, "pos")
+		parts = append(parts, fmt.Sprintf("binlog=%s:%s", file, pos))
+	}
+	if gtids, ok := offsetValue(offset, "gtids"); ok {
+		parts = append(parts, "gtids="+gtids)
+	}
+	if lsn, ok := offsetValue(offset, "commit_lsn"); ok {
+		parts = append(parts, "commit_lsn="+lsn)
+	}
+	if scn, ok := offsetValue(offset, "commit_scn"); ok {
+		parts = append(parts, "scn="+scn)
+	} else if scn, ok := offsetValue(offset, "scn"); ok {
+		parts = append(parts, "scn="+scn)
+	}
+	if sec, ok := offsetValue(offset, "sec"); ok {
+		ord, _ := offsetValue(offset, "ord")
+		parts = append(parts, fmt.Sprintf("ts=%s:%s", sec, ord))
+	}
+	return strings.Join(parts, " ")
+}
+
+// offsetValue returns a non-empty offset value as a string. Numbers are formatted without
+// exponent or fraction, so large LSNs and SCNs keep all their digits.
+func offsetValue(offset map[string]interface{}, key string) (string, bool) {
+	var s string
+	switch v := offset[key].(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	s = strings.TrimSpace(s)
+	return s, s != ""
+}
+
+// postgresLSN formats a numeric PostgreSQL LSN the way PostgreSQL prints it, e.g. 16/B374D848.
+// Values that are not numbers are returned unchanged.
+func postgresLSN(lsn string) string {
+	n, err := strconv.ParseUint(lsn, 10, 64)
+	if err != nil {
+		return lsn
+	}
+	return fmt.Sprintf("%X/%X", n>>32, n&0xffffffff)
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Source position", func() {
+	// decode parses a GET /connectors/{name}/offsets response body.
+	decode := func(body string) []connectorOffset {
+		var payload struct {
+			Offsets []connectorOffset `json:"offsets"`
+		}
+		decoder := json.NewDecoder(strings.NewReader(body))
+		decoder.UseNumber()
+		Expect(decoder.Decode(&payload)).To(Succeed())
+		return payload.Offsets
+	}
+
+	DescribeTable("formats the committed position",
+		func(body, position string) {
+			Expect(sourcePosition(decode(body))).To(Equal(position))
+		},
+		Entry("PostgreSQL",
+			`{"offsets":[{"partition":{"server":"inventory"},"offset":{"lsn":97390608456,"lsn_proc":97390608456,"txId":1043,"ts_usec":1714564800000000}}]}`,
+			"lsn=16/ACEEC048"),
+		Entry("MySQL with binlog coordinates",
+			`{"offsets":[{"partition":{"server":"inventory"},"offset":{"file":"mysql-bin.000003","pos":154,"ts_sec":1714564800}}]}`,
+			"binlog=mysql-bin.000003:154"),
+		Entry("MySQL with GTIDs",
+			`{"offsets":[{"partition":{"server":"inventory"},"offset":{"file":"mysql-bin.000003","pos":154,"gtids":"3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"}}]}`,
+			"binlog=mysql-bin.000003:154 gtids=3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"),
+		Entry("SQL Server with several databases",
+			`{"offsets":[`+
+				`{"partition":{"server":"inventory","database":"orders"},"offset":{"commit_lsn":"0000002b:00000298:0003","change_lsn":"0000002b:00000298:0002"}},`+
+				`{"partition":{"server":"inventory","database":"customers"},"offset":{"commit_lsn":"0000002a:00000100:0001"}}]}`,
+			"commit_lsn=0000002a:00000100:0001; commit_lsn=0000002b:00000298:0003"),
+		Entry("Oracle",
+			`{"offsets":[{"partition":{"server":"inventory"},"offset":{"scn":"3005291","commit_scn":"3005293:1:0a001b00e1030000"}}]}`,
+			"scn=3005293:1:0a001b00e1030000"),
+		Entry("Oracle with a numeric SCN beyond float64 precision",
+			`{"offsets":[{"partition":{"server":"inventory"},"offset":{"scn":18446744073709551}}]}`,
+			"scn=18446744073709551"),
+		Entry("MongoDB",
+			`{"offsets":[{"partition":{"server_id":"inventory"},"offset":{"sec":1714564800,"ord":3,"resume_token":"8266326A"}}]}`,
+			"ts=1714564800:3"),
+		Entry("no offsets", `{"offsets":[]}`, ""),
+		Entry("an unknown connector", `{"offsets":[{"partition":{"file":"/data/in.txt"},"offset":{"position":42}}]}`, ""),
+	)
+})
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// stateHistoryLimit bounds Status.History, so a flapping connector does not bloat the object.
+const stateHistoryLimit = 10
+
+// recordStateHistory appends the change of the connector state since previous to
+// Status.History, dropping the oldest entries beyond stateHistoryLimit. States that were not
+// reported by Connect are not recorded.
+func recordStateHistory(dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, now time.Time) {
+	from, to := previous.ConnectorStatus, dbc.Status.ConnectorStatus
+	if !knownState(from) {
+		from = ""
+	}
+	if !knownState(to) || from == to {
+		return
+	}
+	history := append(dbc.Status.History, apiv1alpha1.StateTransition{
+		Time:   metav1.NewTime(now),
+		From:   from,
+		To:     to,
+		Reason: transitionReason(dbc, previous, from, to),
+	})
+	if len(history) > stateHistoryLimit {
+		history = append([]apiv1alpha1.StateTransition(nil), history[len(history)-stateHistoryLimit:]...)
+	}
+	dbc.Status.History = history
+}
+
+// transitionReason classifies a change of the connector state from from to to.
+func transitionReason(dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, from, to string) string {
+	switch {
+	case to == "FAILED":
+		return apiv1alpha1.TransitionReasonFailed
+	case from == "FAILED" && to == "RUNNING":
+		return apiv1alpha1.TransitionReasonRecovered
+	case from == "" && dbc.Status.CreatedTime != nil && !dbc.Status.CreatedTime.Equal(previous.CreatedTime):
+		return apiv1alpha1.TransitionReasonCreated
+	case from != "" && desiredStateTransitions[dbc.Spec.DesiredState].state == to:
+		return apiv1alpha1.TransitionReasonDesiredState
+	}
+	return apiv1alpha1.TransitionReasonObserved
+}
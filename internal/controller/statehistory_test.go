@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("State history", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// observe records a status check that found the connector in state.
+	observe := func(dbc *apiv1alpha1.DebeziumConnector, state string, at time.Time) {
+		previous := dbc.Status.DeepCopy()
+		dbc.Status.ConnectorStatus = state
+		recordStateHistory(dbc, previous, at)
+	}
+
+	It("records state changes only", func() {
+		dbc := newTestDebeziumConnector("http://state-history:8083")
+		observe(dbc, "UNKNOWN", now)
+		observe(dbc, "RUNNING", now.Add(time.Second))
+		observe(dbc, "RUNNING", now.Add(2*time.Second))
+		observe(dbc, "FAILED", now.Add(3*time.Second))
+		observe(dbc, "UNKNOWN", now.Add(4*time.Second))
+		observe(dbc, "RUNNING", now.Add(5*time.Second))
+		dbc.Spec.DesiredState = apiv1alpha1.DesiredStatePaused
+		observe(dbc, "PAUSED", now.Add(6*time.Second))
+
+		Expect(dbc.Status.History).To(Equal([]apiv1alpha1.StateTransition{
+			{Time: metav1.NewTime(now.Add(time.Second)), To: "RUNNING", Reason: apiv1alpha1.TransitionReasonObserved},
+			{Time: metav1.NewTime(now.Add(3 * time.Second)), From: "RUNNING", To: "FAILED", Reason: apiv1alpha1.TransitionReasonFailed},
+			{Time: metav1.NewTime(now.Add(5 * time.Second)), To: "RUNNING", Reason: apiv1alpha1.TransitionReasonObserved},
+			{Time: metav1.NewTime(now.Add(6 * time.Second)), From: "RUNNING", To: "PAUSED", Reason: apiv1alpha1.TransitionReasonDesiredState},
+		}))
+	})
+
+	It("keeps the last transitions only", func() {
+		dbc := newTestDebeziumConnector("http://state-history:8083")
+		for i := 0; i < 2*stateHistoryLimit; i++ {
+			state := "RUNNING"
+			if i%2 == 1 {
+				state = "PAUSED"
+			}
+			observe(dbc, state, now.Add(time.Duration(i)*time.Second))
+		}
+		Expect(dbc.Status.History).To(HaveLen(stateHistoryLimit))
+		Expect(dbc.Status.History[0].Time.Time).To(Equal(now.Add(stateHistoryLimit * time.Second)))
+		Expect(dbc.Status.History[stateHistoryLimit-1].Time.Time).To(Equal(now.Add((2*stateHistoryLimit - 1) * time.Second)))
+	})
+
+	It("is recorded while reconciling", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		reconcileWith := func(state string) []apiv1alpha1.StateTransition {
+			if state != "" {
+				connect.mu.Lock()
+				connect.states["inventory"] = state
+				connect.mu.Unlock()
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			latest := &apiv1alpha1.DebeziumConnector{}
+			Expect(r.Get(ctx, key, latest)).To(Succeed())
+			return latest.Status.History
+		}
+
+		history := reconcileWith("")
+		Expect(history).To(HaveLen(1))
+		Expect(history[0].From).To(BeEmpty())
+		Expect(history[0].To).To(Equal("RUNNING"))
+		Expect(history[0].Reason).To(Equal(apiv1alpha1.TransitionReasonCreated))
+
+		Expect(reconcileWith("RUNNING")).To(HaveLen(1))
+
+		history = reconcileWith("FAILED")
+		Expect(history).To(HaveLen(2))
+		Expect(history[1].From).To(Equal("RUNNING"))
+		Expect(history[1].To).To(Equal("FAILED"))
+		Expect(history[1].Reason).To(Equal(apiv1alpha1.TransitionReasonFailed))
+
+		history = reconcileWith("RUNNING")
+		Expect(history).To(HaveLen(3))
+		Expect(history[2].Reason).To(Equal(apiv1alpha1.TransitionReasonRecovered))
+	})
+})
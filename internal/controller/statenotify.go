@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// defaultNotifyQueueSize bounds the transitions waiting to be posted by a WebhookNotifier.
+const defaultNotifyQueueSize = 100
+
+// StateTransition is a change of the state of a connector or one of its tasks, observed while
+// reconciling its status.
+type StateTransition struct {
+	Namespace    string      `json:"namespace"`
+	Resource     string      `json:"resource"`
+	Connector    string      `json:"connector"`
+	DebeziumHost string      `json:"debeziumHost"`
+	Task         *int32      `json:"task,omitempty"`
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	Time         metav1.Time `json:"time"`
+}
+
+// String describes the transition in one sentence.
+func (t StateTransition) String() string {
+	if t.Task != nil {
+		return fmt.Sprintf("Task %d of connector %s changed from %s to %s", *t.Task, t.Connector, t.From, t.To)
+	}
+	return fmt.Sprintf("Connector %s changed from %s to %s", t.Connector, t.From, t.To)
+}
+
+// StateNotifier is told about state transitions. Notify is called from the reconcile and must
+// not block.
+type StateNotifier interface {
+	Notify(transition StateTransition)
+}
+
+// knownState reports whether state was actually reported by Connect.
+func knownState(state string) bool {
+	return state != "" && state != "UNKNOWN"
+}
+
+// stateTransitions returns the transitions of the connector and its tasks from previous to the
+// current status of dbc. States that were not reported by Connect, and tasks that were added or
+// removed, are not transitions.
+func stateTransitions(dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, name string) []StateTransition {
+	now := metav1.Now()
+	base := StateTransition{
+		Namespace:    dbc.Namespace,
+		Resource:     dbc.Name,
+		Connector:    name,
+		DebeziumHost: dbc.Spec.DebeziumHost,
+		Time:         now,
+	}
+	var transitions []StateTransition
+	if from, to := previous.ConnectorStatus, dbc.Status.ConnectorStatus; knownState(from) && knownState(to) && from != to {
+		t := base
+		t.From, t.To = from, to
+		transitions = append(transitions, t)
+	}
+	before := make(map[int32]string, len(previous.Tasks))
+	for _, task := range previous.Tasks {
+		before[task.ID] = task.State
+	}
+	for _, task := range dbc.Status.Tasks {
+		if from, ok := before[task.ID]; ok && knownState(from) && knownState(task.State) && from != task.State {
+			t := base
+			id := task.ID
+			t.Task, t.From, t.To = &id, from, task.State
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions
+}
+
+// notifyStateTransitions reports each state transition of dbc since previous as a StateChanged
+// event when StateChangeEvents is set, and passes it on to the StateNotifier.
+func (r *DebeziumConnectorReconciler) notifyStateTransitions(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, previous *apiv1alpha1.DebeziumConnectorStatus, name string) {
+	if !r.StateChangeEvents && r.StateNotifier == nil {
+		return
+	}
+	for _, t := range stateTransitions(dbc, previous, name) {
+		traceStep(ctx, "%s", t.String())
+		if r.StateChangeEvents {
+			eventtype := corev1.EventTypeNormal
+			if t.To == "FAILED" {
+				eventtype = corev1.EventTypeWarning
+			}
+			r.recordEvent(dbc, eventtype, "StateChanged", "%s", t.String())
+		}
+		if r.StateNotifier != nil {
+			r.StateNotifier.Notify(t)
+		}
+	}
+}
+
+// WebhookNotifier posts state transitions as JSON to a webhook URL. The payload carries the
+// transition's fields and a "text" field with its description, which Slack-style incoming
+// webhooks display. Transitions are queued and posted by Start, so a slow or unavailable webhook
+// never delays a reconcile; transitions are dropped when the queue is full or a post fails.
+type WebhookNotifier struct {
+	// URL receives a POST for each transition.
+	URL string
+	// HTTPClient sends the posts.
+	HTTPClient *http.Client
+
+	queue chan StateTransition
+}
+
+// NewWebhookNotifier returns a notifier posting to url, using a client with a 10 second timeout
+// when client is nil.
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookNotifier{URL: url, HTTPClient: client, queue: make(chan StateTransition, defaultNotifyQueueSize)}
+}
+
+// Notify queues transition for posting, dropping it when the queue is full.
+func (n *WebhookNotifier) Notify(transition StateTransition) {
+	select {
+	case n.queue <- transition:
+	default:
+		log.Log.WithName("state-notifier").Info("notification queue is full; dropping state transition", "transition", transition.String())
+	}
+}
+
+// NeedLeaderElection runs the notifier only on the leader, whose reconciles queue transitions.
+func (n *WebhookNotifier) NeedLeaderElection() bool {
+	return true
+}
+
+// Start posts queued transitions until ctx is cancelled.
+func (n *WebhookNotifier) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("state-notifier")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case t := <-n.queue:
+			if err := n.post(ctx, t); err != nil {
+				logger.Error(err, "failed to post state transition", "transition", t.String())
+			}
+		}
+	}
+}
+
+// post sends a single transition to the webhook.
+func (n *WebhookNotifier) post(ctx context.Context, t StateTransition) error {
+	data, err := json.Marshal(struct {
+		Text string `json:"text"`
+		StateTransition
+	}{Text: t.String(), StateTransition: t})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// recordingNotifier collects the transitions it is told about.
+type recordingNotifier struct {
+	mu          sync.Mutex
+	transitions []StateTransition
+}
+
+func (n *recordingNotifier) Notify(t StateTransition) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.transitions = append(n.transitions, t)
+}
+
+var _ = Describe("State change notifications", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	describe := func(ts []StateTransition) []string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.String())
+		}
+		return out
+	}
+
+	It("detects connector and task transitions", func() {
+		dbc := newTestDebeziumConnector("http://connect:8083")
+		previous := &apiv1alpha1.DebeziumConnectorStatus{
+			ConnectorStatus: "RUNNING",
+			Tasks:           []apiv1alpha1.TaskStatus{{ID: 0, State: "RUNNING"}, {ID: 1, State: "RUNNING"}},
+		}
+		dbc.Status.ConnectorStatus = "FAILED"
+		dbc.Status.Tasks = []apiv1alpha1.TaskStatus{{ID: 0, State: "RUNNING"}, {ID: 1, State: "FAILED"}, {ID: 2, State: "RUNNING"}}
+
+		transitions := stateTransitions(dbc, previous, "inventory")
+		Expect(describe(transitions)).To(Equal([]string{
+			"Connector inventory changed from RUNNING to FAILED",
+			"Task 1 of connector inventory changed from RUNNING to FAILED",
+		}))
+		Expect(transitions[0].DebeziumHost).To(Equal("http://connect:8083"))
+		Expect(transitions[0].Resource).To(Equal("inventory"))
+	})
+
+	It("ignores states not reported by Connect", func() {
+		dbc := newTestDebeziumConnector("http://connect:8083")
+		dbc.Status.ConnectorStatus = "RUNNING"
+		Expect(stateTransitions(dbc, &apiv1alpha1.DebeziumConnectorStatus{}, "inventory")).To(BeEmpty())
+		Expect(stateTransitions(dbc, &apiv1alpha1.DebeziumConnectorStatus{ConnectorStatus: "UNKNOWN"}, "inventory")).To(BeEmpty())
+		dbc.Status.ConnectorStatus = "UNKNOWN"
+		Expect(stateTransitions(dbc, &apiv1alpha1.DebeziumConnectorStatus{ConnectorStatus: "RUNNING"}, "inventory")).To(BeEmpty())
+	})
+
+	It("emits an event and notifies on a reconciled transition", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Status.ConnectorStatus = "RUNNING"
+		connect.setConnector(dbc.Spec.Config, "PAUSED")
+		notifier := &recordingNotifier{}
+		recorder := record.NewFakeRecorder(10)
+		r := &DebeziumConnectorReconciler{
+			Client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:        connect.Client(),
+			Recorder:          recorder,
+			StateChangeEvents: true,
+			StateNotifier:     notifier,
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Events).To(Receive(Equal("Normal StateChanged Connector inventory changed from RUNNING to PAUSED")))
+		Expect(describe(notifier.transitions)).To(Equal([]string{"Connector inventory changed from RUNNING to PAUSED"}))
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notifier.transitions).To(HaveLen(1))
+	})
+
+	Context("with a webhook", func() {
+		var (
+			server   *httptest.Server
+			received chan map[string]interface{}
+		)
+
+		BeforeEach(func() {
+			received = make(chan map[string]interface{}, 10)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]interface{}
+				Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+				received <- payload
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		transition := StateTransition{Namespace: "default", Resource: "inventory", Connector: "inventory", From: "RUNNING", To: "FAILED"}
+
+		It("posts queued transitions with a text field", func() {
+			n := NewWebhookNotifier(server.URL, server.Client())
+			runCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(n.Start(runCtx)).To(Succeed())
+			}()
+
+			n.Notify(transition)
+			var payload map[string]interface{}
+			Eventually(received).Should(Receive(&payload))
+			Expect(payload).To(HaveKeyWithValue("text", "Connector inventory changed from RUNNING to FAILED"))
+			Expect(payload).To(HaveKeyWithValue("to", "FAILED"))
+			Expect(payload).To(HaveKeyWithValue("namespace", "default"))
+			Expect(payload).NotTo(HaveKey("task"))
+		})
+
+		It("drops transitions instead of blocking when the queue is full", func() {
+			n := NewWebhookNotifier(server.URL, server.Client())
+			for i := 0; i < defaultNotifyQueueSize+5; i++ {
+				n.Notify(transition)
+			}
+			Expect(n.queue).To(HaveLen(defaultNotifyQueueSize))
+		})
+	})
+})
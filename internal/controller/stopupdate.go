@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// restartRequiredKeys are config keys that change where or how the connector reads its source.
+// Changing them on a running connector can leave a half-applied snapshot or replication slot, so
+// the StopAndResume strategy stops the connector around the update.
+var restartRequiredKeys = map[string]bool{
+	"connector.class":         true,
+	"topic.prefix":            true,
+	"database.server.name":    true,
+	"snapshot.mode":           true,
+	"plugin.name":             true,
+	"slot.name":               true,
+	"publication.name":        true,
+	"table.include.list":      true,
+	"table.exclude.list":      true,
+	"schema.include.list":     true,
+	"schema.exclude.list":     true,
+	"collection.include.list": true,
+	"collection.exclude.list": true,
+}
+
+// restartRequiredKeyPrefixes are prefixes of restart-required keys, e.g. the connection settings.
+var restartRequiredKeyPrefixes = []string{"database."}
+
+// stateWaitInterval is the delay between connector status checks while waiting for a state change.
+var stateWaitInterval = 500 * time.Millisecond
+
+// requiresRestart reports whether changing current to desired touches a restart-required key.
+func requiresRestart(current, desired map[string]string) bool {
+	isRestartKey := func(key string) bool {
+		if restartRequiredKeys[key] {
+			return true
+		}
+		for _, prefix := range restartRequiredKeyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	for k, v := range desired {
+		if cur, ok := current[k]; (!ok || cur != v) && isRestartKey(k) {
+			return true
+		}
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok && isRestartKey(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyConfigUpdate updates the connector to config. With the StopAndResume strategy, a change
+// to a restart-required key is applied by stopping the connector, updating it and resuming it,
+// reporting each step in the Reconfiguring condition. Hosts without the stop endpoint get a
+// plain update.
+func (r *DebeziumConnectorReconciler) applyConfigUpdate(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, current, config map[string]string) error {
+	if dbc.Spec.UpdateStrategy != apiv1alpha1.UpdateStrategyStopAndResume || !requiresRestart(current, config) {
+		return r.updateDebeziumConnector(ctx, dbc.Spec.DebeziumHost, config)
+	}
+	if err := r.supportsFeature(ctx, dbc.Spec.DebeziumHost, featureStop); err != nil {
+		log.FromContext(ctx).Info("connector stop is unavailable, updating in place", "reason", err.Error())
+		return r.updateDebeziumConnector(ctx, dbc.Spec.DebeziumHost, config)
+	}
+
+	host, name := dbc.Spec.DebeziumHost, config["name"]
+	if err := r.setReconfiguring(ctx, dbc, "Stopping", "Stopping connector to apply a restart-required config change"); err != nil {
+		return err
+	}
+	if err := r.changeConnectorState(ctx, host, name, "stop"); err != nil {
+		return fmt.Errorf("failed to stop connector: %w", err)
+	}
+	if err := r.waitForConnectorState(ctx, host, name, "STOPPED", "stop"); err != nil {
+		return err
+	}
+
+	if err := r.setReconfiguring(ctx, dbc, "Updating", "Updating stopped connector"); err != nil {
+		return err
+	}
+	if err := r.updateDebeziumConnector(ctx, host, config); err != nil {
+		return err
+	}
+	return r.resumeReconfiguredConnector(ctx, dbc, name)
+}
+
+// resumeReconfiguredConnector resumes a connector stopped by applyConfigUpdate and waits until it
+// runs again. It also completes a reconfiguration interrupted after the update.
+func (r *DebeziumConnectorReconciler) resumeReconfiguredConnector(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) error {
+	host := dbc.Spec.DebeziumHost
+	if err := r.setReconfiguring(ctx, dbc, "Resuming", "Resuming connector with the updated config"); err != nil {
+		return err
+	}
+	if err := r.changeConnectorState(ctx, host, name, "resume"); err != nil {
+		return fmt.Errorf("failed to resume connector: %w", err)
+	}
+	if err := r.waitForConnectorState(ctx, host, name, "RUNNING", "resume"); err != nil {
+		return err
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionReconfiguring,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Reconfigured",
+		Message:            "Connector was stopped, updated and resumed",
+		ObservedGeneration: dbc.Generation,
+	})
+	return nil
+}
+
+// reconfigurationInProgress reports whether a stop, update and resume sequence was interrupted.
+func reconfigurationInProgress(dbc *apiv1alpha1.DebeziumConnector) bool {
+	return meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionReconfiguring)
+}
+
+// setReconfiguring records the current step of a reconfiguration and persists it, so the step
+// is visible while the operation runs.
+func (r *DebeziumConnectorReconciler) setReconfiguring(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, reason, message string) error {
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionReconfiguring,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: dbc.Generation,
+	})
+	return r.updateStatus(ctx, dbc)
+}
+
+// waitForConnectorState polls the connector status until it reports state, failing with an
+// *operationTimeoutError when the operation timeout passes first.
+func (r *DebeziumConnectorReconciler) waitForConnectorState(ctx context.Context, host, name, state, operation string) error {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, r.operationTimeout())
+	defer cancel()
+	for {
+		status, err := r.getDebeziumConnectorStatus(ctx, host, name)
+		if err == nil {
+			if status.Connector.State == state {
+				return nil
+			}
+			if status.Connector.State == "FAILED" {
+				return fmt.Errorf("connector failed while waiting for %s", operation)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return r.asOperationTimeout(ctx.Err(), apiv1alpha1.ConditionReconfiguring, operation, start)
+		case <-time.After(stateWaitInterval):
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Stop and resume updates", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		connect.setConnector(map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"tasks.max":       "1",
+			"snapshot.mode":   "initial",
+		}, "RUNNING")
+		original := stateWaitInterval
+		stateWaitInterval = 10 * time.Millisecond
+		DeferCleanup(func() { stateWaitInterval = original })
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector) (*DebeziumConnectorReconciler, *apiv1alpha1.DebeziumConnector) {
+		r := &DebeziumConnectorReconciler{
+			Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:       connect.Client(),
+			OperationTimeout: 100 * time.Millisecond,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return r, latest
+	}
+
+	stopAndResume := func(snapshotMode string) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.UpdateStrategy = apiv1alpha1.UpdateStrategyStopAndResume
+		dbc.Spec.Config["snapshot.mode"] = snapshotMode
+		return dbc
+	}
+
+	It("stops, updates and resumes the connector for a restart-required change", func() {
+		_, latest := reconcileOnce(stopAndResume("never"))
+		Expect(connect.mutations()).To(Equal([]string{
+			"PUT /connectors/inventory/stop",
+			"PUT /connectors/inventory/config",
+			"PUT /connectors/inventory/resume",
+		}))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("snapshot.mode", "never"))
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionReconfiguring)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("Reconfigured"))
+	})
+
+	It("updates in place when no restart-required key changes", func() {
+		dbc := stopAndResume("initial")
+		dbc.Spec.Config["tasks.max"] = "2"
+		reconcileOnce(dbc)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+	})
+
+	It("updates in place with the default strategy", func() {
+		dbc := stopAndResume("never")
+		dbc.Spec.UpdateStrategy = ""
+		reconcileOnce(dbc)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+	})
+
+	It("falls back to an in-place update when Connect cannot stop connectors", func() {
+		connect.version = "3.4.1"
+		reconcileOnce(stopAndResume("never"))
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/config"}))
+	})
+
+	It("reports a phase that does not complete in time and finishes on a later reconcile", func() {
+		var stuck atomic.Bool
+		stuck.Store(true)
+		connect.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if stuck.Load() && strings.HasSuffix(req.URL.Path, "/stop") {
+				// Connect accepts the stop but the connector never reaches STOPPED.
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			connect.serve(w, req)
+		})
+
+		r, latest := reconcileOnce(stopAndResume("never"))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionReconfiguring)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("DeadlineExceeded"))
+		Expect(cond.Message).To(ContainSubstring("connector stop did not complete within 100ms"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("snapshot.mode", "initial"))
+
+		stuck.Store(false)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionReconfiguring)).To(BeTrue())
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("snapshot.mode", "never"))
+	})
+
+	It("resumes a connector left stopped by an interrupted reconfiguration", func() {
+		dbc := stopAndResume("initial")
+		connect.setConnector(dbc.Spec.Config, "STOPPED")
+		dbc.Status.Conditions = []metav1.Condition{{
+			Type:               apiv1alpha1.ConditionReconfiguring,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Resuming",
+			LastTransitionTime: metav1.Now(),
+		}}
+
+		_, latest := reconcileOnce(dbc)
+		Expect(connect.mutations()).To(Equal([]string{"PUT /connectors/inventory/resume"}))
+		Expect(latest.Status.ConnectorStatus).To(Equal("RUNNING"))
+	})
+
+	It("detects restart-required keys", func() {
+		current := map[string]string{"tasks.max": "1", "database.hostname": "a"}
+		Expect(requiresRestart(current, map[string]string{"tasks.max": "2", "database.hostname": "a"})).To(BeFalse())
+		Expect(requiresRestart(current, map[string]string{"tasks.max": "1", "database.hostname": "b"})).To(BeTrue())
+		Expect(requiresRestart(current, map[string]string{"tasks.max": "1"})).To(BeTrue())
+		Expect(requiresRestart(current, map[string]string{"tasks.max": "1", "database.hostname": "a", "table.include.list": "x"})).To(BeTrue())
+	})
+})
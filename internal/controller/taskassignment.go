@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"sort"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// recordTaskAssignment reports the state of each task and the worker it runs on, which shows
+// when the tasks of a connector all land on one worker.
+func recordTaskAssignment(dbc *apiv1alpha1.DebeziumConnector, status *connectorStatus) {
+	var tasks []apiv1alpha1.TaskStatus
+	for _, task := range status.Tasks {
+		tasks = append(tasks, apiv1alpha1.TaskStatus{
+			ID:       int32(task.ID),
+			State:    task.State,
+			WorkerID: task.WorkerID,
+		})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	dbc.Status.Tasks = tasks
+}
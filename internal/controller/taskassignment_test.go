@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Task assignment", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	It("reports the worker of each task from a multi-worker status", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"name":"inventory","connector":{"state":"RUNNING","worker_id":"10.0.0.5:8083"},` +
+				`"tasks":[{"id":2,"state":"RUNNING","worker_id":"10.0.0.7:8083"},{"id":0,"state":"RUNNING","worker_id":"10.0.0.5:8083"},` +
+				`{"id":1,"state":"FAILED","worker_id":"10.0.0.6:8083","trace":"boom"}],"type":"source"}`))
+		}))
+		defer server.Close()
+
+		r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+		status, err := r.getDebeziumConnectorStatus(ctx, server.URL, "inventory")
+		Expect(err).NotTo(HaveOccurred())
+
+		dbc := &apiv1alpha1.DebeziumConnector{}
+		recordTaskAssignment(dbc, status)
+		Expect(dbc.Status.Tasks).To(Equal([]apiv1alpha1.TaskStatus{
+			{ID: 0, State: "RUNNING", WorkerID: "10.0.0.5:8083"},
+			{ID: 1, State: "FAILED", WorkerID: "10.0.0.6:8083"},
+			{ID: 2, State: "RUNNING", WorkerID: "10.0.0.7:8083"},
+		}))
+	})
+
+	It("writes the task assignment to the status on reconcile", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		connect.mu.Lock()
+		connect.tasks["inventory"] = []taskStatus{
+			{ID: 0, State: "RUNNING", WorkerID: "10.0.0.5:8083"},
+			{ID: 1, State: "RUNNING", WorkerID: "10.0.0.5:8083"},
+		}
+		connect.mu.Unlock()
+
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.Tasks).To(HaveLen(2))
+		Expect(latest.Status.Tasks).To(HaveEach(HaveField("WorkerID", "10.0.0.5:8083")))
+	})
+})
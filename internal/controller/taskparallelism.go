@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// connectorTaskCapacity maps known connector classes to the maximum number of tasks they can
+// run in parallel. Zero means the connector can spread work over any number of tasks.
+var connectorTaskCapacity = map[string]int{
+	"io.debezium.connector.postgresql.PostgresConnector": 1,
+	"io.debezium.connector.mysql.MySqlConnector":         1,
+	"io.debezium.connector.mariadb.MariaDbConnector":     1,
+	"io.debezium.connector.oracle.OracleConnector":       1,
+	"io.debezium.connector.db2.Db2Connector":             1,
+	"io.debezium.connector.sqlserver.SqlServerConnector": 0,
+	"io.debezium.connector.mongodb.MongoDbConnector":     0,
+	"io.debezium.connector.jdbc.JdbcSinkConnector":       0,
+}
+
+// taskParallelismCondition evaluates tasks.max against the capability of the connector class and
+// the number of tasks Connect actually started. It returns nil for unknown classes.
+func taskParallelismCondition(config map[string]string, runningTasks int) *metav1.Condition {
+	capacity, known := connectorTaskCapacity[config["connector.class"]]
+	if !known {
+		return nil
+	}
+	tasksMax := 1
+	if raw, ok := config["tasks.max"]; ok {
+		if n, err := strconv.Atoi(raw); err == nil {
+			tasksMax = n
+		}
+	}
+
+	cond := &metav1.Condition{
+		Type:    apiv1alpha1.ConditionTaskParallelism,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AsConfigured",
+		Message: fmt.Sprintf("Connector runs %d of %d configured tasks", runningTasks, tasksMax),
+	}
+	switch {
+	case capacity == 1 && tasksMax > 1:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "SingleTaskConnector"
+		cond.Message = fmt.Sprintf("%s always runs a single task; tasks.max=%d has no effect", config["connector.class"], tasksMax)
+	case capacity == 0 && runningTasks > 0 && runningTasks < tasksMax:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "TasksCapped"
+		cond.Message = fmt.Sprintf("Connector started %d tasks, below tasks.max=%d", runningTasks, tasksMax)
+	}
+	return cond
+}
+
+// reconcileTaskParallelism updates the TaskParallelism condition and emits a warning event
+// when the connector's effective parallelism drops below tasks.max.
+func (r *DebeziumConnectorReconciler) reconcileTaskParallelism(dbc *apiv1alpha1.DebeziumConnector, config map[string]string, runningTasks int) {
+	cond := taskParallelismCondition(config, runningTasks)
+	if cond == nil {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionTaskParallelism)
+		return
+	}
+	cond.ObservedGeneration = dbc.Generation
+	previous := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionTaskParallelism)
+	if cond.Status == metav1.ConditionFalse && (previous == nil || previous.Reason != cond.Reason) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, cond.Reason, "%s", cond.Message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, *cond)
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Task parallelism condition", func() {
+	config := func(class, tasksMax string) map[string]string {
+		config := map[string]string{"name": "inventory", "connector.class": class}
+		if tasksMax != "" {
+			config["tasks.max"] = tasksMax
+		}
+		return config
+	}
+
+	It("flags tasks.max above one for single-task connectors", func() {
+		cond := taskParallelismCondition(config("io.debezium.connector.mysql.MySqlConnector", "4"), 1)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("SingleTaskConnector"))
+		Expect(cond.Message).To(ContainSubstring("tasks.max=4 has no effect"))
+
+		cond = taskParallelismCondition(config("io.debezium.connector.mysql.MySqlConnector", ""), 1)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(Equal("Connector runs 1 of 1 configured tasks"))
+	})
+
+	It("flags parallel connectors that started fewer tasks than configured", func() {
+		cond := taskParallelismCondition(config("io.debezium.connector.sqlserver.SqlServerConnector", "4"), 2)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("TasksCapped"))
+		Expect(cond.Message).To(Equal("Connector started 2 tasks, below tasks.max=4"))
+
+		// No tasks yet is not reported as capped.
+		cond = taskParallelismCondition(config("io.debezium.connector.sqlserver.SqlServerConnector", "4"), 0)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("skips unknown connector classes", func() {
+		Expect(taskParallelismCondition(config("com.example.CustomConnector", "4"), 1)).To(BeNil())
+	})
+
+	It("emits a warning event only when the condition starts failing", func() {
+		recorder := record.NewFakeRecorder(10)
+		r := &DebeziumConnectorReconciler{Recorder: recorder}
+		dbc := newTestDebeziumConnector("http://connect:8083")
+
+		r.reconcileTaskParallelism(dbc, config("io.debezium.connector.mysql.MySqlConnector", "4"), 1)
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning SingleTaskConnector")))
+		r.reconcileTaskParallelism(dbc, config("io.debezium.connector.mysql.MySqlConnector", "4"), 1)
+		Expect(recorder.Events).NotTo(Receive())
+
+		r.reconcileTaskParallelism(dbc, config("com.example.CustomConnector", "4"), 1)
+		Expect(meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionTaskParallelism)).To(BeNil())
+	})
+})
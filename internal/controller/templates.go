@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// Config sources reported in Status.ConfigSources for keys set on the resource itself.
+const (
+	configSourceSpec       = "spec"
+	configSourceAnnotation = "annotation"
+)
+
+// templateRefsField indexes DebeziumConnectors by the names of the templates they reference.
+const templateRefsField = ".spec.templateRefs"
+
+// mergeTemplates returns config merged over the config of the connector's templates, and records
+// in sources the template each added key was taken from. Later templates take precedence over
+// earlier ones, and config over all of them.
+func (r *DebeziumConnectorReconciler) mergeTemplates(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config, sources map[string]string) (map[string]string, error) {
+	if len(dbc.Spec.TemplateRefs) == 0 {
+		return config, nil
+	}
+	merged := make(map[string]string, len(config))
+	for k, v := range config {
+		merged[k] = v
+	}
+	// Walk the templates from the last, so the first template providing a key is the one that wins.
+	for i := len(dbc.Spec.TemplateRefs) - 1; i >= 0; i-- {
+		name := dbc.Spec.TemplateRefs[i]
+		template := &apiv1alpha1.DebeziumConnectorTemplate{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: dbc.Namespace, Name: name}, template); err != nil {
+			return nil, fmt.Errorf("failed to get connector template %s: %w", name, err)
+		}
+		for k, v := range template.Spec.Config {
+			if _, ok := merged[k]; ok {
+				continue
+			}
+			merged[k] = v
+			sources[k] = "template/" + name
+		}
+	}
+	return merged, nil
+}
+
+// findConnectorsForTemplate maps a DebeziumConnectorTemplate to the DebeziumConnectors that
+// reference it.
+func (r *DebeziumConnectorReconciler) findConnectorsForTemplate(ctx context.Context, template client.Object) []reconcile.Request {
+	list := &apiv1alpha1.DebeziumConnectorList{}
+	if err := r.List(ctx, list, client.InNamespace(template.GetNamespace()), client.MatchingFields{templateRefsField: template.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DebeziumConnectors for template", "template", template.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.Namespace, Name: item.Name}})
+	}
+	return requests
+}
+
+// indexTemplateRefs returns the names of the templates a DebeziumConnector references.
+func indexTemplateRefs(obj client.Object) []string {
+	return obj.(*apiv1alpha1.DebeziumConnector).Spec.TemplateRefs
+}
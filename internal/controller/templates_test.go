@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector templates", func() {
+	ctx := context.Background()
+
+	newTemplate := func(name string, config map[string]string) *apiv1alpha1.DebeziumConnectorTemplate {
+		return &apiv1alpha1.DebeziumConnectorTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       apiv1alpha1.DebeziumConnectorTemplateSpec{Config: config},
+		}
+	}
+
+	base := newTemplate("base", map[string]string{
+		"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+		"tasks.max":       "1",
+		"snapshot.mode":   "initial",
+		"database.port":   "3306",
+	})
+	overlay := newTemplate("overlay", map[string]string{
+		"tasks.max":     "2",
+		"snapshot.mode": "when_needed",
+		"topic.prefix":  "inventory",
+	})
+
+	newReconciler := func(objs ...client.Object) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).WithStatusSubresource(objs...).Build(),
+		}
+	}
+
+	newConnector := func() *apiv1alpha1.DebeziumConnector {
+		return &apiv1alpha1.DebeziumConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+			Spec: apiv1alpha1.DebeziumConnectorSpec{
+				DebeziumHost: "http://connect:8083",
+				TemplateRefs: []string{"base", "overlay"},
+				Config:       map[string]string{"name": "inventory", "snapshot.mode": "never"},
+			},
+		}
+	}
+
+	It("applies templates in order with the resource's own config on top", func() {
+		dbc := newConnector()
+		dbc.Annotations = map[string]string{apiv1alpha1.ConfigOverrideAnnotationPrefix + "database.port": "3307"}
+
+		config, sources, err := newReconciler(base, overlay).resolveLayeredConfig(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(Equal(map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"tasks.max":       "2",
+			"snapshot.mode":   "never",
+			"database.port":   "3307",
+			"topic.prefix":    "inventory",
+		}))
+		Expect(sources).To(Equal(map[string]string{
+			"name":            "spec",
+			"connector.class": "template/base",
+			"tasks.max":       "template/overlay",
+			"snapshot.mode":   "spec",
+			"database.port":   "annotation",
+			"topic.prefix":    "template/overlay",
+		}))
+	})
+
+	It("merges the config Secret between the templates and the spec", func() {
+		dbc := newConnector()
+		dbc.Spec.ConfigSecretRef = &corev1.LocalObjectReference{Name: "inventory-config"}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory-config", Namespace: "default"},
+			Data:       map[string][]byte{"tasks.max": []byte("8"), "database.password": []byte("dbz")},
+		}
+
+		config, sources, err := newReconciler(base, overlay, secret).resolveLayeredConfig(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("tasks.max", "8"))
+		Expect(sources).To(HaveKeyWithValue("tasks.max", "secret/inventory-config"))
+		Expect(sources).To(HaveKeyWithValue("database.password", "secret/inventory-config"))
+	})
+
+	It("lets a later template win over an earlier one", func() {
+		dbc := newConnector()
+		dbc.Spec.TemplateRefs = []string{"overlay", "base"}
+
+		config, sources, err := newReconciler(base, overlay).resolveLayeredConfig(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("tasks.max", "1"))
+		Expect(sources).To(HaveKeyWithValue("tasks.max", "template/base"))
+	})
+
+	It("fails when a template is missing", func() {
+		_, err := newReconciler(base).resolveConfig(ctx, newConnector())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("overlay")))
+	})
+
+	It("reports the config sources in the status", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newConnector()
+		dbc.Generation = 1
+		dbc.Finalizers = []string{debeziumFinalizer}
+		dbc.Spec.DebeziumHost = connect.URL
+		r := newReconciler(base, overlay, dbc)
+		r.HTTPClient = connect.Client()
+
+		key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("topic.prefix", "inventory"))
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.ConfigSources).To(HaveKeyWithValue("connector.class", "template/base"))
+		Expect(latest.Status.ConfigSources).To(HaveKeyWithValue("snapshot.mode", "spec"))
+	})
+})
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// connectorTimeToRunning observes how long connectors take from their creation on a Debezium
+// host to the first status check that finds them RUNNING.
+var connectorTimeToRunning = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "debezium_connector_time_to_running_seconds",
+	Help:    "Seconds from the creation of a connector on the Debezium host until it was first seen RUNNING.",
+	Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+}, []string{"host"})
+
+func init() {
+	metrics.Registry.MustRegister(connectorTimeToRunning)
+}
+
+// recordCreated starts measuring the time dbc takes to become RUNNING after it was created on
+// the Debezium host.
+func recordCreated(dbc *apiv1alpha1.DebeziumConnector, now time.Time) {
+	created := metav1.NewTime(now)
+	dbc.Status.CreatedTime = &created
+	dbc.Status.TimeToRunning = nil
+}
+
+// recordTimeToRunning sets Status.TimeToRunning and observes it in the histogram the first time
+// dbc is seen RUNNING after it was created.
+func recordTimeToRunning(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, state string, now time.Time) {
+	if state != "RUNNING" || dbc.Status.CreatedTime == nil || dbc.Status.TimeToRunning != nil {
+		return
+	}
+	elapsed := now.Sub(dbc.Status.CreatedTime.Time)
+	dbc.Status.TimeToRunning = &metav1.Duration{Duration: elapsed}
+	connectorTimeToRunning.WithLabelValues(dbc.Spec.DebeziumHost).Observe(elapsed.Seconds())
+	traceStep(ctx, "connector is RUNNING %s after it was created", elapsed)
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Time to running", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	It("measures from creation to the first RUNNING state only", func() {
+		created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		dbc := newTestDebeziumConnector("http://time-to-running:8083")
+		recordCreated(dbc, created)
+
+		recordTimeToRunning(ctx, dbc, "UNASSIGNED", created.Add(5*time.Second))
+		Expect(dbc.Status.TimeToRunning).To(BeNil())
+
+		observed := testutil.CollectAndCount(connectorTimeToRunning)
+		recordTimeToRunning(ctx, dbc, "RUNNING", created.Add(42*time.Second))
+		Expect(dbc.Status.TimeToRunning.Duration).To(Equal(42 * time.Second))
+		Expect(testutil.CollectAndCount(connectorTimeToRunning)).To(Equal(observed + 1))
+
+		recordTimeToRunning(ctx, dbc, "RUNNING", created.Add(time.Hour))
+		Expect(dbc.Status.TimeToRunning.Duration).To(Equal(42 * time.Second))
+
+		// A recreated connector is measured again.
+		recordCreated(dbc, created.Add(2*time.Hour))
+		Expect(dbc.Status.TimeToRunning).To(BeNil())
+	})
+
+	It("is not measured for connectors the operator did not create", func() {
+		dbc := newTestDebeziumConnector("http://time-to-running:8083")
+		recordTimeToRunning(ctx, dbc, "RUNNING", time.Now())
+		Expect(dbc.Status.TimeToRunning).To(BeNil())
+	})
+
+	It("is recorded when a created connector is first seen RUNNING", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.CreatedTime).NotTo(BeNil())
+		Expect(latest.Status.TimeToRunning).NotTo(BeNil())
+		timeToRunning := *latest.Status.TimeToRunning
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(*latest.Status.TimeToRunning).To(Equal(timeToRunning))
+	})
+})
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// tableListKeys name the tables or collections whose topics Debezium derives from the prefix.
+var tableListKeys = []string{"table.include.list", "collection.include.list"}
+
+// derivedTopicNames returns the sorted topic names Debezium derives from the prefix of config for
+// the tables its include lists name literally, e.g. inventory.public.orders for the entry
+// public.orders. Entries that are patterns cannot be expanded and are left out.
+func derivedTopicNames(prefix string, config map[string]string) []string {
+	set := map[string]bool{}
+	for _, key := range tableListKeys {
+		for _, entry := range strings.Split(config[key], ",") {
+			entry = strings.ReplaceAll(strings.TrimSpace(entry), `\.`, ".")
+			if util.IsTopicNameLiteral(entry) {
+				set[prefix+"."+entry] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// topicNamesCondition checks the topic prefix of config and the topic names derived from it
+// against the Kafka topic naming rules. Connectors without a topic prefix are not checked.
+func topicNamesCondition(config map[string]string) (metav1.Condition, bool) {
+	key, prefix, ok := apiv1alpha1.TopicPrefix(config)
+	if !ok {
+		return metav1.Condition{}, false
+	}
+	cond := metav1.Condition{
+		Type:    apiv1alpha1.ConditionTopicNames,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Valid",
+		Message: fmt.Sprintf("Topic names derived from %s %s are legal Kafka topic names", key, prefix),
+	}
+	if err := util.ValidateTopicPrefix(prefix); err != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InvalidPrefix"
+		cond.Message = fmt.Sprintf("%s %q makes the connector's topic names illegal: %v", key, prefix, err)
+		return cond, true
+	}
+	var invalid []string
+	var firstErr error
+	for _, name := range derivedTopicNames(prefix, config) {
+		if err := util.ValidateTopicName(name); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			invalid = append(invalid, name)
+		}
+	}
+	if len(invalid) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InvalidTopicName"
+		cond.Message = fmt.Sprintf("%d derived topic names are not legal Kafka topic names, e.g. %s: %v", len(invalid), invalid[0], firstErr)
+	}
+	return cond, true
+}
+
+// reconcileTopicNames updates the TopicNames condition and emits a warning event when the
+// connector's topic names become illegal. Debezium only fails once it writes to such a topic, so
+// the config is still applied.
+func (r *DebeziumConnectorReconciler) reconcileTopicNames(dbc *apiv1alpha1.DebeziumConnector, config map[string]string) {
+	cond, ok := topicNamesCondition(config)
+	if !ok {
+		meta.RemoveStatusCondition(&dbc.Status.Conditions, apiv1alpha1.ConditionTopicNames)
+		return
+	}
+	cond.ObservedGeneration = dbc.Generation
+	previous := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionTopicNames)
+	if cond.Status == metav1.ConditionFalse && (previous == nil || previous.Reason != cond.Reason) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "TopicNames"+cond.Reason, "%s", cond.Message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, cond)
+}
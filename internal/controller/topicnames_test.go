@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Topic names condition", func() {
+	It("derives topic names from literal table names only", func() {
+		config := map[string]string{
+			"table.include.list": `public.orders, public\.customers, public.audit_.*`,
+		}
+		Expect(derivedTopicNames("inventory", config)).To(Equal([]string{
+			"inventory.public.customers",
+			"inventory.public.orders",
+		}))
+	})
+
+	It("accepts a legal prefix and table topics", func() {
+		cond, ok := topicNamesCondition(map[string]string{"topic.prefix": "inventory", "table.include.list": "public.orders"})
+		Expect(ok).To(BeTrue())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("reports prefixes that make all topic names illegal", func() {
+		cond, _ := topicNamesCondition(map[string]string{"topic.prefix": "shop eu"})
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("InvalidPrefix"))
+
+		cond, _ = topicNamesCondition(map[string]string{"database.server.name": strings.Repeat("p", 247)})
+		Expect(cond.Reason).To(Equal("InvalidPrefix"))
+		Expect(cond.Message).To(ContainSubstring("database.server.name"))
+	})
+
+	It("reports table topics longer than Kafka allows", func() {
+		cond, _ := topicNamesCondition(map[string]string{
+			"topic.prefix":       strings.Repeat("p", 200),
+			"table.include.list": "public.orders," + "public." + strings.Repeat("t", 50),
+		})
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("InvalidTopicName"))
+		Expect(cond.Message).To(HavePrefix("1 derived topic names"))
+	})
+
+	It("skips connectors without a topic prefix", func() {
+		_, ok := topicNamesCondition(map[string]string{"connector.class": "io.confluent.connect.jdbc.JdbcSinkConnector"})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("emits a warning event when the topic names become illegal", func() {
+		recorder := record.NewFakeRecorder(10)
+		r := &DebeziumConnectorReconciler{Recorder: recorder}
+		dbc := newTestDebeziumConnector("http://connect:8083")
+
+		r.reconcileTopicNames(dbc, map[string]string{"topic.prefix": "inventory"})
+		Expect(recorder.Events).NotTo(Receive())
+		Expect(meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionTopicNames)).To(BeTrue())
+
+		r.reconcileTopicNames(dbc, map[string]string{"topic.prefix": "inventory/eu"})
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning TopicNamesInvalidPrefix")))
+		r.reconcileTopicNames(dbc, map[string]string{"topic.prefix": "inventory/us"})
+		Expect(recorder.Events).NotTo(Receive())
+
+		r.reconcileTopicNames(dbc, map[string]string{})
+		Expect(meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionTopicNames)).To(BeNil())
+	})
+})
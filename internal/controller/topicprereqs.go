@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/kafka"
+)
+
+// missingTopicsRequeue is the delay before checking missing topics again when the connector has
+// no retry interval.
+const missingTopicsRequeue = 30 * time.Second
+
+// missingTopicsError reports topics a connector needs that do not exist on the Kafka brokers.
+type missingTopicsError struct {
+	topics []string
+}
+
+func (e *missingTopicsError) Error() string {
+	return fmt.Sprintf("topics %s do not exist", strings.Join(e.topics, ", "))
+}
+
+// prerequisiteTopics returns the topics config refers to that must exist before the connector
+// is created: the schema history topic and the signal topic, which must have a single partition,
+// and the dead letter queue topic.
+func prerequisiteTopics(config map[string]string) []kafka.Topic {
+	var topics []kafka.Topic
+	for _, key := range []string{"schema.history.internal.kafka.topic", "database.history.kafka.topic"} {
+		if name := config[key]; name != "" {
+			// The schema history must be kept forever; Debezium cannot recover it once deleted.
+			topics = append(topics, kafka.Topic{
+				Name:              name,
+				Partitions:        1,
+				ReplicationFactor: -1,
+				Config:            map[string]string{"cleanup.policy": "delete", "retention.ms": "-1", "retention.bytes": "-1"},
+			})
+			break
+		}
+	}
+	if name := config["signal.kafka.topic"]; name != "" {
+		topics = append(topics, kafka.Topic{Name: name, Partitions: 1, ReplicationFactor: -1})
+	}
+	if name := config["errors.deadletterqueue.topic.name"]; name != "" {
+		replicationFactor := -1
+		if rf, err := strconv.Atoi(config["errors.deadletterqueue.topic.replication.factor"]); err == nil && rf > 0 {
+			replicationFactor = rf
+		}
+		topics = append(topics, kafka.Topic{Name: name, Partitions: -1, ReplicationFactor: replicationFactor})
+	}
+	return topics
+}
+
+// checkPrerequisiteTopics verifies on the Kafka brokers that the topics the connector needs
+// exist before it is created, creating missing ones when CreateMissingTopics is set. The result
+// is reported in the TopicPrerequisites condition; a *missingTopicsError is returned for topics
+// that are still missing.
+func (r *DebeziumConnectorReconciler) checkPrerequisiteTopics(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) error {
+	topics := prerequisiteTopics(config)
+	if r.TopicAdmin == nil || len(topics) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Name)
+	}
+	missing, err := r.TopicAdmin.MissingTopics(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 && r.CreateMissingTopics {
+		var create []kafka.Topic
+		for _, topic := range topics {
+			for _, name := range missing {
+				if topic.Name == name {
+					create = append(create, topic)
+				}
+			}
+		}
+		if err := r.TopicAdmin.CreateTopics(ctx, create); err != nil {
+			return err
+		}
+		r.recordEvent(dbc, corev1.EventTypeNormal, "TopicsCreated", "Created topics %s", strings.Join(missing, ", "))
+		log.FromContext(ctx).Info("Created prerequisite topics", "topics", missing)
+		missing = nil
+	}
+
+	if len(missing) > 0 {
+		err := &missingTopicsError{topics: missing}
+		if !meta.IsStatusConditionFalse(dbc.Status.Conditions, apiv1alpha1.ConditionTopicPrerequisites) {
+			r.recordEvent(dbc, corev1.EventTypeWarning, "TopicsMissing", "Connector was not created: %s", err.Error())
+		}
+		meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+			Type:               apiv1alpha1.ConditionTopicPrerequisites,
+			Status:             metav1.ConditionFalse,
+			Reason:             "TopicsMissing",
+			Message:            fmt.Sprintf("Connector was not created: %s", err.Error()),
+			ObservedGeneration: dbc.Generation,
+		})
+		return err
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionTopicPrerequisites,
+		Status:             metav1.ConditionTrue,
+		Reason:             "TopicsExist",
+		Message:            fmt.Sprintf("Topics %s exist", strings.Join(names, ", ")),
+		ObservedGeneration: dbc.Generation,
+	})
+	return nil
+}
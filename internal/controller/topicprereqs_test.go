@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/kafka"
+)
+
+// fakeTopicAdmin is an in-memory kafka.Admin.
+type fakeTopicAdmin struct {
+	mu      sync.Mutex
+	topics  map[string]kafka.Topic
+	created []kafka.Topic
+	deleted []string
+}
+
+func (f *fakeTopicAdmin) MissingTopics(_ context.Context, names []string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var missing []string
+	for _, name := range names {
+		if _, ok := f.topics[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+func (f *fakeTopicAdmin) CreateTopics(_ context.Context, topics []kafka.Topic) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, topic := range topics {
+		f.topics[topic.Name] = topic
+		f.created = append(f.created, topic)
+	}
+	return nil
+}
+
+func (f *fakeTopicAdmin) DeleteTopics(_ context.Context, names []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, name := range names {
+		delete(f.topics, name)
+		f.deleted = append(f.deleted, name)
+	}
+	return nil
+}
+
+var _ = Describe("Prerequisite topics", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		admin   *fakeTopicAdmin
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		admin = &fakeTopicAdmin{topics: map[string]kafka.Topic{}}
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newConnector := func() *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["schema.history.internal.kafka.topic"] = "schema-changes.inventory"
+		return dbc
+	}
+
+	reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector, create bool) (reconcile.Result, *apiv1alpha1.DebeziumConnector) {
+		r := &DebeziumConnectorReconciler{
+			Client:              fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:          connect.Client(),
+			TopicAdmin:          admin,
+			CreateMissingTopics: create,
+		}
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return result, latest
+	}
+
+	It("lists the topics the connector needs", func() {
+		topics := prerequisiteTopics(map[string]string{
+			"schema.history.internal.kafka.topic":             "history",
+			"signal.kafka.topic":                              "signals",
+			"errors.deadletterqueue.topic.name":               "dlq",
+			"errors.deadletterqueue.topic.replication.factor": "3",
+		})
+		Expect(topics).To(HaveLen(3))
+		Expect(topics[0]).To(HaveField("Partitions", 1))
+		Expect(topics[0].Config).To(HaveKeyWithValue("retention.ms", "-1"))
+		Expect(topics[1]).To(Equal(kafka.Topic{Name: "signals", Partitions: 1, ReplicationFactor: -1}))
+		Expect(topics[2]).To(Equal(kafka.Topic{Name: "dlq", Partitions: -1, ReplicationFactor: 3}))
+		Expect(prerequisiteTopics(map[string]string{"database.history.kafka.topic": "history"})).To(HaveLen(1))
+	})
+
+	It("does not create the connector while a topic is missing", func() {
+		result, latest := reconcileOnce(newConnector(), false)
+		Expect(connect.mutations()).To(BeEmpty())
+		Expect(result.RequeueAfter).To(Equal(missingTopicsRequeue))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionTopicPrerequisites)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("schema-changes.inventory"))
+	})
+
+	It("creates the connector once its topics exist", func() {
+		admin.topics["schema-changes.inventory"] = kafka.Topic{Name: "schema-changes.inventory"}
+		result, latest := reconcileOnce(newConnector(), false)
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(result.RequeueAfter).To(BeNumerically(">", time.Duration(0)))
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionTopicPrerequisites)).To(BeTrue())
+		Expect(admin.created).To(BeEmpty())
+	})
+
+	It("creates missing topics when asked to", func() {
+		_, latest := reconcileOnce(newConnector(), true)
+		Expect(admin.created).To(HaveLen(1))
+		Expect(admin.created[0]).To(HaveField("Name", "schema-changes.inventory"))
+		Expect(admin.created[0]).To(HaveField("Partitions", 1))
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionTopicPrerequisites)).To(BeTrue())
+	})
+
+	It("does not check topics of existing connectors", func() {
+		dbc := newConnector()
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		_, latest := reconcileOnce(dbc, false)
+		Expect(meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionTopicPrerequisites)).To(BeNil())
+	})
+})
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// featureTopics is GET /connectors/{name}/topics (KIP-558).
+var featureTopics = connectFeature{name: "topic tracking", major: 2, minor: 5, unsupportedReason: "TopicTrackingUnsupported"}
+
+// errTopicTrackingDisabled is returned when the worker runs with topic.tracking.enable=false.
+var errTopicTrackingDisabled = errors.New("topic tracking is disabled on the Connect worker")
+
+// getConnectorTopics sends a GET request to retrieve the topics a connector has written to.
+func (r *DebeziumConnectorReconciler) getConnectorTopics(ctx context.Context, host, name string) ([]string, error) {
+	resp, err := r.get(ctx, r.connectURL(host, "/connectors/%s/topics", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET connector topics: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return nil, err
+	}
+	// Connect answers 403 when topic tracking is disabled on the worker.
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, errTopicTrackingDisabled
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GET connector topics returned status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	var topics map[string]struct {
+		Topics []string `json:"topics"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&topics); err != nil {
+		return nil, fmt.Errorf("failed to decode connector topics: %w", err)
+	}
+	result := append([]string{}, topics[name].Topics...)
+	sort.Strings(result)
+	return result, nil
+}
+
+// reconcileTopics populates Status.Topics from the worker's topic tracking. When the worker
+// cannot report topics the list is cleared and the TopicTracking condition explains why; other
+// errors keep the previous list.
+func (r *DebeziumConnectorReconciler) reconcileTopics(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string) {
+	cond := metav1.Condition{
+		Type:               apiv1alpha1.ConditionTopicTracking,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Tracked",
+		Message:            "Topics are reported by the Connect worker",
+		ObservedGeneration: dbc.Generation,
+	}
+	var topics []string
+	err := r.supportsFeature(ctx, dbc.Spec.DebeziumHost, featureTopics)
+	if err == nil {
+		topics, err = r.getConnectorTopics(ctx, dbc.Spec.DebeziumHost, name)
+	}
+	var unsupported *unsupportedFeatureError
+	switch {
+	case errors.As(err, &unsupported):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = featureTopics.unsupportedReason
+		cond.Message = err.Error()
+	case errors.Is(err, errTopicTrackingDisabled):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "TopicTrackingDisabled"
+		cond.Message = "Topic tracking is disabled on the Connect worker (topic.tracking.enable=false)"
+	case err != nil:
+		log.FromContext(ctx).Error(err, "failed to get connector topics", "name", name)
+		return
+	}
+	dbc.Status.Topics = topics
+	meta.SetStatusCondition(&dbc.Status.Conditions, cond)
+}
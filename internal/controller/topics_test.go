@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Connector topics", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileOnce := func(dbc *apiv1alpha1.DebeziumConnector, httpClient *http.Client) *apiv1alpha1.DebeziumConnector {
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: httpClient,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("reports the topics tracked by the worker", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		connect.topics["inventory"] = []string{"inventory.orders", "inventory.customers"}
+
+		latest := reconcileOnce(dbc, connect.Client())
+		Expect(latest.Status.Topics).To(Equal([]string{"inventory.customers", "inventory.orders"}))
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, apiv1alpha1.ConditionTopicTracking)).To(BeTrue())
+	})
+
+	It("leaves topics empty when topic tracking is disabled", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Status.Topics = []string{"inventory.orders"}
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		connect.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/connectors/inventory/topics" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error_code":403,"message":"Topic tracking is disabled."}`))
+				return
+			}
+			connect.serve(w, req)
+		})
+
+		latest := reconcileOnce(dbc, connect.Client())
+		Expect(latest.Status.Topics).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionTopicTracking)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("TopicTrackingDisabled"))
+	})
+
+	It("does not query topics on Connect versions without topic tracking", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.version = "2.4.1"
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+
+		latest := reconcileOnce(dbc, connect.Client())
+		Expect(latest.Status.Topics).To(BeEmpty())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionTopicTracking)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("TopicTrackingUnsupported"))
+		Expect(connect.requests).NotTo(ContainElement("GET /connectors/inventory/topics"))
+	})
+
+	It("keeps the previous topics when the request fails", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		dbc := newTestDebeziumConnector(server.URL)
+		dbc.Status.Topics = []string{"inventory.orders"}
+
+		r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+		r.serverInfo.set(server.URL, connectServerInfo{Version: "3.6.0", fetchedAt: time.Now()})
+		r.reconcileTopics(ctx, dbc, "inventory")
+		Expect(dbc.Status.Topics).To(Equal([]string{"inventory.orders"}))
+		Expect(meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionTopicTracking)).To(BeNil())
+	})
+})
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// reconcileTraceKey is the ConfigMap data key holding the JSON-encoded reconcile trace.
+const reconcileTraceKey = "trace.json"
+
+// maxTraceEntries caps the entries kept per reconcile, so a trace stays well below the ConfigMap
+// size limit. Later entries are dropped and the trace is marked truncated.
+const maxTraceEntries = 200
+
+// Kinds of reconcile trace entries.
+const (
+	traceKindStep     = "step"
+	traceKindCall     = "call"
+	traceKindDecision = "decision"
+)
+
+// traceEntry is a single step, REST call or decision of a reconcile.
+type traceEntry struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"`
+	Message    string    `json:"message,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	DurationMS int64     `json:"durationMs,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// reconcileTrace collects the entries of one reconcile. A nil *reconcileTrace records nothing, so
+// callers don't need to check whether tracing is enabled.
+type reconcileTrace struct {
+	mu        sync.Mutex
+	Started   time.Time    `json:"started"`
+	Finished  time.Time    `json:"finished"`
+	Result    string       `json:"result"`
+	Truncated bool         `json:"truncated,omitempty"`
+	Entries   []traceEntry `json:"entries"`
+}
+
+type reconcileTraceContextKey struct{}
+
+// withReconcileTrace returns a context carrying trace.
+func withReconcileTrace(ctx context.Context, trace *reconcileTrace) context.Context {
+	return context.WithValue(ctx, reconcileTraceContextKey{}, trace)
+}
+
+// reconcileTraceFrom returns the trace carried by ctx, or nil when tracing is disabled.
+func reconcileTraceFrom(ctx context.Context) *reconcileTrace {
+	trace, _ := ctx.Value(reconcileTraceContextKey{}).(*reconcileTrace)
+	return trace
+}
+
+// tracingEnabled reports whether reconciles of dbc are traced.
+func tracingEnabled(dbc *apiv1alpha1.DebeziumConnector) bool {
+	return dbc.Annotations[apiv1alpha1.TraceAnnotation] == "true"
+}
+
+func (t *reconcileTrace) add(entry traceEntry) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.Entries) >= maxTraceEntries {
+		t.Truncated = true
+		return
+	}
+	entry.Time = time.Now().UTC()
+	t.Entries = append(t.Entries, entry)
+}
+
+// traceStep records a step taken by the reconcile traced in ctx.
+func traceStep(ctx context.Context, format string, args ...interface{}) {
+	reconcileTraceFrom(ctx).add(traceEntry{Kind: traceKindStep, Message: fmt.Sprintf(format, args...)})
+}
+
+// traceDecision records a decision made by the reconcile traced in ctx.
+func traceDecision(ctx context.Context, format string, args ...interface{}) {
+	reconcileTraceFrom(ctx).add(traceEntry{Kind: traceKindDecision, Message: fmt.Sprintf(format, args...)})
+}
+
+// do sends req with the reconciler's HTTP client, recording the call in the trace of the request
+// context.
+func (r *DebeziumConnectorReconciler) do(req *http.Request) (*http.Response, error) {
+	trace := reconcileTraceFrom(req.Context())
+	start := time.Now()
+	resp, err := r.doWithHostBreaker(req, func(req *http.Request) (*http.Response, error) {
+		return r.doWithHostLimit(req, r.HTTPClient.Do)
+	})
+	if err == nil {
+		recordRESTStatus(req.Context(), resp.StatusCode)
+	}
+	if trace != nil {
+		entry := traceEntry{
+			Kind:       traceKindCall,
+			Method:     req.Method,
+			URL:        req.URL.Redacted(),
+			DurationMS: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.StatusCode = resp.StatusCode
+		}
+		trace.add(entry)
+	}
+	return resp, err
+}
+
+// get sends a GET request to url.
+func (r *DebeziumConnectorReconciler) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.do(req)
+}
+
+// post sends a POST request with a JSON body to url.
+func (r *DebeziumConnectorReconciler) post(ctx context.Context, url string, data []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return r.do(req)
+}
+
+// reconcileTraceName returns the name of the ConfigMap holding the reconcile trace of a DebeziumConnector.
+func reconcileTraceName(dbc *apiv1alpha1.DebeziumConnector) string {
+	return dbc.Name + "-reconcile-trace"
+}
+
+// finishReconcileTrace records the outcome of the reconcile and writes the trace to the
+// connector's trace ConfigMap, replacing the trace of the previous reconcile. When tracing is
+// disabled a previously written trace is removed.
+func (r *DebeziumConnectorReconciler) finishReconcileTrace(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, trace *reconcileTrace, result string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reconcileTraceName(dbc),
+			Namespace: dbc.Namespace,
+		},
+	}
+	if trace == nil {
+		return r.deleteOwnedConfigMap(ctx, dbc, cm)
+	}
+
+	trace.mu.Lock()
+	trace.Finished = time.Now().UTC()
+	trace.Result = result
+	data, err := json.MarshalIndent(trace, "", "  ")
+	trace.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode reconcile trace: %w", err)
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = util.StandardLabels(dbc.Labels)
+		cm.Data = map[string]string{reconcileTraceKey: string(data)}
+		return controllerutil.SetControllerReference(dbc, cm, r.Scheme())
+	})
+	return err
+}
+
+// reconcileResultString describes the outcome of a reconcile for its trace.
+func reconcileResultString(result ctrl.Result, err error) string {
+	switch {
+	case err != nil:
+		return "error: " + err.Error()
+	case result.RequeueAfter > 0:
+		return "requeue after " + result.RequeueAfter.String()
+	case result.Requeue:
+		return "requeue"
+	}
+	return "done"
+}
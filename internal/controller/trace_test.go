@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Reconcile tracing", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+	traceKey := types.NamespacedName{Name: "inventory-reconcile-trace", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+	}
+
+	readTrace := func(r *DebeziumConnectorReconciler) *reconcileTrace {
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, traceKey, cm)).To(Succeed())
+		trace := &reconcileTrace{}
+		Expect(json.Unmarshal([]byte(cm.Data[reconcileTraceKey]), trace)).To(Succeed())
+		return trace
+	}
+
+	It("records the steps, REST calls and decisions of a create", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{apiv1alpha1.TraceAnnotation: "true"}
+		r := newReconciler(dbc)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		trace := readTrace(r)
+		Expect(trace.Result).To(HavePrefix("requeue after"))
+		Expect(trace.Truncated).To(BeFalse())
+		Expect(trace.Entries).To(ContainElement(And(
+			HaveField("Kind", traceKindStep),
+			HaveField("Message", "resolved config of connector inventory with 3 keys"),
+		)))
+
+		var calls []string
+		var createIndex, decisionIndex int
+		for i, entry := range trace.Entries {
+			if entry.Kind == traceKindCall {
+				calls = append(calls, entry.Method+" "+entry.URL)
+			}
+			if entry.Kind == traceKindCall && entry.Method == http.MethodPost && entry.URL == connect.URL+"/connectors" {
+				createIndex = i
+				Expect(entry.StatusCode).To(Equal(http.StatusCreated))
+			}
+			if entry.Kind == traceKindDecision && entry.Message == "connector inventory does not exist; creating it" {
+				decisionIndex = i
+			}
+		}
+		Expect(calls).To(ContainElements(
+			"GET "+connect.URL+"/connectors/inventory",
+			"POST "+connect.URL+"/connectors",
+			"GET "+connect.URL+"/connectors/inventory/status",
+		))
+		Expect(decisionIndex).To(BeNumerically(">", 0))
+		Expect(createIndex).To(BeNumerically(">", decisionIndex))
+		Expect(trace.Entries).To(ContainElement(And(
+			HaveField("Kind", traceKindCall),
+			HaveField("URL", connect.URL+"/connectors/inventory"),
+			HaveField("StatusCode", http.StatusNotFound),
+		)))
+	})
+
+	It("does not trace without the annotation", func() {
+		r := newReconciler(newTestDebeziumConnector(connect.URL))
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, traceKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("removes the trace once the annotation is removed", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Annotations = map[string]string{apiv1alpha1.TraceAnnotation: "true"}
+		r := newReconciler(dbc)
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		readTrace(r)
+
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		delete(latest.Annotations, apiv1alpha1.TraceAnnotation)
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, traceKey, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("caps the number of entries", func() {
+		trace := &reconcileTrace{}
+		traceCtx := withReconcileTrace(ctx, trace)
+		for i := 0; i < maxTraceEntries+5; i++ {
+			traceStep(traceCtx, "step %d", i)
+		}
+		Expect(trace.Entries).To(HaveLen(maxTraceEntries))
+		Expect(trace.Truncated).To(BeTrue())
+
+		// Steps of untraced reconciles are dropped.
+		traceStep(ctx, "untraced")
+	})
+})
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// ConfigTransformer rewrites the resolved config before it is sent to the Debezium host, e.g.
+// to inject environment-specific settings.
+type ConfigTransformer interface {
+	// Transform returns the rewritten config. It must not modify config.
+	Transform(config map[string]string) (map[string]string, error)
+}
+
+// NopConfigTransformer returns the config unchanged.
+type NopConfigTransformer struct{}
+
+// Transform implements ConfigTransformer.
+func (NopConfigTransformer) Transform(config map[string]string) (map[string]string, error) {
+	return config, nil
+}
+
+// envRefPattern matches ${env:VAR} tokens in config values.
+var envRefPattern = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// EnvConfigTransformer substitutes ${env:VAR} tokens with variables from the operator's
+// environment. Only variables starting with Prefix can be referenced, so connector authors
+// cannot read unrelated operator settings.
+type EnvConfigTransformer struct {
+	// Prefix restricts which variables may be referenced.
+	Prefix string
+	// LookupEnv looks up variables; os.LookupEnv is used when nil.
+	LookupEnv func(string) (string, bool)
+}
+
+// Transform implements ConfigTransformer.
+func (t EnvConfigTransformer) Transform(config map[string]string) (map[string]string, error) {
+	lookup := t.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	transformed := make(map[string]string, len(config))
+	for k, value := range config {
+		var transformErr error
+		transformed[k] = envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			name := envRefPattern.FindStringSubmatch(ref)[1]
+			if !strings.HasPrefix(name, t.Prefix) {
+				transformErr = fmt.Errorf("environment variable %s does not start with the allowed prefix %q", name, t.Prefix)
+				return ref
+			}
+			v, ok := lookup(name)
+			if !ok {
+				transformErr = fmt.Errorf("environment variable %s is not set", name)
+				return ref
+			}
+			return v
+		})
+		if transformErr != nil {
+			return nil, fmt.Errorf("failed to transform config key %q: %w", k, transformErr)
+		}
+	}
+	return transformed, nil
+}
+
+// downwardRefPattern matches ${downward:field} tokens in config values.
+var downwardRefPattern = regexp.MustCompile(`\$\{downward:([A-Za-z]+)\}`)
+
+// DownwardEnv maps the fields that ${downward:field} tokens can reference to the environment
+// variables the operator Deployment sets from the downward API.
+var DownwardEnv = map[string]string{
+	"namespace":          util.PodNamespaceEnv,
+	"podName":            "POD_NAME",
+	"podIP":              "POD_IP",
+	"nodeName":           "NODE_NAME",
+	"serviceAccountName": "POD_SERVICE_ACCOUNT",
+}
+
+// DownwardConfigTransformer substitutes ${downward:field} tokens with metadata of the operator
+// pod, e.g. ${downward:nodeName}, so connectors can be tagged with the cluster identity of the
+// operator that manages them. The fields are listed in DownwardEnv.
+type DownwardConfigTransformer struct {
+	// LookupEnv looks up variables; os.LookupEnv is used when nil.
+	LookupEnv func(string) (string, bool)
+}
+
+// Transform implements ConfigTransformer.
+func (t DownwardConfigTransformer) Transform(config map[string]string) (map[string]string, error) {
+	lookup := t.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	transformed := make(map[string]string, len(config))
+	for k, value := range config {
+		var transformErr error
+		transformed[k] = downwardRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			field := downwardRefPattern.FindStringSubmatch(ref)[1]
+			name, ok := DownwardEnv[field]
+			if !ok {
+				transformErr = fmt.Errorf("unknown downward API field %s", field)
+				return ref
+			}
+			v, ok := lookup(name)
+			if !ok || v == "" {
+				transformErr = fmt.Errorf("downward API field %s is not available: %s is not set", field, name)
+				return ref
+			}
+			return v
+		})
+		if transformErr != nil {
+			return nil, fmt.Errorf("failed to transform config key %q: %w", k, transformErr)
+		}
+	}
+	return transformed, nil
+}
+
+// StripKeysConfigTransformer removes keys starting with one of Prefixes, so operator-only
+// metadata can be kept in Spec.Config without being sent to the Debezium host. Stripped keys are
+// also left out of drift detection, which compares the transformed config.
+type StripKeysConfigTransformer struct {
+	Prefixes []string
+}
+
+// Transform implements ConfigTransformer.
+func (t StripKeysConfigTransformer) Transform(config map[string]string) (map[string]string, error) {
+	transformed := make(map[string]string, len(config))
+	for k, v := range config {
+		if !t.strips(k) {
+			transformed[k] = v
+		}
+	}
+	return transformed, nil
+}
+
+func (t StripKeysConfigTransformer) strips(key string) bool {
+	for _, prefix := range t.Prefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// transformConfig runs the configured transformers over config in order.
+func (r *DebeziumConnectorReconciler) transformConfig(config map[string]string) (map[string]string, error) {
+	for _, transformer := range r.ConfigTransformers {
+		transformed, err := transformer.Transform(config)
+		if err != nil {
+			return nil, err
+		}
+		config = transformed
+	}
+	return config, nil
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// upperNameTransformer is a test transformer that upper-cases the connector name.
+type upperNameTransformer struct{}
+
+func (upperNameTransformer) Transform(config map[string]string) (map[string]string, error) {
+	transformed := map[string]string{}
+	for k, v := range config {
+		transformed[k] = v
+	}
+	transformed["name"] = fmt.Sprintf("%s-PROD", config["name"])
+	return transformed, nil
+}
+
+var _ = Describe("Config transformers", func() {
+	var env map[string]string
+	envTransformer := EnvConfigTransformer{
+		Prefix: "DBZ_",
+		LookupEnv: func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		},
+	}
+
+	BeforeEach(func() {
+		env = map[string]string{"DBZ_BROKERS": "kafka-prod:9092", "OPERATOR_TOKEN": "secret"}
+	})
+
+	It("substitutes environment references", func() {
+		config := map[string]string{"schema.history.internal.kafka.bootstrap.servers": "${env:DBZ_BROKERS}"}
+		transformed, err := envTransformer.Transform(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transformed).To(HaveKeyWithValue("schema.history.internal.kafka.bootstrap.servers", "kafka-prod:9092"))
+		Expect(config).To(HaveKeyWithValue("schema.history.internal.kafka.bootstrap.servers", "${env:DBZ_BROKERS}"))
+	})
+
+	It("rejects variables outside the prefix and unset variables", func() {
+		_, err := envTransformer.Transform(map[string]string{"auth.token": "${env:OPERATOR_TOKEN}"})
+		Expect(err).To(MatchError(ContainSubstring("allowed prefix")))
+		_, err = envTransformer.Transform(map[string]string{"topic.prefix": "${env:DBZ_MISSING}"})
+		Expect(err).To(MatchError(ContainSubstring("DBZ_MISSING is not set")))
+	})
+
+	It("runs the transformer chain in order during config resolution", func() {
+		dbc := newTestDebeziumConnector("http://unused")
+		dbc.Spec.Config["name"] = "${env:DBZ_NAME}"
+		env["DBZ_NAME"] = "inventory"
+		r := &DebeziumConnectorReconciler{
+			ConfigTransformers: []ConfigTransformer{NopConfigTransformer{}, envTransformer, upperNameTransformer{}},
+		}
+		config, err := r.resolveConfig(context.Background(), dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("name", "inventory-PROD"))
+	})
+
+	It("substitutes downward API references", func() {
+		downward := DownwardConfigTransformer{
+			LookupEnv: func(name string) (string, bool) {
+				v, ok := map[string]string{"NODE_NAME": "node-1", "POD_NAMESPACE": "debezium-system"}[name]
+				return v, ok
+			},
+		}
+		config := map[string]string{"name": "inventory", "operator.origin": "${downward:namespace}/${downward:nodeName}"}
+		transformed, err := downward.Transform(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transformed).To(Equal(map[string]string{"name": "inventory", "operator.origin": "debezium-system/node-1"}))
+		Expect(config).To(HaveKeyWithValue("operator.origin", "${downward:namespace}/${downward:nodeName}"))
+
+		_, err = downward.Transform(map[string]string{"operator.origin": "${downward:podName}"})
+		Expect(err).To(MatchError(ContainSubstring("POD_NAME is not set")))
+		_, err = downward.Transform(map[string]string{"operator.origin": "${downward:clusterName}"})
+		Expect(err).To(MatchError(ContainSubstring("unknown downward API field clusterName")))
+	})
+
+	It("strips keys with the configured prefixes without modifying the config", func() {
+		config := map[string]string{"name": "inventory", "operator.owner": "team-a", "operator.ticket": "CDC-12", "topic.prefix": "inventory"}
+		transformed, err := StripKeysConfigTransformer{Prefixes: []string{"operator.", ""}}.Transform(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transformed).To(Equal(map[string]string{"name": "inventory", "topic.prefix": "inventory"}))
+		Expect(config).To(HaveKey("operator.owner"))
+	})
+
+	It("neither sends stripped keys nor treats them as drift", func() {
+		ctx := context.Background()
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["operator.owner"] = "team-a"
+		r := &DebeziumConnectorReconciler{
+			Client:             fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:         connect.Client(),
+			ConfigTransformers: []ConfigTransformer{StripKeysConfigTransformer{Prefixes: []string{"operator."}}},
+		}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dbc)}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(connect.configs["inventory"]).NotTo(HaveKey("operator.owner"))
+
+		// The operator-only key changes, which does not change what is sent.
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, req.NamespacedName, latest)).To(Succeed())
+		latest.Spec.Config["operator.owner"] = "team-b"
+		latest.Generation++
+		Expect(r.Update(ctx, latest)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(r.Get(ctx, req.NamespacedName, latest)).To(Succeed())
+		Expect(latest.Spec.Config).To(HaveKeyWithValue("operator.owner", "team-b"))
+	})
+})
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// rejectTransformChain reports in the Validated condition that the resolved config has
+// transforms and predicates that do not refer to each other consistently, and retries without
+// sending it. The webhook cannot see keys merged from templates or the config Secret, so the
+// check is repeated on the resolved config.
+func (r *DebeziumConnectorReconciler) rejectTransformChain(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, errs field.ErrorList) (ctrl.Result, error) {
+	err := fmt.Errorf("config has inconsistent transforms: %w", errs.ToAggregate())
+	log.FromContext(ctx).Info("Not applying config with inconsistent transforms", "error", err.Error())
+	traceDecision(ctx, "%s", err.Error())
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionValidated,
+		Status:             metav1.ConditionFalse,
+		Reason:             "InvalidTransformChain",
+		Message:            err.Error(),
+		ObservedGeneration: dbc.Generation,
+	})
+	r.recordEvent(dbc, corev1.EventTypeWarning, "InvalidTransformChain", "%s", err.Error())
+	return r.retryAfterFailure(ctx, dbc, err)
+}
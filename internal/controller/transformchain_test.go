@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Transform chain", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var (
+		connect *fakeConnect
+		r       *DebeziumConnectorReconciler
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileWith := func(transforms map[string]string) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		for k, v := range transforms {
+			dbc.Spec.Config[k] = v
+		}
+		r = &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("never sends a config whose transform refers to an undefined predicate", func() {
+		dbc := reconcileWith(map[string]string{
+			"transforms":                  "route",
+			"transforms.route.type":       "org.apache.kafka.connect.transforms.RegexRouter",
+			"transforms.route.predicate":  "isOrders",
+			"predicates":                  "isTombstone",
+			"predicates.isTombstone.type": "org.apache.kafka.connect.transforms.predicates.RecordIsTombstone",
+		})
+		Expect(connect.mutations()).To(BeEmpty())
+		cond := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionValidated)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("InvalidTransformChain"))
+		Expect(cond.Message).To(ContainSubstring(`transform "route" refers to predicate "isOrders"`))
+	})
+
+	It("sends transforms and their predicates in a single request", func() {
+		dbc := reconcileWith(map[string]string{
+			"transforms":                 "route",
+			"transforms.route.type":      "org.apache.kafka.connect.transforms.RegexRouter",
+			"transforms.route.predicate": "isOrders",
+			"predicates":                 "isOrders",
+			"predicates.isOrders.type":   "org.apache.kafka.connect.transforms.predicates.TopicNameMatches",
+		})
+		Expect(connect.mutations()).To(Equal([]string{"POST /connectors"}))
+		Expect(dbc.Status.ConnectorStatus).To(Equal("RUNNING"))
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("transforms.route.predicate", "isOrders"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("predicates.isOrders.type", "org.apache.kafka.connect.transforms.predicates.TopicNameMatches"))
+	})
+})
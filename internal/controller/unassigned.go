@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+// stateUnassigned is reported by Connect for a connector or task that no worker has picked up,
+// e.g. after a rebalance that left workers without capacity.
+const stateUnassigned = "UNASSIGNED"
+
+// unassignedTaskIDs returns the IDs of the tasks no worker runs.
+func unassignedTaskIDs(status *connectorStatus) []string {
+	var ids []string
+	for _, task := range status.Tasks {
+		if task.State == stateUnassigned {
+			ids = append(ids, strconv.Itoa(task.ID))
+		}
+	}
+	return ids
+}
+
+// reconcileUnassigned sets the Unassigned condition from the connector status and emits a warning
+// event when the connector or one of its tasks becomes unassigned. With RestartUnassignedAfter
+// set, a connector that stays unassigned for that long is restarted.
+func (r *DebeziumConnectorReconciler) reconcileUnassigned(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, name string, status *connectorStatus, until time.Time) {
+	taskIDs := unassignedTaskIDs(status)
+	if status.Connector.State != stateUnassigned && len(taskIDs) == 0 {
+		if meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionUnassigned) != nil {
+			meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+				Type:               apiv1alpha1.ConditionUnassigned,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Assigned",
+				Message:            "Connector and tasks are assigned to workers",
+				ObservedGeneration: dbc.Generation,
+			})
+		}
+		return
+	}
+
+	message := "Connector is not assigned to a worker"
+	if status.Connector.State != stateUnassigned {
+		message = fmt.Sprintf("Tasks %s are not assigned to a worker", strings.Join(taskIDs, ", "))
+	}
+	if !meta.IsStatusConditionTrue(dbc.Status.Conditions, apiv1alpha1.ConditionUnassigned) {
+		r.recordEvent(dbc, corev1.EventTypeWarning, "Unassigned", "%s", message)
+	}
+	meta.SetStatusCondition(&dbc.Status.Conditions, metav1.Condition{
+		Type:               apiv1alpha1.ConditionUnassigned,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NoWorkerAssigned",
+		Message:            message,
+		ObservedGeneration: dbc.Generation,
+	})
+
+	cond := meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionUnassigned)
+	if r.RestartUnassignedAfter <= 0 || !r.mutationsAllowed(dbc, until) || time.Since(cond.LastTransitionTime.Time) < r.RestartUnassignedAfter {
+		return
+	}
+	url := r.connectURL(dbc.Spec.DebeziumHost, "/connectors/%s/restart?includeTasks=true", name)
+	if err := r.sendJSON(ctx, http.MethodPost, url, nil, nil); err != nil {
+		log.FromContext(ctx).Error(err, "failed to restart unassigned connector", "name", name)
+		return
+	}
+	r.recordEvent(dbc, corev1.EventTypeNormal, "RestartedUnassigned", "Restarted connector %s after it stayed unassigned for %s", name, r.RestartUnassignedAfter)
+	// Wait another grace period before the next restart.
+	cond.LastTransitionTime = metav1.Now()
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Unassigned connectors", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	It("parses the UNASSIGNED state from the status endpoint", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"name":"inventory","connector":{"state":"UNASSIGNED","worker_id":"10.0.0.5:8083"},` +
+				`"tasks":[{"id":0,"state":"RUNNING","worker_id":"10.0.0.5:8083"},{"id":1,"state":"UNASSIGNED","worker_id":"10.0.0.6:8083"}],"type":"source"}`))
+		}))
+		defer server.Close()
+
+		r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+		status, err := r.getDebeziumConnectorStatus(ctx, server.URL, "inventory")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Connector.State).To(Equal(stateUnassigned))
+		Expect(unassignedTaskIDs(status)).To(Equal([]string{"1"}))
+	})
+
+	var (
+		connect  *fakeConnect
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newReconciler := func(dbc *apiv1alpha1.DebeziumConnector, restartAfter time.Duration) *DebeziumConnectorReconciler {
+		return &DebeziumConnectorReconciler{
+			Client:                 fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient:             connect.Client(),
+			Recorder:               recorder,
+			RestartUnassignedAfter: restartAfter,
+		}
+	}
+
+	reconcileOnce := func(r *DebeziumConnectorReconciler) *apiv1alpha1.DebeziumConnector {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		return latest
+	}
+
+	It("sets the Unassigned condition and emits a warning once", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, stateUnassigned)
+		r := newReconciler(dbc, 0)
+
+		latest := reconcileOnce(r)
+		Expect(latest.Status.ConnectorStatus).To(Equal(stateUnassigned))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUnassigned)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(Equal("Connector is not assigned to a worker"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Warning Unassigned")))
+
+		reconcileOnce(r)
+		Expect(recorder.Events).NotTo(Receive())
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+
+	It("reports unassigned tasks and clears the condition once assigned", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, "RUNNING")
+		connect.tasks["inventory"] = []taskStatus{{ID: 0, State: "RUNNING"}, {ID: 1, State: stateUnassigned}}
+		r := newReconciler(dbc, 0)
+
+		latest := reconcileOnce(r)
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUnassigned)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Message).To(Equal("Tasks 1 are not assigned to a worker"))
+
+		connect.mu.Lock()
+		connect.tasks["inventory"] = []taskStatus{{ID: 0, State: "RUNNING"}, {ID: 1, State: "RUNNING"}}
+		connect.mu.Unlock()
+		latest = reconcileOnce(r)
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, apiv1alpha1.ConditionUnassigned)).To(BeTrue())
+	})
+
+	It("restarts a connector that stays unassigned past the grace period", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Status.Conditions = []metav1.Condition{{
+			Type:               apiv1alpha1.ConditionUnassigned,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoWorkerAssigned",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		}}
+		connect.setConnector(dbc.Spec.Config, stateUnassigned)
+		r := newReconciler(dbc, 5*time.Minute)
+
+		latest := reconcileOnce(r)
+		Expect(connect.mutations()).To(ConsistOf("POST /connectors/inventory/restart"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Normal RestartedUnassigned")))
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionUnassigned)
+		Expect(time.Since(cond.LastTransitionTime.Time)).To(BeNumerically("<", time.Minute))
+	})
+
+	It("does not restart within the grace period", func() {
+		dbc := newTestDebeziumConnector(connect.URL)
+		connect.setConnector(dbc.Spec.Config, stateUnassigned)
+		r := newReconciler(dbc, 5*time.Minute)
+
+		reconcileOnce(r)
+		Expect(connect.mutations()).To(BeEmpty())
+	})
+})
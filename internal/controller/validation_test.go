@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Validated condition", func() {
+	ctx := context.Background()
+
+	var (
+		server *httptest.Server
+		mu     sync.Mutex
+		status int
+		body   string
+	)
+
+	BeforeEach(func() {
+		status, body = http.StatusOK, `{"errors":{}}`
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/" {
+				_, _ = w.Write([]byte(`{"version":"3.6.0","commit":"abc"}`))
+				return
+			}
+			if !strings.HasSuffix(r.URL.Path, "/config/validate") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	respond := func(s int, b string) {
+		mu.Lock()
+		defer mu.Unlock()
+		status, body = s, b
+	}
+
+	validate := func() (*apiv1alpha1.DebeziumConnector, *metav1.Condition) {
+		r := &DebeziumConnectorReconciler{HTTPClient: server.Client()}
+		dbc := newTestDebeziumConnector(server.URL)
+		dbc.Spec.Config["database.password"] = "s3cr3t-pw"
+		config, _ := dbc.DesiredConfig()
+		r.recordValidation(ctx, dbc, config)
+		return dbc, meta.FindStatusCondition(dbc.Status.Conditions, apiv1alpha1.ConditionValidated)
+	}
+
+	It("records an accepted config with the Connect version", func() {
+		dbc, cond := validate()
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("Accepted"))
+		Expect(cond.ObservedGeneration).To(Equal(dbc.Generation))
+		Expect(cond.Message).To(HavePrefix("Configuration accepted by Kafka Connect 3.6.0 at "))
+		Expect(dbc.Status.ConnectVersion).To(Equal("3.6.0"))
+		Expect(dbc.Status.LastValidationTime).NotTo(BeNil())
+	})
+
+	It("lists the rejected keys in order", func() {
+		respond(http.StatusOK, `{"errors":{"database.user":"missing","database.hostname":"missing"}}`)
+		dbc, cond := validate()
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("ValidationFailed"))
+		Expect(cond.Message).To(Equal("Debezium rejected config keys: database.hostname, database.user"))
+		Expect(dbc.Status.LastValidationTime).To(BeNil())
+	})
+
+	It("reports a failing endpoint without the config's secrets", func() {
+		respond(http.StatusInternalServerError, `{"message":"cannot connect with password s3cr3t-pw"}`)
+		_, cond := validate()
+		Expect(cond.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(cond.Reason).To(Equal("ValidationError"))
+		Expect(cond.Message).To(ContainSubstring("status 500"))
+		Expect(cond.Message).NotTo(ContainSubstring("s3cr3t-pw"))
+	})
+
+	It("records a missing validate endpoint as unsupported", func() {
+		respond(http.StatusMethodNotAllowed, "")
+		_, cond := validate()
+		Expect(cond.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(cond.Reason).To(Equal(validationUnsupportedReason))
+	})
+
+	It("does not validate again against a host without the validate endpoint", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		connect.validateStatus = http.StatusMethodNotAllowed
+		dbc := newTestDebeziumConnector(connect.URL)
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+		}
+		key := client.ObjectKeyFromObject(dbc)
+		validations := func() int {
+			connect.mu.Lock()
+			defer connect.mu.Unlock()
+			n := 0
+			for _, req := range connect.requests {
+				if strings.HasSuffix(req, "/config/validate") {
+					n++
+				}
+			}
+			return n
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(validations()).To(Equal(1))
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, apiv1alpha1.ConditionValidated)
+		Expect(cond.Reason).To(Equal(validationUnsupportedReason))
+	})
+})
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"sync"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// pluginValueTypes caches, per connector class, the types of the config keys that are not strings,
+// as reported by the config definitions of the validate endpoint. The zero value is ready to use.
+type pluginValueTypes struct {
+	mu      sync.Mutex
+	classes map[string]map[string]util.ValueType
+}
+
+func (c *pluginValueTypes) get(connectorClass string) map[string]util.ValueType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.classes[connectorClass]
+}
+
+func (c *pluginValueTypes) set(connectorClass string, types map[string]util.ValueType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.classes == nil {
+		c.classes = map[string]map[string]util.ValueType{}
+	}
+	c.classes[connectorClass] = types
+}
+
+// configDefinition is an entry of the "configs" of a validate response.
+type configDefinition struct {
+	Definition struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"definition"`
+}
+
+// recordValueTypes remembers the types of the non-string keys in the config definitions of
+// connectorClass, so their values are normalized like those of ValueTypes.
+func (r *DebeziumConnectorReconciler) recordValueTypes(connectorClass string, configs []configDefinition) {
+	if r.ValueTypes == nil || connectorClass == "" || len(configs) == 0 {
+		return
+	}
+	types := map[string]util.ValueType{}
+	for _, c := range configs {
+		if t := util.ParseValueType(c.Definition.Type); t != util.ValueString && c.Definition.Name != "" {
+			types[c.Definition.Name] = t
+		}
+	}
+	r.pluginValueTypes.set(connectorClass, types)
+}
+
+// normalizeValues returns config with the values of typed keys in their canonical form, e.g.
+// "TRUE" as "true" or "1000.0" as "1000", so values Connect rewrites are not seen as drift. The
+// types reported by the connector's plugin take precedence over ValueTypes. config is returned
+// unchanged when ValueTypes is nil.
+func (r *DebeziumConnectorReconciler) normalizeValues(config map[string]string) map[string]string {
+	if r.ValueTypes == nil {
+		return config
+	}
+	types := r.ValueTypes
+	if learned := r.pluginValueTypes.get(config["connector.class"]); len(learned) > 0 {
+		types = make(map[string]util.ValueType, len(r.ValueTypes)+len(learned))
+		for k, t := range r.ValueTypes {
+			types[k] = t
+		}
+		for k, t := range learned {
+			types[k] = t
+		}
+	}
+	return util.NormalizeValues(config, types)
+}
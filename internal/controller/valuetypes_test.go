@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+var _ = Describe("Typed config values", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	reconcileWith := func(desired, onHost map[string]string, valueTypes map[string]util.ValueType) []string {
+		dbc := newTestDebeziumConnector(connect.URL)
+		hostConfig := map[string]string{}
+		for k, v := range dbc.Spec.Config {
+			hostConfig[k] = v
+		}
+		for k, v := range desired {
+			dbc.Spec.Config[k] = v
+		}
+		for k, v := range onHost {
+			hostConfig[k] = v
+		}
+		if onHost != nil {
+			connect.setConnector(hostConfig, "RUNNING")
+		}
+
+		r := &DebeziumConnectorReconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).WithStatusSubresource(dbc).Build(),
+			HTTPClient: connect.Client(),
+			ValueTypes: valueTypes,
+		}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		return connect.mutations()
+	}
+
+	It("ignores booleans and integers Connect rewrites when normalization is enabled", func() {
+		desired := map[string]string{"tasks.max": "1", "include.schema.changes": "true"}
+		onHost := map[string]string{"tasks.max": "1.0", "include.schema.changes": "TRUE"}
+		Expect(reconcileWith(desired, onHost, util.DefaultValueTypes)).To(BeEmpty())
+	})
+
+	It("still updates values that differ", func() {
+		desired := map[string]string{"tasks.max": "2"}
+		onHost := map[string]string{"tasks.max": "1.0"}
+		Expect(reconcileWith(desired, onHost, util.DefaultValueTypes)).To(ConsistOf("PUT /connectors/inventory/config"))
+	})
+
+	It("compares values exactly when normalization is disabled", func() {
+		desired := map[string]string{"include.schema.changes": "true"}
+		onHost := map[string]string{"include.schema.changes": "TRUE"}
+		Expect(reconcileWith(desired, onHost, nil)).To(ConsistOf("PUT /connectors/inventory/config"))
+	})
+
+	It("sends normalized values", func() {
+		desired := map[string]string{"tasks.max": "1.0", "include.schema.changes": "TRUE", "snapshot.mode": "INITIAL"}
+		Expect(reconcileWith(desired, nil, util.DefaultValueTypes)).To(ConsistOf("POST /connectors"))
+		connect.mu.Lock()
+		defer connect.mu.Unlock()
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("tasks.max", "1"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("include.schema.changes", "true"))
+		Expect(connect.configs["inventory"]).To(HaveKeyWithValue("snapshot.mode", "INITIAL"))
+	})
+
+	It("uses the types of the plugin's config definition", func() {
+		plugin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"errors":{},"configs":[
+				{"definition":{"name":"custom.ratio","type":"DOUBLE"}},
+				{"definition":{"name":"tasks.max","type":"INT"}},
+				{"definition":{"name":"snapshot.mode","type":"STRING"}}]}`))
+		}))
+		defer plugin.Close()
+		r := &DebeziumConnectorReconciler{HTTPClient: plugin.Client(), ValueTypes: map[string]util.ValueType{}}
+		config := map[string]string{
+			"name":            "inventory",
+			"connector.class": "io.debezium.connector.mysql.MySqlConnector",
+			"custom.ratio":    "0.50",
+			"tasks.max":       "1.0",
+			"snapshot.mode":   "INITIAL",
+		}
+		Expect(r.normalizeValues(config)).To(Equal(config))
+
+		_, err := r.validateConnectorConfig(ctx, plugin.URL, config)
+		Expect(err).NotTo(HaveOccurred())
+		normalized := r.normalizeValues(config)
+		Expect(normalized).To(HaveKeyWithValue("custom.ratio", "0.5"))
+		Expect(normalized).To(HaveKeyWithValue("tasks.max", "1"))
+		Expect(normalized).To(HaveKeyWithValue("snapshot.mode", "INITIAL"))
+
+		// Other connector classes keep their own types.
+		config["connector.class"] = "io.debezium.connector.postgresql.PostgresConnector"
+		Expect(r.normalizeValues(config)).To(HaveKeyWithValue("custom.ratio", "0.50"))
+	})
+})
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+	"github.com/oleksandrfrolov95/debezium-operator/internal/util"
+)
+
+// Environment variables that enable and configure the Vault resolver.
+const (
+	VaultAddrEnv      = "VAULT_ADDR"
+	VaultRoleEnv      = "VAULT_ROLE"
+	VaultAuthPathEnv  = "VAULT_AUTH_PATH"
+	VaultTokenFileEnv = "VAULT_SA_TOKEN_FILE"
+)
+
+// defaultServiceAccountTokenFile is the projected token of the operator's service account.
+const defaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// vaultRefPattern matches ${vault:path#key} tokens in config values.
+var vaultRefPattern = regexp.MustCompile(`\$\{vault:([^#}]+)#([^}]+)\}`)
+
+// VaultResolver replaces ${vault:path#key} tokens in config values with secrets read from
+// Vault, logging in with the Kubernetes auth method.
+type VaultResolver struct {
+	// Address is the Vault server URL.
+	Address string
+	// Role is the Vault role bound to the operator's service account.
+	Role string
+	// AuthPath is the mount path of the Kubernetes auth method; "kubernetes" when empty.
+	AuthPath string
+	// TokenFile holds the service account JWT presented to Vault.
+	TokenFile string
+	// HTTPClient is used for Vault requests; http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	token string
+	// tokenExpiry is when the token is renewed; zero for tokens without a lease.
+	tokenExpiry time.Time
+}
+
+// NewVaultResolverFromEnv returns a VaultResolver configured from the environment, or nil
+// when VAULT_ADDR is not set.
+func NewVaultResolverFromEnv() (*VaultResolver, error) {
+	addr := os.Getenv(VaultAddrEnv)
+	if addr == "" {
+		return nil, nil
+	}
+	role := os.Getenv(VaultRoleEnv)
+	if role == "" {
+		return nil, fmt.Errorf("%s must be set when %s is set", VaultRoleEnv, VaultAddrEnv)
+	}
+	tokenFile := os.Getenv(VaultTokenFileEnv)
+	if tokenFile == "" {
+		tokenFile = defaultServiceAccountTokenFile
+	}
+	return &VaultResolver{
+		Address:   strings.TrimSuffix(addr, "/"),
+		Role:      role,
+		AuthPath:  os.Getenv(VaultAuthPathEnv),
+		TokenFile: tokenFile,
+	}, nil
+}
+
+// Resolve implements ConfigResolver.
+func (v *VaultResolver) Resolve(ctx context.Context, dbc *apiv1alpha1.DebeziumConnector, config map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(config))
+	secrets := map[string]map[string]string{}
+	for k, value := range config {
+		var resolveErr error
+		resolved[k] = vaultRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			m := vaultRefPattern.FindStringSubmatch(ref)
+			path, key := m[1], m[2]
+			data, ok := secrets[path]
+			if !ok {
+				var err error
+				if data, err = v.read(ctx, path); err != nil {
+					resolveErr = err
+					return ref
+				}
+				secrets[path] = data
+			}
+			secret, ok := data[key]
+			if !ok {
+				resolveErr = fmt.Errorf("vault secret %s has no key %q", path, key)
+				return ref
+			}
+			return secret
+		})
+		if resolveErr != nil {
+			return nil, fmt.Errorf("failed to resolve config key %q: %w", k, resolveErr)
+		}
+	}
+	return resolved, nil
+}
+
+// read returns the string values of the Vault secret at path, for both KV v1 and v2 engines.
+func (v *VaultResolver) read(ctx context.Context, path string) (map[string]string, error) {
+	token, err := v.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", v.Address, strings.TrimPrefix(path, "/")), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := v.do(req, &secret); err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+
+	// KV v2 nests the secret under data.data next to its metadata.
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	values := make(map[string]string, len(data))
+	for k, val := range data {
+		if s, ok := val.(string); ok {
+			values[k] = s
+		} else {
+			values[k] = fmt.Sprint(val)
+		}
+	}
+	return values, nil
+}
+
+// login returns a Vault token, logging in with the Kubernetes auth method when the cached
+// token is missing or about to expire.
+func (v *VaultResolver) login(ctx context.Context) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.token != "" && (v.tokenExpiry.IsZero() || time.Now().Before(v.tokenExpiry)) {
+		return v.token, nil
+	}
+
+	jwt, err := os.ReadFile(v.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+	authPath := v.AuthPath
+	if authPath == "" {
+		authPath = "kubernetes"
+	}
+	payload, err := json.Marshal(map[string]string{"role": v.Role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/auth/%s/login", v.Address, authPath), bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := v.do(req, &resp); err != nil {
+		return "", fmt.Errorf("vault login failed: %w", err)
+	}
+
+	// Renew ahead of expiry so in-flight reconciles do not use an expired token. A lease
+	// duration of zero means the token does not expire, e.g. a root or periodic token.
+	v.token = resp.Auth.ClientToken
+	v.tokenExpiry = time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		v.tokenExpiry = time.Now().Add(time.Duration(resp.Auth.LeaseDuration) * time.Second * 9 / 10)
+	}
+	return v.token, nil
+}
+
+// do sends req and decodes a successful JSON response into out.
+func (v *VaultResolver) do(req *http.Request, out interface{}) error {
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := util.DecodeResponseBody(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, util.RedactText(string(body), nil))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Vault config resolver", func() {
+	ctx := context.Background()
+
+	var (
+		server *httptest.Server
+		logins atomic.Int32
+		lease  atomic.Int32
+		v      *VaultResolver
+	)
+
+	BeforeEach(func() {
+		logins.Store(0)
+		lease.Store(3600)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/v1/auth/kubernetes/login":
+				var payload map[string]string
+				_ = json.NewDecoder(r.Body).Decode(&payload)
+				if payload["role"] != "debezium" || payload["jwt"] != "sa-jwt" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				logins.Add(1)
+				_, _ = fmt.Fprintf(w, `{"auth":{"client_token":"vault-token","lease_duration":%d}}`, lease.Load())
+			case "/v1/secret/data/inventory":
+				if r.Header.Get("X-Vault-Token") != "vault-token" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":{"data":{"password":"s3cr3t","user":"debezium"},"metadata":{"version":1}}}`))
+			case "/v1/kv/legacy":
+				_, _ = w.Write([]byte(`{"data":{"token":"abc"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+			}
+		}))
+
+		tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+		Expect(os.WriteFile(tokenFile, []byte("sa-jwt\n"), 0o600)).To(Succeed())
+		v = &VaultResolver{Address: server.URL, Role: "debezium", TokenFile: tokenFile, HTTPClient: server.Client()}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("replaces vault references in KV v1 and v2 secrets", func() {
+		resolved, err := v.Resolve(ctx, nil, map[string]string{
+			"database.user":     "${vault:secret/data/inventory#user}",
+			"database.password": "${vault:secret/data/inventory#password}",
+			"auth.token":        "Bearer ${vault:kv/legacy#token}",
+			"database.hostname": "mysql",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved).To(Equal(map[string]string{
+			"database.user":     "debezium",
+			"database.password": "s3cr3t",
+			"auth.token":        "Bearer abc",
+			"database.hostname": "mysql",
+		}))
+		Expect(logins.Load()).To(Equal(int32(1)))
+	})
+
+	It("keeps a token without a lease", func() {
+		lease.Store(0)
+		for i := 0; i < 2; i++ {
+			_, err := v.Resolve(ctx, nil, map[string]string{"database.password": "${vault:secret/data/inventory#password}"})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(logins.Load()).To(Equal(int32(1)))
+	})
+
+	It("fails on a missing key or path", func() {
+		_, err := v.Resolve(ctx, nil, map[string]string{"database.password": "${vault:secret/data/inventory#missing}"})
+		Expect(err).To(MatchError(ContainSubstring(`no key "missing"`)))
+
+		_, err = v.Resolve(ctx, nil, map[string]string{"database.password": "${vault:secret/data/other#password}"})
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	It("is applied after the Secret and spec config are merged", func() {
+		connect := newFakeConnect()
+		defer connect.Close()
+		dbc := newTestDebeziumConnector(connect.URL)
+		dbc.Spec.Config["database.password"] = "${vault:secret/data/inventory#password}"
+		r := &DebeziumConnectorReconciler{
+			Client:          fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc).Build(),
+			ConfigResolvers: []ConfigResolver{v},
+		}
+		config, err := r.resolveConfig(ctx, dbc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config["database.password"]).To(Equal("s3cr3t"))
+	})
+})
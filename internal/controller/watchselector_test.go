@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/oleksandrfrolov95/debezium-operator/api/v1alpha1"
+)
+
+var _ = Describe("Watch label selector", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "inventory", Namespace: "default"}
+
+	var connect *fakeConnect
+
+	BeforeEach(func() {
+		connect = newFakeConnect()
+	})
+
+	AfterEach(func() {
+		connect.Close()
+	})
+
+	newConnector := func(partition string) *apiv1alpha1.DebeziumConnector {
+		dbc := newTestDebeziumConnector(connect.URL)
+		if partition != "" {
+			dbc.Labels = map[string]string{"partition": partition}
+		}
+		return dbc
+	}
+
+	It("filters events of connectors outside the partition", func() {
+		r := &DebeziumConnectorReconciler{WatchSelector: labels.SelectorFromSet(labels.Set{"partition": "a"})}
+		filter := predicate.NewPredicateFuncs(r.watches)
+
+		Expect(filter.Create(event.CreateEvent{Object: newConnector("a")})).To(BeTrue())
+		Expect(filter.Create(event.CreateEvent{Object: newConnector("b")})).To(BeFalse())
+		Expect(filter.Create(event.CreateEvent{Object: newConnector("")})).To(BeFalse())
+		Expect(filter.Update(event.UpdateEvent{ObjectOld: newConnector("a"), ObjectNew: newConnector("b")})).To(BeFalse())
+		Expect(filter.Delete(event.DeleteEvent{Object: newConnector("b")})).To(BeFalse())
+	})
+
+	It("watches all connectors without a selector", func() {
+		r := &DebeziumConnectorReconciler{}
+		Expect(r.watches(newConnector(""))).To(BeTrue())
+	})
+
+	It("ignores a connector outside the partition entirely", func() {
+		dbc := newConnector("b")
+		dbc.Finalizers = nil
+		now := metav1.Now()
+		deleting := newConnector("b")
+		deleting.Name = "deleting"
+		deleting.DeletionTimestamp = &now
+		r := &DebeziumConnectorReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dbc, deleting).WithStatusSubresource(dbc, deleting).Build(),
+			HTTPClient:    connect.Client(),
+			WatchSelector: labels.SelectorFromSet(labels.Set{"partition": "a"}),
+		}
+
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "deleting", Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connect.requests).To(BeEmpty())
+		latest := &apiv1alpha1.DebeziumConnector{}
+		Expect(r.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Finalizers).To(BeEmpty())
+		Expect(r.Get(ctx, types.NamespacedName{Name: "deleting", Namespace: "default"}, latest)).To(Succeed())
+		Expect(latest.Finalizers).To(ConsistOf(debeziumFinalizer))
+	})
+})
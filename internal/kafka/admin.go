@@ -0,0 +1,122 @@
+//go:build kafka
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// admin implements Admin with the segmentio/kafka-go client.
+type admin struct {
+	client *kafkago.Client
+}
+
+// NewAdmin returns an Admin connecting to the brokers of opts.
+func NewAdmin(opts Options) (Admin, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	transport := &kafkago.Transport{DialTimeout: opts.timeout()}
+	if opts.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	mechanism, err := saslMechanism(opts)
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+	return &admin{client: &kafkago.Client{
+		Addr:      kafkago.TCP(opts.BootstrapServers...),
+		Timeout:   opts.timeout(),
+		Transport: transport,
+	}}, nil
+}
+
+// saslMechanism returns the SASL mechanism configured in opts, or nil without SASL.
+func saslMechanism(opts Options) (sasl.Mechanism, error) {
+	switch strings.ToUpper(opts.SASLMechanism) {
+	case SASLPlain:
+		return plain.Mechanism{Username: opts.Username, Password: opts.Password}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, opts.Username, opts.Password)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, opts.Username, opts.Password)
+	}
+	return nil, nil
+}
+
+// MissingTopics implements Admin.
+func (a *admin) MissingTopics(ctx context.Context, names []string) ([]string, error) {
+	resp, err := a.client.Metadata(ctx, &kafkago.MetadataRequest{Topics: names})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topic metadata: %w", err)
+	}
+	existing := make(map[string]bool, len(resp.Topics))
+	for _, topic := range resp.Topics {
+		switch {
+		case topic.Error == nil:
+			existing[topic.Name] = true
+		case errors.Is(topic.Error, kafkago.UnknownTopicOrPartition):
+		default:
+			return nil, fmt.Errorf("failed to read metadata of topic %s: %w", topic.Name, topic.Error)
+		}
+	}
+	var missing []string
+	for _, name := range names {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// CreateTopics implements Admin.
+func (a *admin) CreateTopics(ctx context.Context, topics []Topic) error {
+	req := &kafkago.CreateTopicsRequest{}
+	for _, topic := range topics {
+		config := kafkago.TopicConfig{
+			Topic:             topic.Name,
+			NumPartitions:     topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+		}
+		for name, value := range topic.Config {
+			config.ConfigEntries = append(config.ConfigEntries, kafkago.ConfigEntry{ConfigName: name, ConfigValue: value})
+		}
+		req.Topics = append(req.Topics, config)
+	}
+	resp, err := a.client.CreateTopics(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create topics: %w", err)
+	}
+	var errs []error
+	for name, err := range resp.Errors {
+		if err != nil && !errors.Is(err, kafkago.TopicAlreadyExists) {
+			errs = append(errs, fmt.Errorf("failed to create topic %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteTopics implements Admin.
+func (a *admin) DeleteTopics(ctx context.Context, names []string) error {
+	resp, err := a.client.DeleteTopics(ctx, &kafkago.DeleteTopicsRequest{Topics: names})
+	if err != nil {
+		return fmt.Errorf("failed to delete topics: %w", err)
+	}
+	var errs []error
+	for name, err := range resp.Errors {
+		if err != nil && !errors.Is(err, kafkago.UnknownTopicOrPartition) {
+			errs = append(errs, fmt.Errorf("failed to delete topic %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,11 @@
+//go:build !kafka
+
+package kafka
+
+// NewAdmin returns ErrUnsupported; the Kafka client is only compiled in with the kafka build tag.
+func NewAdmin(opts Options) (Admin, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	return nil, ErrUnsupported
+}
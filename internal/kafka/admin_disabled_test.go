@@ -0,0 +1,15 @@
+//go:build !kafka
+
+package kafka
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewAdmin without the kafka build tag", func() {
+	It("reports that Kafka support is not compiled in", func() {
+		_, err := NewAdmin(Options{BootstrapServers: []string{"kafka:9092"}})
+		Expect(err).To(MatchError(ErrUnsupported))
+	})
+})
@@ -0,0 +1,61 @@
+//go:build kafka
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// These specs run against a real broker, e.g. one started with
+//
+//	docker run -d -p 9092:9092 apache/kafka:3.7.0
+//	KAFKA_BOOTSTRAP_SERVERS=localhost:9092 go test -tags kafka ./internal/kafka/
+var _ = Describe("Admin", func() {
+	ctx := context.Background()
+
+	var admin Admin
+
+	BeforeEach(func() {
+		servers := os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
+		if servers == "" {
+			Skip("KAFKA_BOOTSTRAP_SERVERS is not set")
+		}
+		var err error
+		admin, err = NewAdmin(Options{BootstrapServers: strings.Split(servers, ",")})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("creates and deletes topics", func() {
+		name := fmt.Sprintf("debezium-operator-test-%d", time.Now().UnixNano())
+		missing, err := admin.MissingTopics(ctx, []string{name})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(Equal([]string{name}))
+
+		Expect(admin.CreateTopics(ctx, []Topic{{
+			Name:              name,
+			Partitions:        1,
+			ReplicationFactor: -1,
+			Config:            map[string]string{"retention.ms": "-1"},
+		}})).To(Succeed())
+		Eventually(func() ([]string, error) {
+			return admin.MissingTopics(ctx, []string{name})
+		}).WithTimeout(10 * time.Second).Should(BeEmpty())
+
+		// Creating an existing topic is not an error.
+		Expect(admin.CreateTopics(ctx, []Topic{{Name: name, Partitions: 1, ReplicationFactor: -1}})).To(Succeed())
+
+		Expect(admin.DeleteTopics(ctx, []string{name})).To(Succeed())
+		Eventually(func() ([]string, error) {
+			return admin.MissingTopics(ctx, []string{name})
+		}).WithTimeout(10 * time.Second).Should(Equal([]string{name}))
+		// Deleting a missing topic is not an error.
+		Expect(admin.DeleteTopics(ctx, []string{name})).To(Succeed())
+	})
+})
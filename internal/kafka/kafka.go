@@ -0,0 +1,85 @@
+// Package kafka checks and creates the Kafka topics connectors need, talking to the brokers
+// directly instead of through Kafka Connect. The client is only compiled into the operator when
+// it is built with the kafka build tag.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Topic describes a topic a connector needs before it is created.
+type Topic struct {
+	Name string
+	// Partitions is the number of partitions; -1 uses the broker default.
+	Partitions int
+	// ReplicationFactor is the number of replicas; -1 uses the broker default.
+	ReplicationFactor int
+	// Config holds topic-level config, e.g. retention.ms.
+	Config map[string]string
+}
+
+// Admin checks and creates topics on the Kafka brokers.
+type Admin interface {
+	// MissingTopics returns the names of the topics that do not exist, in the order given.
+	MissingTopics(ctx context.Context, names []string) ([]string, error)
+	// CreateTopics creates the topics; topics that already exist are not an error.
+	CreateTopics(ctx context.Context, topics []Topic) error
+	// DeleteTopics deletes the topics; topics that do not exist are not an error.
+	DeleteTopics(ctx context.Context, names []string) error
+}
+
+// SASL mechanisms accepted in Options.SASLMechanism.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// Options configures the connection to the Kafka brokers.
+type Options struct {
+	// BootstrapServers are the host:port addresses of the brokers.
+	BootstrapServers []string
+	// SASLMechanism is PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512; SASL is not used when empty.
+	SASLMechanism string
+	// Username and Password authenticate with the SASL mechanism.
+	Username string
+	Password string
+	// TLS encrypts the connections to the brokers, verifying them with the system roots.
+	TLS bool
+	// Timeout bounds each request to the brokers; DefaultTimeout is used when zero.
+	Timeout time.Duration
+}
+
+// DefaultTimeout bounds requests to the brokers when Options.Timeout is not set.
+const DefaultTimeout = 10 * time.Second
+
+// ErrUnsupported is returned by NewAdmin when the operator was built without the kafka tag.
+var ErrUnsupported = errors.New("the operator was built without Kafka support; rebuild it with -tags kafka")
+
+// validate checks that opts can be used to connect to the brokers.
+func (opts Options) validate() error {
+	if len(opts.BootstrapServers) == 0 {
+		return errors.New("no Kafka bootstrap servers configured")
+	}
+	switch strings.ToUpper(opts.SASLMechanism) {
+	case "":
+	case SASLPlain, SASLScramSHA256, SASLScramSHA512:
+		if opts.Username == "" {
+			return fmt.Errorf("SASL mechanism %s requires a username", opts.SASLMechanism)
+		}
+	default:
+		return fmt.Errorf("unsupported SASL mechanism %q", opts.SASLMechanism)
+	}
+	return nil
+}
+
+func (opts Options) timeout() time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return DefaultTimeout
+}
@@ -0,0 +1,26 @@
+package kafka
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+	It("requires bootstrap servers", func() {
+		Expect(Options{}.validate()).To(MatchError(ContainSubstring("bootstrap servers")))
+	})
+
+	It("accepts the supported SASL mechanisms", func() {
+		for _, mechanism := range []string{"", SASLPlain, SASLScramSHA256, "scram-sha-512"} {
+			opts := Options{BootstrapServers: []string{"kafka:9092"}, SASLMechanism: mechanism, Username: "operator"}
+			Expect(opts.validate()).To(Succeed(), mechanism)
+		}
+	})
+
+	It("rejects unknown SASL mechanisms and missing usernames", func() {
+		opts := Options{BootstrapServers: []string{"kafka:9092"}, SASLMechanism: "GSSAPI", Username: "operator"}
+		Expect(opts.validate()).To(MatchError(ContainSubstring("unsupported SASL mechanism")))
+		opts = Options{BootstrapServers: []string{"kafka:9092"}, SASLMechanism: SASLPlain}
+		Expect(opts.validate()).To(MatchError(ContainSubstring("requires a username")))
+	})
+})
@@ -0,0 +1,194 @@
+// Package operatorconfig reads the operator settings from a YAML file, as an alternative to
+// passing them all as command-line flags, and reloads the file when it changes.
+package operatorconfig
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Config holds the settings of the operator config file. Each key is the name of the flag it
+// sets, e.g.
+//
+//	deep-check-every: 5
+//	restart-failed-cooldown: 10m
+//	watch-label-selector: team=data
+//
+// Settings missing from the file keep the value of their flag.
+type Config struct {
+	PauseReconciliation    *bool            `json:"pause-reconciliation,omitempty"`
+	DeepCheckEvery         *int             `json:"deep-check-every,omitempty"`
+	OperationTimeout       *metav1.Duration `json:"connector-operation-timeout,omitempty"`
+	CreateConfirmTimeout   *metav1.Duration `json:"create-confirm-timeout,omitempty"`
+	RestartUnassignedAfter *metav1.Duration `json:"restart-unassigned-after,omitempty"`
+	RestartFailedCooldown  *metav1.Duration `json:"restart-failed-cooldown,omitempty"`
+	ConfigHistoryLimit     *int             `json:"config-history-limit,omitempty"`
+	StateChangeEvents      *bool            `json:"state-change-events,omitempty"`
+
+	WatchLabelSelector         *string          `json:"watch-label-selector,omitempty"`
+	RequireManagedTag          *bool            `json:"require-managed-tag,omitempty"`
+	ConnectBasePath            *string          `json:"connect-base-path,omitempty"`
+	ConnectRequestTimeout      *metav1.Duration `json:"connect-request-timeout,omitempty"`
+	NormalizeListValues        *bool            `json:"normalize-list-values,omitempty"`
+	NormalizeTypedValues       *bool            `json:"normalize-typed-values,omitempty"`
+	ReferencedSecretsOnly      *bool            `json:"referenced-secrets-only,omitempty"`
+	StripConfigKeyPrefixes     *string          `json:"strip-config-key-prefixes,omitempty"`
+	ConfigEnvPrefix            *string          `json:"config-env-prefix,omitempty"`
+	RedactKeyPattern           *string          `json:"redact-key-pattern,omitempty"`
+	ConfigSizeLimit            *int             `json:"config-size-limit,omitempty"`
+	RejectOversizedConfig      *bool            `json:"reject-oversized-config,omitempty"`
+	DetectOrphans              *bool            `json:"detect-orphans,omitempty"`
+	PruneOrphans               *bool            `json:"prune-orphans,omitempty"`
+	OrphanNamePrefix           *string          `json:"orphan-name-prefix,omitempty"`
+	OrphanDetectionInterval    *metav1.Duration `json:"orphan-detection-interval,omitempty"`
+	WebhookValidationTimeout   *metav1.Duration `json:"webhook-validation-timeout,omitempty"`
+	WebhookBestEffort          *bool            `json:"webhook-validation-best-effort,omitempty"`
+	DisableRemoteValidation    *bool            `json:"disable-remote-validation,omitempty"`
+	WarnOnTopicPrefixCollision *bool            `json:"warn-on-topic-prefix-collision,omitempty"`
+	KafkaBootstrapServers      *string          `json:"kafka-bootstrap-servers,omitempty"`
+	CreateMissingTopics        *bool            `json:"create-missing-topics,omitempty"`
+	StateChangeWebhookURL      *string          `json:"state-change-webhook-url,omitempty"`
+	HostFailureThreshold       *int             `json:"host-failure-threshold,omitempty"`
+	ProbeSchemaRegistry        *bool            `json:"probe-schema-registry,omitempty"`
+	MaxConcurrentReconciles    *int             `json:"max-concurrent-reconciles,omitempty"`
+	MaxConcurrentHostOps       *int             `json:"max-concurrent-host-operations,omitempty"`
+	SchemaRegistryProbeTimeout *metav1.Duration `json:"schema-registry-probe-timeout,omitempty"`
+	SchemaRegistryProbeHosts   *string          `json:"schema-registry-probe-hosts,omitempty"`
+	HostBreakerCooldown        *metav1.Duration `json:"host-breaker-cooldown,omitempty"`
+}
+
+// Reloadable are the flags whose values are applied while the operator runs when the config
+// file changes. Changes to any other setting take effect after a restart.
+var Reloadable = []string{
+	"pause-reconciliation",
+	"deep-check-every",
+	"connector-operation-timeout",
+	"create-confirm-timeout",
+	"restart-unassigned-after",
+	"restart-failed-cooldown",
+	"config-history-limit",
+	"state-change-events",
+}
+
+// Parse parses a config file. Unknown keys are rejected, so a misspelled setting is not
+// silently ignored.
+func Parse(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("invalid operator config: %w", err)
+	}
+	return c, nil
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator config: %w", err)
+	}
+	return Parse(data)
+}
+
+// Values returns the settings in c as flag values, keyed by flag name.
+func (c *Config) Values() map[string]string {
+	values := map[string]string{}
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		switch value := field.Interface().(type) {
+		case *bool:
+			values[name] = strconv.FormatBool(*value)
+		case *int:
+			values[name] = strconv.Itoa(*value)
+		case *string:
+			values[name] = *value
+		case *metav1.Duration:
+			values[name] = value.Duration.String()
+		}
+	}
+	return values
+}
+
+// Flags applies config files to a parsed flag set. Flags given on the command line take
+// precedence over the file.
+type Flags struct {
+	fs       *flag.FlagSet
+	explicit map[string]bool
+}
+
+// NewFlags returns Flags for fs, which must already be parsed.
+func NewFlags(fs *flag.FlagSet) *Flags {
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	return &Flags{fs: fs, explicit: explicit}
+}
+
+// Apply sets the flags to the values in c. When names are given, only those flags are set, and
+// the ones missing from c are reset to their defaults, so removing a setting from the file
+// undoes it. It returns the names of the flags whose values changed.
+func (f *Flags) Apply(c *Config, names ...string) ([]string, error) {
+	values := c.Values()
+	if len(names) == 0 {
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	var changed []string
+	for _, name := range names {
+		fl := f.fs.Lookup(name)
+		if fl == nil {
+			return nil, fmt.Errorf("operator config sets unknown flag %q", name)
+		}
+		if f.explicit[name] {
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			value = fl.DefValue
+		}
+		previous := fl.Value.String()
+		if err := f.fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid %s in operator config: %w", name, err)
+		}
+		if fl.Value.String() != previous {
+			changed = append(changed, name)
+		}
+	}
+	return changed, nil
+}
+
+// RestartRequired returns the names of the settings that differ between previous and next but
+// are not Reloadable, and so only take effect after a restart.
+func RestartRequired(previous, next *Config) []string {
+	reloadable := map[string]bool{}
+	for _, name := range Reloadable {
+		reloadable[name] = true
+	}
+	before, after := previous.Values(), next.Values()
+	var names []string
+	for name := range before {
+		if _, ok := after[name]; !ok && !reloadable[name] {
+			names = append(names, name)
+		}
+	}
+	for name, value := range after {
+		if previousValue, ok := before[name]; (!ok || previousValue != value) && !reloadable[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
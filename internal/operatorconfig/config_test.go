@@ -0,0 +1,103 @@
+package operatorconfig
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	// newFlagSet returns a flag set with a few of the operator's flags, parsed from args.
+	newFlagSet := func(args ...string) (*flag.FlagSet, *int, *time.Duration, *string) {
+		fs := flag.NewFlagSet("operator", flag.ContinueOnError)
+		deepCheckEvery := fs.Int("deep-check-every", 10, "")
+		cooldown := fs.Duration("restart-failed-cooldown", 5*time.Minute, "")
+		selector := fs.String("watch-label-selector", "", "")
+		fs.Bool("pause-reconciliation", false, "")
+		Expect(fs.Parse(args)).To(Succeed())
+		return fs, deepCheckEvery, cooldown, selector
+	}
+
+	It("parses settings keyed by flag name", func() {
+		c, err := Parse([]byte("deep-check-every: 5\nrestart-failed-cooldown: 10m\nwatch-label-selector: team=data\npause-reconciliation: true\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*c.DeepCheckEvery).To(Equal(5))
+		Expect(c.RestartFailedCooldown.Duration).To(Equal(10 * time.Minute))
+		Expect(c.Values()).To(Equal(map[string]string{
+			"deep-check-every":        "5",
+			"restart-failed-cooldown": "10m0s",
+			"watch-label-selector":    "team=data",
+			"pause-reconciliation":    "true",
+		}))
+	})
+
+	It("rejects unknown settings and invalid values", func() {
+		_, err := Parse([]byte("deep-check-evry: 5\n"))
+		Expect(err).To(MatchError(ContainSubstring("deep-check-evry")))
+		_, err = Parse([]byte("restart-failed-cooldown: soon\n"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("loads a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte("config-history-limit: 3\n"), 0o600)).To(Succeed())
+		c, err := Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*c.ConfigHistoryLimit).To(Equal(3))
+
+		_, err = Load(filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fills in the flags not given on the command line", func() {
+		fs, deepCheckEvery, cooldown, selector := newFlagSet("--deep-check-every=20")
+		c, err := Parse([]byte("deep-check-every: 5\nrestart-failed-cooldown: 10m\nwatch-label-selector: team=data\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		changed, err := NewFlags(fs).Apply(c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(Equal([]string{"restart-failed-cooldown", "watch-label-selector"}))
+		Expect(*deepCheckEvery).To(Equal(20))
+		Expect(*cooldown).To(Equal(10 * time.Minute))
+		Expect(*selector).To(Equal("team=data"))
+	})
+
+	It("rejects settings without a flag", func() {
+		fs, _, _, _ := newFlagSet()
+		c, err := Parse([]byte("kafka-bootstrap-servers: kafka:9092\n"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = NewFlags(fs).Apply(c)
+		Expect(err).To(MatchError(ContainSubstring("kafka-bootstrap-servers")))
+	})
+
+	It("reloads only the given flags and resets removed settings", func() {
+		fs, deepCheckEvery, cooldown, selector := newFlagSet()
+		flags := NewFlags(fs)
+		c, err := Parse([]byte("deep-check-every: 5\nrestart-failed-cooldown: 10m\n"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = flags.Apply(c)
+		Expect(err).NotTo(HaveOccurred())
+
+		next, err := Parse([]byte("restart-failed-cooldown: 1m\nwatch-label-selector: team=data\n"))
+		Expect(err).NotTo(HaveOccurred())
+		changed, err := flags.Apply(next, "deep-check-every", "restart-failed-cooldown", "pause-reconciliation")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(Equal([]string{"deep-check-every", "restart-failed-cooldown"}))
+		Expect(*deepCheckEvery).To(Equal(10))
+		Expect(*cooldown).To(Equal(time.Minute))
+		Expect(*selector).To(BeEmpty())
+	})
+
+	It("lists the changes that require a restart", func() {
+		previous, err := Parse([]byte("deep-check-every: 5\nwatch-label-selector: team=data\ndetect-orphans: true\n"))
+		Expect(err).NotTo(HaveOccurred())
+		next, err := Parse([]byte("deep-check-every: 2\nwatch-label-selector: team=billing\nconnect-base-path: /connect\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(RestartRequired(previous, next)).To(Equal([]string{"connect-base-path", "detect-orphans", "watch-label-selector"}))
+		Expect(RestartRequired(next, next)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,82 @@
+package operatorconfig
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultReloadInterval is how often a Watcher checks the config file when Interval is zero.
+const DefaultReloadInterval = 10 * time.Second
+
+// Watcher reloads the config file whenever its content changes. The file is polled rather than
+// watched for events, since a ConfigMap mounted as a volume is updated by swapping a symlink,
+// which file event watchers on the file itself miss.
+type Watcher struct {
+	// Path is the config file.
+	Path string
+	// Interval is the time between two checks of the file; DefaultReloadInterval when zero.
+	Interval time.Duration
+	// OnChange is called with the new config after the file changed. A file that cannot be read
+	// or parsed is logged and ignored, so the last valid config stays in effect.
+	OnChange func(ctx context.Context, c *Config)
+
+	last []byte
+}
+
+// NewWatcher returns a Watcher that calls onChange once the file at path differs from its
+// current content.
+func NewWatcher(path string, onChange func(ctx context.Context, c *Config)) (*Watcher, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{Path: path, OnChange: onChange, last: data}, nil
+}
+
+// NeedLeaderElection is false, so every replica applies the new config.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the file every Interval until ctx is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check reloads the file when its content changed since the last check.
+func (w *Watcher) check(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("operatorconfig")
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		logger.Error(err, "failed to read operator config", "path", w.Path)
+		return
+	}
+	if bytes.Equal(data, w.last) {
+		return
+	}
+	w.last = data
+	c, err := Parse(data)
+	if err != nil {
+		logger.Error(err, "ignoring invalid operator config", "path", w.Path)
+		return
+	}
+	logger.Info("Operator config changed", "path", w.Path)
+	w.OnChange(ctx, c)
+}
@@ -0,0 +1,78 @@
+package operatorconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		path    string
+		changes chan *Config
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte("deep-check-every: 5\n"), 0o600)).To(Succeed())
+		changes = make(chan *Config, 10)
+	})
+
+	newWatcher := func() *Watcher {
+		w, err := NewWatcher(path, func(_ context.Context, c *Config) { changes <- c })
+		Expect(err).NotTo(HaveOccurred())
+		return w
+	}
+
+	It("reports a changed file once", func() {
+		w := newWatcher()
+		w.check(context.Background())
+		Expect(changes).NotTo(Receive())
+
+		Expect(os.WriteFile(path, []byte("deep-check-every: 2\n"), 0o600)).To(Succeed())
+		w.check(context.Background())
+		var c *Config
+		Expect(changes).To(Receive(&c))
+		Expect(*c.DeepCheckEvery).To(Equal(2))
+
+		w.check(context.Background())
+		Expect(changes).NotTo(Receive())
+	})
+
+	It("keeps the last valid config when the file is invalid or missing", func() {
+		w := newWatcher()
+		Expect(os.WriteFile(path, []byte("deep-check-every: often\n"), 0o600)).To(Succeed())
+		w.check(context.Background())
+		Expect(changes).NotTo(Receive())
+
+		Expect(os.Remove(path)).To(Succeed())
+		w.check(context.Background())
+		Expect(changes).NotTo(Receive())
+
+		Expect(os.WriteFile(path, []byte("deep-check-every: 3\n"), 0o600)).To(Succeed())
+		w.check(context.Background())
+		Expect(changes).To(Receive())
+	})
+
+	It("polls the file until stopped", func() {
+		w := newWatcher()
+		w.Interval = 10 * time.Millisecond
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- w.Start(ctx) }()
+
+		Expect(os.WriteFile(path, []byte("pause-reconciliation: true\n"), 0o600)).To(Succeed())
+		Eventually(changes).Should(Receive())
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("requires the file to exist", func() {
+		_, err := NewWatcher(filepath.Join(GinkgoT().TempDir(), "missing.yaml"), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,7 +1,11 @@
 package util
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -9,88 +13,309 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ServiceDNSNames returns the names a Service is reachable by from within the cluster, from the
+// short name to the fully qualified one.
+func ServiceDNSNames(service, namespace string) []string {
+	return []string{
+		service,
+		fmt.Sprintf("%s.%s", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+	}
+}
+
+// certSANs splits the common name and extra hosts into DNS and IP subject alternative names,
+// dropping blanks and duplicates.
+func certSANs(commonName string, hosts []string) ([]string, []net.IP) {
+	var dnsNames []string
+	var ips []net.IP
+	seen := map[string]bool{}
+	for _, host := range append([]string{commonName}, hosts...) {
+		host = strings.TrimSpace(host)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		if ip := net.ParseIP(host); ip != nil {
+			ips = append(ips, ip)
+		} else {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	return dnsNames, ips
+}
+
+// Key types of a generated certificate.
+const (
+	KeyTypeRSA   = "rsa"
+	KeyTypeECDSA = "ecdsa"
+)
+
+// CertKey selects the private key of a generated certificate: the RSA modulus size in bits, or
+// the ECDSA curve size (256 or 384). The zero value is DefaultCertKey.
+type CertKey struct {
+	Type string
+	Size int
+}
+
+// DefaultCertKey is the RSA 2048 key the operator has always generated.
+var DefaultCertKey = CertKey{Type: KeyTypeRSA, Size: 2048}
+
+// ParseCertKey parses a key option of the form "rsa-2048", "rsa-4096", "ecdsa-p256" or "ecdsa-p384".
+func ParseCertKey(s string) (CertKey, error) {
+	keyType, size, _ := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "-")
+	key := CertKey{Type: keyType}
+	switch keyType {
+	case KeyTypeRSA:
+		key.Size, _ = strconv.Atoi(size)
+	case KeyTypeECDSA:
+		key.Size, _ = strconv.Atoi(strings.TrimPrefix(size, "p"))
+	}
+	if _, err := key.generate(); err != nil {
+		return CertKey{}, fmt.Errorf("invalid certificate key %q: %w", s, err)
+	}
+	return key, nil
+}
+
+// generate returns a new private key.
+func (k CertKey) generate() (crypto.Signer, error) {
+	if k == (CertKey{}) {
+		k = DefaultCertKey
+	}
+	switch k.Type {
+	case KeyTypeRSA:
+		if k.Size != 2048 && k.Size != 3072 && k.Size != 4096 {
+			return nil, fmt.Errorf("unsupported RSA key size %d (use 2048, 3072 or 4096)", k.Size)
+		}
+		return rsa.GenerateKey(rand.Reader, k.Size)
+	case KeyTypeECDSA:
+		switch k.Size {
+		case 256:
+			return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		case 384:
+			return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		}
+		return nil, fmt.Errorf("unsupported ECDSA curve P-%d (use P-256 or P-384)", k.Size)
+	}
+	return nil, fmt.Errorf("unsupported key type %q (use rsa or ecdsa)", k.Type)
+}
+
+// privateKeyPEM encodes a private key in the PEM block matching its type.
+func privateKeyPEM(priv crypto.Signer) (*pem.Block, error) {
+	switch key := priv.(type) {
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}, nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", priv)
+}
+
 // GenerateSelfSignedCert generates a new self-signed certificate and writes the files to certDir.
-func GenerateSelfSignedCert(certDir, commonName string) error {
-	keyPath := filepath.Join(certDir, "tls.key")
-	certPath := filepath.Join(certDir, "tls.crt")
+// The common name and hosts are included as subject alternative names; hosts that parse as IP
+// addresses are added as IP SANs.
+func GenerateSelfSignedCert(certDir, commonName string, key CertKey, hosts ...string) error {
+	return GenerateCert(certDir, commonName, key, nil, hosts...)
+}
 
-	// Generate a new RSA private key.
-	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+// GenerateCert generates a new certificate signed by ca, or self-signed when ca is nil, and
+// writes the files to certDir. SANs are set as for GenerateSelfSignedCert.
+func GenerateCert(certDir, commonName string, key CertKey, ca *CertAuthority, hosts ...string) error {
+	// Generate a new private key.
+	priv, err := key.generate()
 	if err != nil {
 		return fmt.Errorf("failed to generate private key: %w", err)
 	}
+	keyBlock, err := privateKeyPEM(priv)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	// Key encipherment only applies to RSA key exchange.
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, ok := priv.(*rsa.PrivateKey); ok {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
 
 	// Create a certificate template with SANs.
+	dnsNames, ips := certSANs(commonName, hosts)
 	template := x509.Certificate{
 		SerialNumber: big.NewInt(time.Now().UnixNano()),
 		Subject: pkix.Name{
 			CommonName: commonName,
 		},
-		DNSNames:              []string{commonName},
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
 		NotBefore:             time.Now(),
 		NotAfter:              time.Now().Add(365 * 24 * time.Hour), // 1 year validity
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		KeyUsage:              keyUsage,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 	}
 
-	// Self-sign the certificate.
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	// Sign the certificate with the CA, or self-sign it. A CA-signed certificate must not outlive the CA.
+	parent, signer := &template, crypto.Signer(priv)
+	if ca != nil {
+		parent, signer = ca.Cert, ca.Key
+		if template.NotAfter.After(ca.Cert.NotAfter) {
+			template.NotAfter = ca.Cert.NotAfter
+		}
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, parent, priv.Public(), signer)
 	if err != nil {
 		return fmt.Errorf("failed to create certificate: %w", err)
 	}
 
-	// Write certificate to file.
-	certOut, err := os.Create(certPath)
-	if err != nil {
-		return fmt.Errorf("failed to open %s for writing: %w", certPath, err)
+	// Write the certificate and key files together.
+	certData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	return writeCertFiles(certDir, certData, pem.EncodeToMemory(keyBlock))
+}
+
+// certDataLink points at the directory holding the current certificate and key. tls.crt and
+// tls.key are links through it, so swapping the link publishes both files at once, the way the
+// kubelet updates Secret volumes.
+const certDataLink = "..data"
+
+// certFiles are the files published in certDir.
+var certFiles = []string{"tls.crt", "tls.key"}
+
+// writeCertFiles writes certificate and key data into files in certDir. Both files are written
+// to a new directory that is then swapped in, so a reader never sees a certificate with a key
+// from a different pair.
+func writeCertFiles(certDir string, certData, keyData []byte) error {
+	if err := linkCertFiles(certDir); err != nil {
+		return err
 	}
-	defer certOut.Close()
-	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
-		return fmt.Errorf("failed to write certificate to %s: %w", certPath, err)
+	return publishCertFiles(certDir, map[string][]byte{"tls.crt": certData, "tls.key": keyData})
+}
+
+// linkCertFiles replaces plain tls.crt and tls.key files, e.g. written by an earlier version,
+// with links through certDataLink. The existing pair is published first so the contents do not
+// change while the links are replaced.
+func linkCertFiles(certDir string) error {
+	linked := true
+	for _, name := range certFiles {
+		if target, err := os.Readlink(filepath.Join(certDir, name)); err != nil || target != filepath.Join(certDataLink, name) {
+			linked = false
+		}
 	}
+	if linked {
+		return nil
+	}
+	current := map[string][]byte{}
+	for _, name := range certFiles {
+		data, err := os.ReadFile(filepath.Join(certDir, name))
+		if err == nil {
+			current[name] = data
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", filepath.Join(certDir, name), err)
+		}
+	}
+	if err := publishCertFiles(certDir, current); err != nil {
+		return err
+	}
+	for _, name := range certFiles {
+		if err := replaceSymlink(filepath.Join(certDataLink, name), filepath.Join(certDir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Write private key to file.
-	keyOut, err := os.Create(keyPath)
+// publishCertFiles writes files to a new directory in certDir, points certDataLink at it and
+// removes the previous directories.
+func publishCertFiles(certDir string, files map[string][]byte) error {
+	dataDir, err := os.MkdirTemp(certDir, "..cert_")
 	if err != nil {
-		return fmt.Errorf("failed to open %s for writing: %w", keyPath, err)
+		return fmt.Errorf("failed to create certificate directory in %s: %w", certDir, err)
+	}
+	for name, data := range files {
+		mode := os.FileMode(0644)
+		if name == "tls.key" {
+			mode = 0600
+		}
+		path := filepath.Join(dataDir, name)
+		if err := os.WriteFile(path, data, mode); err != nil {
+			_ = os.RemoveAll(dataDir)
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
 	}
-	defer keyOut.Close()
-	if err := pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
-		return fmt.Errorf("failed to write private key to %s: %w", keyPath, err)
+	if err := replaceSymlink(filepath.Base(dataDir), filepath.Join(certDir, certDataLink)); err != nil {
+		_ = os.RemoveAll(dataDir)
+		return err
 	}
 
+	// Removing the previous files also signals file watchers to reload.
+	previous, err := filepath.Glob(filepath.Join(certDir, "..cert_*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range previous {
+		if dir != dataDir {
+			if err := os.RemoveAll(dir); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", dir, err)
+			}
+		}
+	}
 	return nil
 }
 
-// writeCertFiles writes certificate and key data into files in certDir.
-func writeCertFiles(certDir string, certData, keyData []byte) error {
-	certPath := filepath.Join(certDir, "tls.crt")
-	keyPath := filepath.Join(certDir, "tls.key")
-
-	if err := os.WriteFile(certPath, certData, 0644); err != nil {
-		return fmt.Errorf("failed to write certificate to %s: %w", certPath, err)
+// replaceSymlink atomically points path at target by renaming a new link over it.
+func replaceSymlink(target, path string) error {
+	tmp := path + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("failed to link %s: %w", path, err)
 	}
-	if err := os.WriteFile(keyPath, keyData, 0600); err != nil {
-		return fmt.Errorf("failed to write key to %s: %w", keyPath, err)
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
 	}
 	return nil
 }
 
+// SyncCertFiles writes the certificate and key of a TLS secret to certDir when they differ from
+// the files already there, and reports whether the files were changed.
+func SyncCertFiles(certDir string, secret *corev1.Secret) (bool, error) {
+	certData, certOk := secret.Data["tls.crt"]
+	keyData, keyOk := secret.Data["tls.key"]
+	if !certOk || !keyOk {
+		return false, fmt.Errorf("secret %s does not contain tls.crt and tls.key", secret.Name)
+	}
+	currentCert, certErr := os.ReadFile(filepath.Join(certDir, "tls.crt"))
+	currentKey, keyErr := os.ReadFile(filepath.Join(certDir, "tls.key"))
+	if certErr == nil && keyErr == nil && bytes.Equal(currentCert, certData) && bytes.Equal(currentKey, keyData) {
+		return false, nil
+	}
+	if err := writeCertFiles(certDir, certData, keyData); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // LoadOrGenerateCert checks for an existing cert secret and writes its contents to certDir.
-// If the secret doesn't exist, it generates a new certificate and creates the secret.
-func LoadOrGenerateCert(ctx context.Context, c client.Client, namespace, secretName, certDir, commonName string) error {
+// If the secret doesn't exist, or was not issued by ca when one is given, it generates a new
+// certificate with key for commonName and hosts and stores it in the secret. The secret's
+// ca.crt entry holds the CA certificate of a CA-signed certificate.
+func LoadOrGenerateCert(ctx context.Context, c client.Client, namespace, secretName, certDir, commonName string, key CertKey, ca *CertAuthority, hosts ...string) error {
 	// Ensure the cert directory exists.
 	if err := os.MkdirAll(certDir, 0755); err != nil {
 		return fmt.Errorf("failed to create cert directory %s: %w", certDir, err)
@@ -98,57 +323,80 @@ func LoadOrGenerateCert(ctx context.Context, c client.Client, namespace, secretN
 
 	secret := &corev1.Secret{}
 	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret)
-	if err == nil {
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get certificate secret: %w", err)
+	}
+	exists := err == nil
+	if exists {
 		// Secret exists; extract certificate and key.
 		certData, certOk := secret.Data["tls.crt"]
 		keyData, keyOk := secret.Data["tls.key"]
 		if !certOk || !keyOk {
 			return fmt.Errorf("secret %s exists but does not contain tls.crt and tls.key", secretName)
 		}
-		// Write certificate and key files to certDir.
-		return writeCertFiles(certDir, certData, keyData)
-	} else if apierrors.IsNotFound(err) {
-		// Secret does not exist; generate a new certificate.
-		if err := GenerateSelfSignedCert(certDir, commonName); err != nil {
-			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
-		}
-		// Read the generated certificate and key.
-		certData, err := os.ReadFile(filepath.Join(certDir, "tls.crt"))
-		if err != nil {
-			return fmt.Errorf("failed to read generated certificate: %w", err)
+		// Reissue a certificate that was self-signed or signed by another CA once a CA is configured.
+		if ca == nil || issuedBy(certData, ca) {
+			// Write certificate and key files to certDir.
+			return writeCertFiles(certDir, certData, keyData)
 		}
-		keyData, err := os.ReadFile(filepath.Join(certDir, "tls.key"))
-		if err != nil {
-			return fmt.Errorf("failed to read generated key: %w", err)
-		}
-		// Create the certificate secret.
-		newSecret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      secretName,
-				Namespace: namespace,
-			},
-			Data: map[string][]byte{
-				"tls.crt": certData,
-				"tls.key": keyData,
-			},
-			Type: corev1.SecretTypeTLS,
-		}
-		if err := c.Create(ctx, newSecret); err != nil {
-			return fmt.Errorf("failed to create certificate secret: %w", err)
+	}
+
+	// Generate a new certificate.
+	if err := GenerateCert(certDir, commonName, key, ca, hosts...); err != nil {
+		return fmt.Errorf("failed to generate certificate: %w", err)
+	}
+	// Read the generated certificate and key.
+	certData, err := os.ReadFile(filepath.Join(certDir, "tls.crt"))
+	if err != nil {
+		return fmt.Errorf("failed to read generated certificate: %w", err)
+	}
+	keyData, err := os.ReadFile(filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		return fmt.Errorf("failed to read generated key: %w", err)
+	}
+	data := map[string][]byte{
+		"tls.crt": certData,
+		"tls.key": keyData,
+	}
+	if ca != nil {
+		data["ca.crt"] = ca.CertPEM
+	}
+
+	if exists {
+		secret.Data = data
+		if err := c.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to update certificate secret: %w", err)
 		}
 		return nil
-	} else {
-		return fmt.Errorf("failed to get certificate secret: %w", err)
 	}
+	// Create the certificate secret.
+	newSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+			Labels:    StandardLabels(nil),
+		},
+		Data: data,
+		Type: corev1.SecretTypeTLS,
+	}
+	if err := c.Create(ctx, newSecret); err != nil {
+		return fmt.Errorf("failed to create certificate secret: %w", err)
+	}
+	return nil
 }
 
+// UpdateWebhookCABundle sets the caBundle of a webhook to the CA certificate in the TLS secret's
+// ca.crt entry, or to the self-signed certificate in tls.crt when there is none.
 func UpdateWebhookCABundle(ctx context.Context, c client.Client, webhookName string, vwcName string, secretNamespace, secretName string) error {
 	// Retrieve the TLS secret.
 	secret := &corev1.Secret{}
 	if err := c.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: secretName}, secret); err != nil {
 		return fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, secretName, err)
 	}
-	caBundle, ok := secret.Data["tls.crt"]
+	caBundle, ok := secret.Data["ca.crt"]
+	if !ok || len(caBundle) == 0 {
+		caBundle, ok = secret.Data["tls.crt"]
+	}
 	if !ok || len(caBundle) == 0 {
 		return fmt.Errorf("secret %s/%s does not contain a valid tls.crt", secretNamespace, secretName)
 	}
@@ -177,3 +425,66 @@ func UpdateWebhookCABundle(ctx context.Context, c client.Client, webhookName str
 	}
 	return nil
 }
+
+// WebhookPolicy overrides how the API server treats a webhook, so availability can be traded for
+// strictness without editing the ValidatingWebhookConfiguration by hand.
+type WebhookPolicy struct {
+	// FailurePolicy is Fail or Ignore; the configured policy is kept when empty.
+	FailurePolicy admissionregistrationv1.FailurePolicyType
+	// NamespaceSelector limits the webhook to matching namespaces; the configured selector is kept
+	// when nil.
+	NamespaceSelector *metav1.LabelSelector
+}
+
+// ParseFailurePolicy parses a webhook failure policy, "Fail" or "Ignore" in any case. An empty
+// value keeps the configured policy.
+func ParseFailurePolicy(value string) (admissionregistrationv1.FailurePolicyType, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return "", nil
+	case "fail":
+		return admissionregistrationv1.Fail, nil
+	case "ignore":
+		return admissionregistrationv1.Ignore, nil
+	}
+	return "", fmt.Errorf("unsupported webhook failure policy %q: use Fail or Ignore", value)
+}
+
+// UpdateWebhookPolicy applies policy to the webhook named webhookName. The configuration is only
+// updated when it differs.
+func UpdateWebhookPolicy(ctx context.Context, c client.Client, webhookName string, vwcName string, policy WebhookPolicy) error {
+	if policy.FailurePolicy == "" && policy.NamespaceSelector == nil {
+		return nil
+	}
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: vwcName}, vwc); err != nil {
+		return fmt.Errorf("failed to get ValidatingWebhookConfiguration %s: %w", vwcName, err)
+	}
+
+	found, changed := false, false
+	for i, wh := range vwc.Webhooks {
+		if wh.Name != webhookName {
+			continue
+		}
+		found = true
+		if policy.FailurePolicy != "" && (wh.FailurePolicy == nil || *wh.FailurePolicy != policy.FailurePolicy) {
+			failurePolicy := policy.FailurePolicy
+			vwc.Webhooks[i].FailurePolicy = &failurePolicy
+			changed = true
+		}
+		if policy.NamespaceSelector != nil && !equality.Semantic.DeepEqual(wh.NamespaceSelector, policy.NamespaceSelector) {
+			vwc.Webhooks[i].NamespaceSelector = policy.NamespaceSelector.DeepCopy()
+			changed = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("webhook with name %q not found in ValidatingWebhookConfiguration %s", webhookName, vwcName)
+	}
+	if !changed {
+		return nil
+	}
+	if err := c.Update(ctx, vwc); err != nil {
+		return fmt.Errorf("failed to update ValidatingWebhookConfiguration %s: %w", vwcName, err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GenerateSelfSignedCert", func() {
+	parseCert := func(dir string) *x509.Certificate {
+		data, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+		Expect(err).NotTo(HaveOccurred())
+		block, _ := pem.Decode(data)
+		Expect(block).NotTo(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+		return cert
+	}
+
+	It("includes every form of the service name and extra IPs as SANs", func() {
+		dir := GinkgoT().TempDir()
+		hosts := append(ServiceDNSNames("debezium-operator", "cdc"), "webhook.example.com", "10.0.0.12", " ", "")
+		Expect(GenerateSelfSignedCert(dir, "debezium-operator.cdc.svc", DefaultCertKey, hosts...)).To(Succeed())
+
+		cert := parseCert(dir)
+		Expect(cert.Subject.CommonName).To(Equal("debezium-operator.cdc.svc"))
+		Expect(cert.DNSNames).To(Equal([]string{
+			"debezium-operator.cdc.svc",
+			"debezium-operator",
+			"debezium-operator.cdc",
+			"debezium-operator.cdc.svc.cluster.local",
+			"webhook.example.com",
+		}))
+		Expect(cert.IPAddresses).To(HaveLen(1))
+		Expect(cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.12"))).To(BeTrue())
+		for _, name := range cert.DNSNames {
+			Expect(cert.VerifyHostname(name)).To(Succeed(), name)
+		}
+		Expect(cert.VerifyHostname("10.0.0.12")).To(Succeed())
+	})
+
+	It("uses the common name as the only SAN without extra hosts", func() {
+		dir := GinkgoT().TempDir()
+		Expect(GenerateSelfSignedCert(dir, "debezium-operator.cdc.svc", CertKey{})).To(Succeed())
+		Expect(parseCert(dir).DNSNames).To(Equal([]string{"debezium-operator.cdc.svc"}))
+	})
+
+	It("generates each supported key type with the matching PEM block", func() {
+		for option, expected := range map[string]struct {
+			blockType string
+			algorithm x509.PublicKeyAlgorithm
+			size      int
+		}{
+			"rsa-2048":   {"RSA PRIVATE KEY", x509.RSA, 2048},
+			"RSA-4096":   {"RSA PRIVATE KEY", x509.RSA, 4096},
+			"ecdsa-p256": {"EC PRIVATE KEY", x509.ECDSA, 256},
+			"ecdsa-p384": {"EC PRIVATE KEY", x509.ECDSA, 384},
+		} {
+			key, err := ParseCertKey(option)
+			Expect(err).NotTo(HaveOccurred(), option)
+			dir := GinkgoT().TempDir()
+			Expect(GenerateSelfSignedCert(dir, "debezium-operator.cdc.svc", key)).To(Succeed(), option)
+
+			cert := parseCert(dir)
+			Expect(cert.PublicKeyAlgorithm).To(Equal(expected.algorithm), option)
+			switch pub := cert.PublicKey.(type) {
+			case *rsa.PublicKey:
+				Expect(pub.N.BitLen()).To(Equal(expected.size), option)
+				Expect(cert.KeyUsage & x509.KeyUsageKeyEncipherment).NotTo(BeZero())
+			case *ecdsa.PublicKey:
+				Expect(pub.Curve.Params().BitSize).To(Equal(expected.size), option)
+				Expect(cert.KeyUsage & x509.KeyUsageKeyEncipherment).To(BeZero())
+			}
+
+			keyData, err := os.ReadFile(filepath.Join(dir, "tls.key"))
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode(keyData)
+			Expect(block.Type).To(Equal(expected.blockType), option)
+			certData, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tls.X509KeyPair(certData, keyData)
+			Expect(err).NotTo(HaveOccurred(), option)
+		}
+	})
+
+	It("rejects unsupported key options", func() {
+		for _, option := range []string{"rsa-1024", "ecdsa-p521", "ed25519", "rsa"} {
+			_, err := ParseCertKey(option)
+			Expect(err).To(HaveOccurred(), option)
+		}
+	})
+})
+
+var _ = Describe("writeCertFiles", func() {
+	// newPair returns the certificate and key data of a freshly generated certificate.
+	newPair := func() ([]byte, []byte) {
+		dir := GinkgoT().TempDir()
+		Expect(GenerateSelfSignedCert(dir, "debezium-operator.cdc.svc", CertKey{Type: KeyTypeECDSA, Size: 256})).To(Succeed())
+		certData, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+		Expect(err).NotTo(HaveOccurred())
+		keyData, err := os.ReadFile(filepath.Join(dir, "tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		return certData, keyData
+	}
+
+	It("replaces plain cert files with links to the current pair", func() {
+		certDir := GinkgoT().TempDir()
+		oldCert, oldKey := newPair()
+		Expect(os.WriteFile(filepath.Join(certDir, "tls.crt"), oldCert, 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(certDir, "tls.key"), oldKey, 0600)).To(Succeed())
+
+		newCert, newKey := newPair()
+		Expect(writeCertFiles(certDir, newCert, newKey)).To(Succeed())
+		Expect(os.ReadFile(filepath.Join(certDir, "tls.crt"))).To(Equal(newCert))
+		Expect(os.ReadFile(filepath.Join(certDir, "tls.key"))).To(Equal(newKey))
+		Expect(os.Readlink(filepath.Join(certDir, "tls.key"))).To(Equal(filepath.Join(certDataLink, "tls.key")))
+		info, err := os.Stat(filepath.Join(certDir, "tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		// Only the directory of the current pair is kept.
+		dirs, err := filepath.Glob(filepath.Join(certDir, "..cert_*"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dirs).To(HaveLen(1))
+	})
+
+	It("never exposes a certificate with the key of another pair", func() {
+		certDir := GinkgoT().TempDir()
+		certA, keyA := newPair()
+		certB, keyB := newPair()
+		Expect(writeCertFiles(certDir, certA, keyA)).To(Succeed())
+
+		done := make(chan struct{})
+		writeErrs := make(chan error, 1)
+		go func() {
+			defer close(writeErrs)
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				certData, keyData := certA, keyA
+				if i%2 == 0 {
+					certData, keyData = certB, keyB
+				}
+				if err := writeCertFiles(certDir, certData, keyData); err != nil {
+					writeErrs <- err
+					return
+				}
+			}
+		}()
+
+		checked := 0
+		for i := 0; i < 2000; i++ {
+			certData, certErr := os.ReadFile(filepath.Join(certDir, "tls.crt"))
+			keyData, keyErr := os.ReadFile(filepath.Join(certDir, "tls.key"))
+			again, againErr := os.ReadFile(filepath.Join(certDir, "tls.crt"))
+			// A pair was swapped in, or a previous one removed, between the reads; a watcher
+			// reloads on the event of that change.
+			if os.IsNotExist(certErr) || os.IsNotExist(keyErr) || os.IsNotExist(againErr) || !bytes.Equal(certData, again) {
+				continue
+			}
+			Expect(certErr).NotTo(HaveOccurred())
+			Expect(keyErr).NotTo(HaveOccurred())
+			_, err := tls.X509KeyPair(certData, keyData)
+			Expect(err).NotTo(HaveOccurred())
+			checked++
+		}
+		close(done)
+		Expect(<-writeErrs).To(Succeed())
+		Expect(checked).To(BeNumerically(">", 0))
+	})
+})
+
+var _ = Describe("UpdateWebhookPolicy", func() {
+	ctx := context.Background()
+
+	var c client.Client
+
+	BeforeEach(func() {
+		fail := admissionregistrationv1.Fail
+		c = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "vwc"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{Name: "webhook", FailurePolicy: &fail},
+				{Name: "other", FailurePolicy: &fail},
+			},
+		}).Build()
+	})
+
+	get := func() *admissionregistrationv1.ValidatingWebhookConfiguration {
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "vwc"}, vwc)).To(Succeed())
+		return vwc
+	}
+
+	It("sets the failure policy and namespace selector of the named webhook only", func() {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"debezium.io/webhook": "enabled"}}
+		Expect(UpdateWebhookPolicy(ctx, c, "webhook", "vwc", WebhookPolicy{
+			FailurePolicy:     admissionregistrationv1.Ignore,
+			NamespaceSelector: selector,
+		})).To(Succeed())
+
+		vwc := get()
+		Expect(*vwc.Webhooks[0].FailurePolicy).To(Equal(admissionregistrationv1.Ignore))
+		Expect(vwc.Webhooks[0].NamespaceSelector).To(Equal(selector))
+		Expect(*vwc.Webhooks[1].FailurePolicy).To(Equal(admissionregistrationv1.Fail))
+		Expect(vwc.Webhooks[1].NamespaceSelector).To(BeNil())
+	})
+
+	It("does not update an unchanged configuration", func() {
+		before := get().ResourceVersion
+		Expect(UpdateWebhookPolicy(ctx, c, "webhook", "vwc", WebhookPolicy{FailurePolicy: admissionregistrationv1.Fail})).To(Succeed())
+		Expect(UpdateWebhookPolicy(ctx, c, "webhook", "vwc", WebhookPolicy{})).To(Succeed())
+		Expect(get().ResourceVersion).To(Equal(before))
+	})
+
+	It("fails for an unknown webhook", func() {
+		err := UpdateWebhookPolicy(ctx, c, "missing", "vwc", WebhookPolicy{FailurePolicy: admissionregistrationv1.Ignore})
+		Expect(err).To(MatchError(ContainSubstring(`webhook with name "missing" not found`)))
+	})
+
+	It("parses failure policies", func() {
+		Expect(ParseFailurePolicy("ignore")).To(Equal(admissionregistrationv1.Ignore))
+		Expect(ParseFailurePolicy("Fail")).To(Equal(admissionregistrationv1.Fail))
+		Expect(ParseFailurePolicy("")).To(BeEmpty())
+		_, err := ParseFailurePolicy("retry")
+		Expect(err).To(MatchError(ContainSubstring("unsupported webhook failure policy")))
+	})
+})
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertAuthority is a CA that signs the webhook certificate instead of the certificate signing
+// itself.
+type CertAuthority struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// CertPEM is the PEM encoded CA certificate, used as the webhook caBundle.
+	CertPEM []byte
+}
+
+// ParseCertAuthority parses a PEM encoded CA certificate and its private key.
+func ParseCertAuthority(certPEM, keyPEM []byte) (*CertAuthority, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("CA certificate is not a PEM encoded certificate")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("certificate %q is not a CA certificate", cert.Subject.CommonName)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("CA key is not PEM encoded")
+	}
+	key, err := parsePrivateKey(keyBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	if !publicKeysEqual(cert.PublicKey, key.Public()) {
+		return nil, fmt.Errorf("CA key does not match the CA certificate")
+	}
+	return &CertAuthority{Cert: cert, Key: key, CertPEM: pem.EncodeToMemory(certBlock)}, nil
+}
+
+// LoadCertAuthority reads a CA certificate and key from the tls.crt and tls.key, or ca.crt and
+// ca.key, entries of a Secret.
+func LoadCertAuthority(ctx context.Context, c client.Reader, namespace, name string) (*CertAuthority, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get CA secret %s/%s: %w", namespace, name, err)
+	}
+	for _, keys := range [][2]string{{"tls.crt", "tls.key"}, {"ca.crt", "ca.key"}} {
+		certPEM, certOk := secret.Data[keys[0]]
+		keyPEM, keyOk := secret.Data[keys[1]]
+		if certOk && keyOk {
+			ca, err := ParseCertAuthority(certPEM, keyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CA secret %s/%s: %w", namespace, name, err)
+			}
+			return ca, nil
+		}
+	}
+	return nil, fmt.Errorf("CA secret %s/%s does not contain tls.crt and tls.key or ca.crt and ca.key", namespace, name)
+}
+
+// parsePrivateKey parses a PKCS #1, SEC 1 or PKCS #8 private key.
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+}
+
+// publicKeysEqual reports whether two public keys are the same.
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	aDER, errA := x509.MarshalPKIXPublicKey(a)
+	bDER, errB := x509.MarshalPKIXPublicKey(b)
+	return errA == nil && errB == nil && bytes.Equal(aDER, bDER)
+}
+
+// issuedBy reports whether the PEM encoded certificate was signed by ca.
+func issuedBy(certPEM []byte, ca *CertAuthority) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return err == nil && cert.CheckSignatureFrom(ca.Cert) == nil
+}
@@ -0,0 +1,157 @@
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestCA returns the PEM encoded certificate and key of a new CA.
+func newTestCA(isCA bool) ([]byte, []byte) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Internal CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(30 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+var _ = Describe("Webhook certificate CA", func() {
+	ctx := context.Background()
+	const namespace = "cdc"
+
+	readCert := func(data []byte) *x509.Certificate {
+		block, _ := pem.Decode(data)
+		Expect(block).NotTo(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+		return cert
+	}
+
+	It("issues a certificate that verifies against the CA", func() {
+		caPEM, caKeyPEM := newTestCA(true)
+		ca, err := ParseCertAuthority(caPEM, caKeyPEM)
+		Expect(err).NotTo(HaveOccurred())
+
+		dir := GinkgoT().TempDir()
+		Expect(GenerateCert(dir, "debezium-operator.cdc.svc", DefaultCertKey, ca, ServiceDNSNames("debezium-operator", namespace)...)).To(Succeed())
+		certData, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+		Expect(err).NotTo(HaveOccurred())
+		leaf := readCert(certData)
+		Expect(leaf.Issuer.CommonName).To(Equal("Internal CA"))
+		Expect(leaf.NotAfter).NotTo(BeTemporally(">", ca.Cert.NotAfter))
+
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(caPEM)).To(BeTrue())
+		for _, name := range []string{"debezium-operator.cdc.svc", "debezium-operator.cdc.svc.cluster.local"} {
+			_, err = leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+			Expect(err).NotTo(HaveOccurred(), name)
+		}
+
+		// A different CA does not verify the certificate.
+		otherPEM, _ := newTestCA(true)
+		others := x509.NewCertPool()
+		Expect(others.AppendCertsFromPEM(otherPEM)).To(BeTrue())
+		_, err = leaf.Verify(x509.VerifyOptions{DNSName: "debezium-operator.cdc.svc", Roots: others})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("loads the CA from a Secret and rejects unusable ones", func() {
+		caPEM, caKeyPEM := newTestCA(true)
+		leafPEM, leafKeyPEM := newTestCA(false)
+		_, otherKeyPEM := newTestCA(true)
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: namespace}, Data: map[string][]byte{"ca.crt": caPEM, "ca.key": caKeyPEM}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "leaf", Namespace: namespace}, Data: map[string][]byte{"tls.crt": leafPEM, "tls.key": leafKeyPEM}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mismatch", Namespace: namespace}, Data: map[string][]byte{"tls.crt": caPEM, "tls.key": otherKeyPEM}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: namespace}},
+		).Build()
+
+		ca, err := LoadCertAuthority(ctx, c, namespace, "ca")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ca.CertPEM).To(Equal(caPEM))
+
+		_, err = LoadCertAuthority(ctx, c, namespace, "leaf")
+		Expect(err).To(MatchError(ContainSubstring("is not a CA certificate")))
+		_, err = LoadCertAuthority(ctx, c, namespace, "mismatch")
+		Expect(err).To(MatchError(ContainSubstring("does not match")))
+		_, err = LoadCertAuthority(ctx, c, namespace, "empty")
+		Expect(err).To(MatchError(ContainSubstring("does not contain")))
+		_, err = LoadCertAuthority(ctx, c, namespace, "missing")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("stores the CA in the TLS secret and uses it as the caBundle", func() {
+		caPEM, caKeyPEM := newTestCA(true)
+		ca, err := ParseCertAuthority(caPEM, caKeyPEM)
+		Expect(err).NotTo(HaveOccurred())
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "vwc"},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "webhook"}},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(vwc).Build()
+
+		dir := GinkgoT().TempDir()
+		Expect(LoadOrGenerateCert(ctx, c, namespace, "tls", dir, "debezium-operator.cdc.svc", DefaultCertKey, ca)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "tls"}, secret)).To(Succeed())
+		Expect(secret.Data["ca.crt"]).To(Equal(caPEM))
+		Expect(readCert(secret.Data["tls.crt"]).CheckSignatureFrom(ca.Cert)).To(Succeed())
+
+		Expect(UpdateWebhookCABundle(ctx, c, "webhook", "vwc", namespace, "tls")).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "vwc"}, vwc)).To(Succeed())
+		Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(caPEM))
+	})
+
+	It("reissues a self-signed certificate once a CA is configured", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		dir := GinkgoT().TempDir()
+		Expect(LoadOrGenerateCert(ctx, c, namespace, "tls", dir, "debezium-operator.cdc.svc", DefaultCertKey, nil)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "tls"}, secret)).To(Succeed())
+		Expect(secret.Data).NotTo(HaveKey("ca.crt"))
+		selfSigned := secret.Data["tls.crt"]
+
+		// Without a CA the existing certificate is kept.
+		Expect(LoadOrGenerateCert(ctx, c, namespace, "tls", dir, "debezium-operator.cdc.svc", DefaultCertKey, nil)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "tls"}, secret)).To(Succeed())
+		Expect(secret.Data["tls.crt"]).To(Equal(selfSigned))
+
+		caPEM, caKeyPEM := newTestCA(true)
+		ca, err := ParseCertAuthority(caPEM, caKeyPEM)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(LoadOrGenerateCert(ctx, c, namespace, "tls", dir, "debezium-operator.cdc.svc", DefaultCertKey, ca)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "tls"}, secret)).To(Succeed())
+		Expect(secret.Data["ca.crt"]).To(Equal(caPEM))
+		Expect(readCert(secret.Data["tls.crt"]).CheckSignatureFrom(ca.Cert)).To(Succeed())
+		Expect(os.ReadFile(filepath.Join(dir, "tls.crt"))).To(Equal(secret.Data["tls.crt"]))
+	})
+})
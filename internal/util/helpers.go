@@ -1,5 +1,11 @@
 package util
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
 // ConfigsEqual compares two configuration maps for equality.
 func ConfigsEqual(a, b map[string]string) bool {
 	if len(a) != len(b) {
@@ -12,3 +18,21 @@ func ConfigsEqual(a, b map[string]string) bool {
 	}
 	return true
 }
+
+// ConfigHash returns a stable hash of a configuration map, used to detect config changes
+// without storing the config itself.
+func ConfigHash(config map[string]string) string {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(config[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
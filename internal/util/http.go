@@ -0,0 +1,38 @@
+package util
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DecodeResponseBody replaces resp.Body with a decompressing reader when the server returned
+// a gzip-encoded body that the transport did not already decode (e.g. behind some gateways).
+// The original body is left for the caller to close.
+func DecodeResponseBody(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip response body: %w", err)
+	}
+	resp.Body = gz
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// ConnectURL returns the URL of path on the Kafka Connect REST API at host. When Connect is
+// exposed below a path prefix, e.g. by a gateway, basePath is put in front of path. Slashes are
+// normalized, so "kafka-connect", "/kafka-connect" and "/kafka-connect/" are equivalent.
+func ConnectURL(host, basePath, path string) string {
+	url := strings.TrimSuffix(host, "/")
+	if prefix := strings.Trim(basePath, "/"); prefix != "" {
+		url += "/" + prefix
+	}
+	return url + "/" + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,67 @@
+package util
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DecodeResponseBody", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		// Mimic a gateway that gzips responses regardless of the request's Accept-Encoding.
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+			_ = json.NewEncoder(gz).Encode(map[string]string{"name": "inventory"})
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("decodes gzip bodies the transport left compressed", func() {
+		client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+		resp, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(DecodeResponseBody(resp)).To(Succeed())
+		var body map[string]string
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		Expect(body).To(HaveKeyWithValue("name", "inventory"))
+	})
+
+	It("leaves bodies already decoded by the transport untouched", func() {
+		resp, err := http.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(resp.Uncompressed).To(BeTrue())
+		Expect(DecodeResponseBody(resp)).To(Succeed())
+		var body map[string]string
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		Expect(body).To(HaveKeyWithValue("name", "inventory"))
+	})
+})
+
+var _ = DescribeTable("ConnectURL",
+	func(host, basePath, path, expected string) {
+		Expect(ConnectURL(host, basePath, path)).To(Equal(expected))
+	},
+	Entry("without a base path", "http://connect:8083", "", "/connectors", "http://connect:8083/connectors"),
+	Entry("with a trailing slash on the host", "http://connect:8083/", "", "/connectors", "http://connect:8083/connectors"),
+	Entry("with a base path", "http://gateway", "/kafka-connect", "/connectors/inventory", "http://gateway/kafka-connect/connectors/inventory"),
+	Entry("with a trailing slash on the base path", "http://gateway", "/kafka-connect/", "/connectors", "http://gateway/kafka-connect/connectors"),
+	Entry("with a relative base path", "http://gateway/", "kafka-connect", "connectors", "http://gateway/kafka-connect/connectors"),
+	Entry("with a nested base path", "http://gateway", "/platform/kafka-connect", "/", "http://gateway/platform/kafka-connect/"),
+	Entry("with a query", "http://gateway", "/kafka-connect", "/connectors?expand=status", "http://gateway/kafka-connect/connectors?expand=status"),
+)
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPClientOptions tunes the connection pool of the client used for the Kafka Connect REST API.
+type HTTPClientOptions struct {
+	// Timeout bounds each request, including reading the response body; zero means no timeout.
+	Timeout time.Duration
+	// MaxIdleConns bounds the idle connections kept across all hosts; zero means no limit.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost bounds the idle connections kept per host. It should cover the
+	// concurrent requests to one Connect host, or connections are closed and reopened.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds all connections per host; requests wait for a free connection once
+	// it is reached. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept before it is closed.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the TCP keep-alive period of the connections.
+	KeepAlive time.Duration
+}
+
+// DefaultHTTPClientOptions are used for the Kafka Connect client unless tuned with flags.
+var DefaultHTTPClientOptions = HTTPClientOptions{
+	Timeout:             10 * time.Second,
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 32,
+	IdleConnTimeout:     90 * time.Second,
+	KeepAlive:           30 * time.Second,
+}
+
+// NewHTTPClient returns a client whose transport pools connections as configured by opts. The
+// client is safe for concurrent use and meant to be shared, so connections are reused across
+// reconciles.
+func NewHTTPClient(opts HTTPClientOptions) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: opts.KeepAlive}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// newCountingServer starts a server answering with a small JSON document and counts the
+// connections opened to it.
+func newCountingServer(delay time.Duration) (*httptest.Server, *atomic.Int32) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"state": "RUNNING", "padding": strings.Repeat("x", 1024)})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	return server, &conns
+}
+
+// getJSON decodes the response like the reconciler does, which leaves the trailing newline
+// unread.
+func getJSON(client *http.Client, url string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var body map[string]string
+	return json.NewDecoder(resp.Body).Decode(&body)
+}
+
+var _ = Describe("NewHTTPClient", func() {
+	It("applies the pool settings to the transport", func() {
+		client := NewHTTPClient(HTTPClientOptions{
+			Timeout:             5 * time.Second,
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 4,
+			MaxConnsPerHost:     8,
+			IdleConnTimeout:     time.Minute,
+		})
+		Expect(client.Timeout).To(Equal(5 * time.Second))
+		transport := client.Transport.(*http.Transport)
+		Expect(transport.MaxIdleConns).To(Equal(10))
+		Expect(transport.MaxIdleConnsPerHost).To(Equal(4))
+		Expect(transport.MaxConnsPerHost).To(Equal(8))
+		Expect(transport.IdleConnTimeout).To(Equal(time.Minute))
+	})
+
+	It("reuses one connection for sequential requests", func() {
+		server, conns := newCountingServer(0)
+		defer server.Close()
+
+		client := NewHTTPClient(DefaultHTTPClientOptions)
+		for i := 0; i < 20; i++ {
+			Expect(getJSON(client, server.URL)).To(Succeed())
+		}
+		Expect(conns.Load()).To(Equal(int32(1)))
+	})
+
+	It("bounds the connections per host", func() {
+		server, conns := newCountingServer(20 * time.Millisecond)
+		defer server.Close()
+
+		opts := DefaultHTTPClientOptions
+		opts.MaxConnsPerHost = 2
+		client := NewHTTPClient(opts)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(getJSON(client, server.URL)).To(Succeed())
+			}()
+		}
+		wg.Wait()
+		Expect(conns.Load()).To(BeNumerically("<=", 2))
+	})
+})
+
+func BenchmarkHTTPClientSequentialRequests(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		client *http.Client
+	}{
+		{"pooled", NewHTTPClient(DefaultHTTPClientOptions)},
+		{"unpooled", &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			server, conns := newCountingServer(0)
+			defer server.Close()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := getJSON(bc.client, server.URL); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+)
+
+// ImpersonatedConfig returns a copy of base that impersonates the given service account.
+func ImpersonatedConfig(base *rest.Config, namespace, serviceAccount string) *rest.Config {
+	cfg := rest.CopyConfig(base)
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount),
+	}
+	return cfg
+}
@@ -0,0 +1,19 @@
+package util
+
+const (
+	// ManagedByLabel is the standard label identifying the tool that manages an object.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the ManagedByLabel value set on objects created by the operator.
+	ManagedByValue = "debezium-operator"
+)
+
+// StandardLabels builds the label set for objects created by the operator: the labels of the
+// parent resource (if any) plus the managed-by label, which always takes precedence.
+func StandardLabels(parentLabels map[string]string) map[string]string {
+	labels := make(map[string]string, len(parentLabels)+1)
+	for k, v := range parentLabels {
+		labels[k] = v
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	return labels
+}
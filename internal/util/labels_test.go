@@ -0,0 +1,16 @@
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StandardLabels", func() {
+	It("merges parent labels with the managed-by label", func() {
+		labels := StandardLabels(map[string]string{"team": "data", ManagedByLabel: "helm"})
+		Expect(labels).To(Equal(map[string]string{
+			"team":         "data",
+			ManagedByLabel: ManagedByValue,
+		}))
+	})
+})
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ListNormalization is how a comma-separated list value is normalized before configs are compared.
+type ListNormalization int
+
+const (
+	// ListTrim trims whitespace around items and drops empty items; the item order is significant.
+	ListTrim ListNormalization = iota
+	// ListSort additionally sorts the items, for lists whose order has no meaning.
+	ListSort
+)
+
+// DefaultListValuedKeys lists the comma-separated Debezium and Kafka Connect config keys. Include
+// and exclude lists are sets, while transforms and predicates are applied in order.
+var DefaultListValuedKeys = map[string]ListNormalization{
+	"database.include.list":   ListSort,
+	"database.exclude.list":   ListSort,
+	"schema.include.list":     ListSort,
+	"schema.exclude.list":     ListSort,
+	"table.include.list":      ListSort,
+	"table.exclude.list":      ListSort,
+	"column.include.list":     ListSort,
+	"column.exclude.list":     ListSort,
+	"collection.include.list": ListSort,
+	"collection.exclude.list": ListSort,
+	"message.key.columns":     ListTrim,
+	"transforms":              ListTrim,
+	"predicates":              ListTrim,
+}
+
+// NormalizeListValue trims the items of a comma-separated list, drops empty items and, if
+// sorted is set, sorts them.
+func NormalizeListValue(value string, sorted bool) string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if sorted {
+		sort.Strings(items)
+	}
+	return strings.Join(items, ",")
+}
+
+// NormalizeConfig returns a copy of config with the values of listKeys normalized.
+func NormalizeConfig(config map[string]string, listKeys map[string]ListNormalization) map[string]string {
+	normalized := make(map[string]string, len(config))
+	for k, v := range config {
+		if mode, ok := listKeys[k]; ok {
+			v = NormalizeListValue(v, mode == ListSort)
+		}
+		normalized[k] = v
+	}
+	return normalized
+}
+
+// ValueType is the type of a config value, used to normalize it to a canonical form before
+// configs are compared or sent to the Debezium host.
+type ValueType int
+
+const (
+	// ValueString values are not normalized.
+	ValueString ValueType = iota
+	// ValueBoolean values are written "true" or "false", in any case.
+	ValueBoolean
+	// ValueInt values are whole numbers, also of Kafka's SHORT and LONG types, e.g. "1000"
+	// written as "1000.0".
+	ValueInt
+	// ValueDouble values are decimal numbers, e.g. "0.5" written as "0.50" or "5E-1".
+	ValueDouble
+)
+
+// DefaultValueTypes lists the types of common Debezium and Kafka Connect config keys that are
+// not strings, for connector classes whose config definition is not known.
+var DefaultValueTypes = map[string]ValueType{
+	"tasks.max":                                     ValueInt,
+	"database.port":                                 ValueInt,
+	"database.server.id":                            ValueInt,
+	"snapshot.fetch.size":                           ValueInt,
+	"snapshot.delay.ms":                             ValueInt,
+	"snapshot.max.threads":                          ValueInt,
+	"max.batch.size":                                ValueInt,
+	"max.queue.size":                                ValueInt,
+	"max.queue.size.in.bytes":                       ValueInt,
+	"poll.interval.ms":                              ValueInt,
+	"heartbeat.interval.ms":                         ValueInt,
+	"retriable.restart.connector.wait.ms":           ValueInt,
+	"errors.retry.timeout":                          ValueInt,
+	"errors.retry.delay.max.ms":                     ValueInt,
+	"errors.max.retries":                            ValueInt,
+	"connect.timeout.ms":                            ValueInt,
+	"query.fetch.size":                              ValueInt,
+	"include.schema.changes":                        ValueBoolean,
+	"include.query":                                 ValueBoolean,
+	"tombstones.on.delete":                          ValueBoolean,
+	"provide.transaction.metadata":                  ValueBoolean,
+	"slot.drop.on.stop":                             ValueBoolean,
+	"errors.log.enable":                             ValueBoolean,
+	"errors.log.include.messages":                   ValueBoolean,
+	"errors.deadletterqueue.context.headers.enable": ValueBoolean,
+	"key.converter.schemas.enable":                  ValueBoolean,
+	"value.converter.schemas.enable":                ValueBoolean,
+	"topic.creation.default.replication.factor":     ValueInt,
+	"topic.creation.default.partitions":             ValueInt,
+}
+
+// ParseValueType returns the ValueType of a Kafka Connect config definition type, as reported by
+// the validate endpoint, e.g. BOOLEAN or LONG. Other types are strings.
+func ParseValueType(definitionType string) ValueType {
+	switch strings.ToUpper(definitionType) {
+	case "BOOLEAN":
+		return ValueBoolean
+	case "SHORT", "INT", "LONG":
+		return ValueInt
+	case "DOUBLE":
+		return ValueDouble
+	}
+	return ValueString
+}
+
+// NormalizeTypedValue returns the canonical form of a value of type t. Values that do not parse
+// as t, e.g. placeholders resolved by Connect, are returned unchanged.
+func NormalizeTypedValue(value string, t ValueType) string {
+	s := strings.TrimSpace(value)
+	switch t {
+	case ValueBoolean:
+		if strings.EqualFold(s, "true") || strings.EqualFold(s, "false") {
+			return strings.ToLower(s)
+		}
+	case ValueInt:
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil && f == math.Trunc(f) && math.Abs(f) < math.MaxInt64 {
+			return strconv.FormatInt(int64(f), 10)
+		}
+	case ValueDouble:
+		if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	}
+	return value
+}
+
+// NormalizeValues returns a copy of config with the values of the keys in types normalized to
+// their canonical form.
+func NormalizeValues(config map[string]string, types map[string]ValueType) map[string]string {
+	normalized := make(map[string]string, len(config))
+	for k, v := range config {
+		if t, ok := types[k]; ok {
+			v = NormalizeTypedValue(v, t)
+		}
+		normalized[k] = v
+	}
+	return normalized
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NormalizeConfig", func() {
+	It("normalizes list values", func() {
+		Expect(NormalizeListValue(" b, a ,,c ", false)).To(Equal("b,a,c"))
+		Expect(NormalizeListValue(" b, a ,,c ", true)).To(Equal("a,b,c"))
+		Expect(NormalizeListValue("", true)).To(Equal(""))
+	})
+
+	It("treats reordered and padded set-valued lists as equal", func() {
+		a := map[string]string{"table.include.list": "a,b", "transforms": "route,unwrap"}
+		b := map[string]string{"table.include.list": "b, a", "transforms": "route, unwrap "}
+		Expect(ConfigsEqual(a, b)).To(BeFalse())
+		Expect(ConfigsEqual(NormalizeConfig(a, DefaultListValuedKeys), NormalizeConfig(b, DefaultListValuedKeys))).To(BeTrue())
+	})
+
+	It("keeps the order of ordered lists and other values", func() {
+		a := map[string]string{"transforms": "route,unwrap", "snapshot.mode": "initial"}
+		b := map[string]string{"transforms": "unwrap,route", "snapshot.mode": "initial "}
+		normalizedA, normalizedB := NormalizeConfig(a, DefaultListValuedKeys), NormalizeConfig(b, DefaultListValuedKeys)
+		Expect(normalizedA["transforms"]).NotTo(Equal(normalizedB["transforms"]))
+		Expect(normalizedA["snapshot.mode"]).NotTo(Equal(normalizedB["snapshot.mode"]))
+	})
+})
+
+var _ = Describe("NormalizeValues", func() {
+	DescribeTable("normalizes typed values",
+		func(value string, t ValueType, expected string) {
+			Expect(NormalizeTypedValue(value, t)).To(Equal(expected))
+		},
+		Entry("boolean in upper case", "TRUE", ValueBoolean, "true"),
+		Entry("boolean in mixed case", " False", ValueBoolean, "false"),
+		Entry("invalid boolean", "yes", ValueBoolean, "yes"),
+		Entry("integer", "1000", ValueInt, "1000"),
+		Entry("integer written as a decimal", "1000.0", ValueInt, "1000"),
+		Entry("integer with padding and sign", " +42 ", ValueInt, "42"),
+		Entry("negative integer", "-1", ValueInt, "-1"),
+		Entry("fractional integer", "1.5", ValueInt, "1.5"),
+		Entry("integer placeholder", "${env:TASKS}", ValueInt, "${env:TASKS}"),
+		Entry("double with trailing zeros", "0.50", ValueDouble, "0.5"),
+		Entry("double in exponent notation", "5E-1", ValueDouble, "0.5"),
+		Entry("whole double", "2.0", ValueDouble, "2"),
+		Entry("invalid double", "half", ValueDouble, "half"),
+		Entry("string", "TRUE", ValueString, "TRUE"),
+	)
+
+	It("maps Kafka Connect definition types", func() {
+		Expect(ParseValueType("BOOLEAN")).To(Equal(ValueBoolean))
+		Expect(ParseValueType("SHORT")).To(Equal(ValueInt))
+		Expect(ParseValueType("INT")).To(Equal(ValueInt))
+		Expect(ParseValueType("LONG")).To(Equal(ValueInt))
+		Expect(ParseValueType("DOUBLE")).To(Equal(ValueDouble))
+		Expect(ParseValueType("PASSWORD")).To(Equal(ValueString))
+		Expect(ParseValueType("LIST")).To(Equal(ValueString))
+	})
+
+	It("treats values Connect rewrites as equal", func() {
+		a := map[string]string{"tasks.max": "1", "include.schema.changes": "true", "snapshot.mode": "initial"}
+		b := map[string]string{"tasks.max": "1.0", "include.schema.changes": "TRUE", "snapshot.mode": "initial"}
+		Expect(ConfigsEqual(a, b)).To(BeFalse())
+		Expect(ConfigsEqual(NormalizeValues(a, DefaultValueTypes), NormalizeValues(b, DefaultValueTypes))).To(BeTrue())
+	})
+
+	It("leaves keys without a type unchanged", func() {
+		config := map[string]string{"snapshot.mode": "INITIAL", "tasks.max": "1.0"}
+		normalized := NormalizeValues(config, DefaultValueTypes)
+		Expect(normalized).To(Equal(map[string]string{"snapshot.mode": "INITIAL", "tasks.max": "1"}))
+		Expect(config["tasks.max"]).To(Equal("1.0"))
+	})
+})
@@ -0,0 +1,138 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// PropertiesError reports a malformed line in a Java properties document.
+type PropertiesError struct {
+	// Line is the 1-based line on which the malformed logical line starts.
+	Line int
+	Msg  string
+}
+
+func (e *PropertiesError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// ParseProperties parses a document in the java.util.Properties text format. Keys and values are
+// separated by '=', ':' or whitespace, lines starting with '#' or '!' are comments, a line ending in
+// an odd number of backslashes continues on the next line, and the usual escapes (\t, \n, \r, \f,
+// \uXXXX and backslash-escaped characters) are decoded. Later keys override earlier ones.
+func ParseProperties(text string) (map[string]string, error) {
+	props := map[string]string{}
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text), "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// Join continuation lines, dropping the leading whitespace of each continuation.
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		rawKey, rawValue := splitProperty(line)
+		key, err := unescapeProperty(rawKey)
+		if err != nil {
+			return nil, &PropertiesError{Line: start, Msg: err.Error()}
+		}
+		if key == "" {
+			return nil, &PropertiesError{Line: start, Msg: "missing key"}
+		}
+		value, err := unescapeProperty(rawValue)
+		if err != nil {
+			return nil, &PropertiesError{Line: start, Msg: err.Error()}
+		}
+		props[key] = value
+	}
+	return props, nil
+}
+
+// endsWithContinuation reports whether line ends in an odd number of backslashes.
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line into its raw key and value. The key ends at the first
+// unescaped '=', ':' or whitespace; whitespace around a single separator is dropped.
+func splitProperty(line string) (string, string) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			keyEnd = i
+			break
+		}
+	}
+	rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return line[:keyEnd], rest
+}
+
+// unescapeProperty decodes the escape sequences of a properties key or value.
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\uxxxx escape")
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uxxxx escape %q", s[i-1:i+5])
+			}
+			i += 4
+			// Characters outside the BMP are written as a UTF-16 surrogate pair of escapes.
+			if utf16.IsSurrogate(rune(r)) && i+6 < len(s) && s[i+1] == '\\' && s[i+2] == 'u' {
+				if low, err := strconv.ParseUint(s[i+3:i+7], 16, 32); err == nil {
+					if pair := utf16.DecodeRune(rune(r), rune(low)); pair != utf8.RuneError {
+						b.WriteRune(pair)
+						i += 6
+						continue
+					}
+				}
+			}
+			b.WriteRune(rune(r))
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
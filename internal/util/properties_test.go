@@ -0,0 +1,82 @@
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseProperties", func() {
+	parse := func(text string) map[string]string {
+		props, err := ParseProperties(text)
+		Expect(err).NotTo(HaveOccurred())
+		return props
+	}
+
+	It("accepts '=', ':' and whitespace separators", func() {
+		Expect(parse("a=1\nb: 2\nc 3\nd = 4\ne\t:\t5\nf ==6")).To(Equal(map[string]string{
+			"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "=6",
+		}))
+	})
+
+	It("skips comments and blank lines", func() {
+		Expect(parse("# comment\n! another\n\n   \n  # indented\na=1\n")).To(Equal(map[string]string{"a": "1"}))
+	})
+
+	It("keeps comment characters inside values", func() {
+		Expect(parse("a=x # not a comment")).To(Equal(map[string]string{"a": "x # not a comment"}))
+	})
+
+	It("accepts keys without a value", func() {
+		Expect(parse("a\nb=\nc:")).To(Equal(map[string]string{"a": "", "b": "", "c": ""}))
+	})
+
+	It("handles all line terminators", func() {
+		Expect(parse("a=1\r\nb=2\rc=3\n")).To(Equal(map[string]string{"a": "1", "b": "2", "c": "3"}))
+	})
+
+	It("joins continuation lines and drops their leading whitespace", func() {
+		props := parse("table.include.list=inventory.orders,\\\n    inventory.customers,\\\n\tinventory.products\nnext=1")
+		Expect(props).To(Equal(map[string]string{
+			"table.include.list": "inventory.orders,inventory.customers,inventory.products",
+			"next":               "1",
+		}))
+	})
+
+	It("does not treat an escaped backslash as a continuation", func() {
+		Expect(parse("path=C:\\\\\nnext=1")).To(Equal(map[string]string{"path": `C:\`, "next": "1"}))
+	})
+
+	It("drops a trailing continuation on the last line", func() {
+		Expect(parse("a=1\\")).To(Equal(map[string]string{"a": "1"}))
+	})
+
+	It("does not continue comment lines", func() {
+		Expect(parse("# comment \\\na=1")).To(Equal(map[string]string{"a": "1"}))
+	})
+
+	It("decodes escapes in keys and values", func() {
+		props := parse(`key\ with\=sep\:s=tab\there\nnew\rline\fx\\y\"z\u00e9\uD83D\uDE00`)
+		Expect(props).To(Equal(map[string]string{
+			"key with=sep:s": "tab\there\nnew\rline\fx\\y\"zé😀",
+		}))
+	})
+
+	It("lets later keys override earlier ones", func() {
+		Expect(parse("a=1\na=2")).To(Equal(map[string]string{"a": "2"}))
+	})
+
+	It("reports malformed unicode escapes with the starting line", func() {
+		_, err := ParseProperties("a=1\nb=first\\\n  \\u12G4")
+		Expect(err).To(MatchError(ContainSubstring("line 2")))
+		Expect(err).To(BeAssignableToTypeOf(&PropertiesError{}))
+		Expect(err.(*PropertiesError).Line).To(Equal(2))
+
+		_, err = ParseProperties("a=\\u12")
+		Expect(err).To(MatchError(ContainSubstring("line 1: malformed \\uxxxx escape")))
+	})
+
+	It("reports a missing key", func() {
+		_, err := ParseProperties("a=1\n\n=value")
+		Expect(err).To(MatchError("line 3: missing key"))
+	})
+})
@@ -0,0 +1,67 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultSensitiveKeyPattern matches config keys whose values are redacted by default. A key
+// segment only counts when it ends the key, e.g. ssl.key or api_key, so keys such as
+// key.converter or message.key.columns are left readable.
+const DefaultSensitiveKeyPattern = `(?i)password|secret|token|credential|(^|[._-])key$`
+
+// maskedValue replaces sensitive config values in anything persisted, logged or reported.
+const maskedValue = "******"
+
+// sensitiveKeyPattern matches config keys whose values must not be exposed.
+var sensitiveKeyPattern = regexp.MustCompile(DefaultSensitiveKeyPattern)
+
+// jsonStringPair matches a "key": "value" pair in JSON text, such as config echoed back by Connect.
+var jsonStringPair = regexp.MustCompile(`"([^"\\]+)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// SetSensitiveKeyPattern replaces the pattern matching sensitive config keys.
+// It is meant to be called once at startup, before any redaction happens.
+func SetSensitiveKeyPattern(expr string) error {
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid sensitive key pattern %q: %w", expr, err)
+	}
+	sensitiveKeyPattern = pattern
+	return nil
+}
+
+// IsSensitiveKey reports whether the value of the config key must be redacted.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeyPattern.MatchString(key)
+}
+
+// MaskSensitiveConfig returns a copy of config with the values of sensitive keys masked.
+func MaskSensitiveConfig(config map[string]string) map[string]string {
+	masked := make(map[string]string, len(config))
+	for k, v := range config {
+		if IsSensitiveKey(k) {
+			v = maskedValue
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+// RedactText masks sensitive values in free text such as Connect error bodies: JSON string
+// pairs whose key is sensitive, and any occurrence of a sensitive value from config.
+func RedactText(text string, config map[string]string) string {
+	text = jsonStringPair.ReplaceAllStringFunc(text, func(pair string) string {
+		m := jsonStringPair.FindStringSubmatch(pair)
+		if !IsSensitiveKey(m[1]) {
+			return pair
+		}
+		return fmt.Sprintf(`"%s"%s"%s"`, m[1], m[2], maskedValue)
+	})
+	for k, v := range config {
+		if v != "" && IsSensitiveKey(k) {
+			text = strings.ReplaceAll(text, v, maskedValue)
+		}
+	}
+	return text
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redaction", func() {
+	config := map[string]string{
+		"name":              "inventory",
+		"database.user":     "debezium",
+		"database.password": "s3cr3t-pw",
+		"auth.token":        "tok-123",
+	}
+
+	AfterEach(func() {
+		Expect(SetSensitiveKeyPattern(DefaultSensitiveKeyPattern)).To(Succeed())
+	})
+
+	It("masks the values of sensitive keys", func() {
+		masked := MaskSensitiveConfig(config)
+		Expect(masked["database.password"]).To(Equal(maskedValue))
+		Expect(masked["auth.token"]).To(Equal(maskedValue))
+		Expect(masked["database.user"]).To(Equal("debezium"))
+		Expect(config["database.password"]).To(Equal("s3cr3t-pw"), "input must not be modified")
+	})
+
+	It("never leaves sensitive values in a Connect error body", func() {
+		body := `{"error_code":400,"message":"Connector config {database.password=s3cr3t-pw, auth.token=tok-123} is invalid"}`
+		redacted := RedactText(body, config)
+		Expect(redacted).NotTo(ContainSubstring("s3cr3t-pw"))
+		Expect(redacted).NotTo(ContainSubstring("tok-123"))
+		Expect(redacted).To(ContainSubstring("is invalid"))
+	})
+
+	It("masks sensitive JSON pairs without knowing the config", func() {
+		body := `{"config":{"database.password": "other-pw","database.user":"debezium"}}`
+		redacted := RedactText(body, nil)
+		Expect(redacted).NotTo(ContainSubstring("other-pw"))
+		Expect(redacted).To(ContainSubstring(`"database.user":"debezium"`))
+	})
+
+	It("matches credential-like keys but not keys merely containing key", func() {
+		for _, key := range []string{"ssl.key", "api_key", "aws.secret.access.key", "database.ssl.keystore.password"} {
+			Expect(IsSensitiveKey(key)).To(BeTrue(), key)
+		}
+		for _, key := range []string{"key.converter", "message.key.columns", "snapshot.select.statement.overrides", "monkey"} {
+			Expect(IsSensitiveKey(key)).To(BeFalse(), key)
+		}
+	})
+
+	It("uses a configured key pattern", func() {
+		Expect(SetSensitiveKeyPattern(`(?i)^database\.user$`)).To(Succeed())
+		Expect(IsSensitiveKey("database.user")).To(BeTrue())
+		Expect(IsSensitiveKey("database.password")).To(BeFalse())
+		Expect(RedactText("login failed for debezium", config)).To(Equal("login failed for " + maskedValue))
+	})
+
+	It("rejects an invalid key pattern", func() {
+		Expect(SetSensitiveKeyPattern("(")).NotTo(Succeed())
+		Expect(IsSensitiveKey("database.password")).To(BeTrue())
+	})
+})
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SchemaRegistryURLKeys are the converter settings that point to a schema registry.
+var SchemaRegistryURLKeys = []string{
+	"key.converter.schema.registry.url",
+	"value.converter.schema.registry.url",
+}
+
+// schemaRegistryProbeClient does not follow redirects, so a probe cannot be sent on to a host
+// that is not allowed. A redirect answers the probe.
+var schemaRegistryProbeClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+// ProbeSchemaRegistries checks that the schema registries config points to answer HTTP
+// requests within timeout, which bounds the probe of all registries together. A key may list
+// several registry URLs separated by commas; it is reachable when any of them answers. Any
+// response below 500, including 401 or 404, counts as reachable, since the probe only tests the
+// connection. It returns the keys that were probed and a message for each key whose registries
+// are unreachable. Values with unresolved ${...} references are skipped. The URLs are written by
+// connector authors, so when allowedHosts is not empty only URLs whose host is one of them, or a
+// subdomain of an entry starting with a dot, are probed. httpClient defaults to a client that
+// does not follow redirects.
+func ProbeSchemaRegistries(ctx context.Context, httpClient *http.Client, config map[string]string, timeout time.Duration, allowedHosts []string) (probed, problems []string) {
+	if httpClient == nil {
+		httpClient = schemaRegistryProbeClient
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for _, key := range SchemaRegistryURLKeys {
+		value := strings.TrimSpace(config[key])
+		if value == "" || strings.Contains(value, "${") {
+			continue
+		}
+		var urls []string
+		for _, raw := range strings.Split(value, ",") {
+			if raw = strings.TrimSpace(raw); probeAllowed(raw, allowedHosts) {
+				urls = append(urls, raw)
+			}
+		}
+		if len(urls) == 0 {
+			continue
+		}
+		probed = append(probed, key)
+		var errs []string
+		for _, raw := range urls {
+			err := probeURL(ctx, httpClient, raw, timeout)
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("schema registry of %s is unreachable: %s", key, strings.Join(errs, "; ")))
+		}
+	}
+	return probed, problems
+}
+
+// probeAllowed reports whether the host of rawURL may be probed. Every host may be probed when
+// allowedHosts is empty, and URLs that do not parse are left for probeURL to report.
+func probeAllowed(rawURL string, allowedHosts []string) bool {
+	if len(allowedHosts) == 0 {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// probeURL sends a GET request to rawURL and returns an error unless the server answers with a
+// status below 500 before ctx is done; timeout is the deadline reported in errors. User info is
+// removed from the URL in errors.
+func probeURL(ctx context.Context, httpClient *http.Client, rawURL string, timeout time.Duration) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%q is not a valid URL", redactURL(rawURL))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", u.Redacted(), err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s did not respond within %s", u.Redacted(), timeout)
+		}
+		// The *url.Error repeats the URL including its user info.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: %w", u.Redacted(), err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s returned status %d", u.Redacted(), resp.StatusCode)
+	}
+	return nil
+}
+
+// redactURL masks the user info of a URL that could not be parsed.
+func redactURL(rawURL string) string {
+	if scheme, rest, ok := strings.Cut(rawURL, "://"); ok {
+		if at := strings.LastIndex(rest, "@"); at >= 0 {
+			return scheme + "://xxxxx@" + rest[at+1:]
+		}
+	}
+	return rawURL
+}
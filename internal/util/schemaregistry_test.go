@@ -0,0 +1,116 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProbeSchemaRegistries", func() {
+	ctx := context.Background()
+	var registry *httptest.Server
+
+	BeforeEach(func() {
+		registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	// unreachable returns the URL of a server that is no longer listening.
+	unreachable := func() string {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		return server.URL
+	}
+
+	It("accepts a registry that answers, even without credentials", func() {
+		probed, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"value.converter.schema.registry.url": registry.URL,
+		}, time.Second, nil)
+		Expect(probed).To(Equal([]string{"value.converter.schema.registry.url"}))
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("reports an unreachable registry without its credentials", func() {
+		down := unreachable()
+		_, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"key.converter.schema.registry.url":   registry.URL,
+			"value.converter.schema.registry.url": "http://user:s3cret@" + down[len("http://"):],
+		}, time.Second, nil)
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0]).To(ContainSubstring("value.converter.schema.registry.url"))
+		Expect(problems[0]).NotTo(ContainSubstring("s3cret"))
+	})
+
+	It("accepts a list of registries when one of them answers", func() {
+		_, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"value.converter.schema.registry.url": unreachable() + ", " + registry.URL,
+		}, time.Second, nil)
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("reports a registry that does not answer in time or fails", func() {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer slow.Close()
+		_, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"value.converter.schema.registry.url": slow.URL,
+		}, 50*time.Millisecond, nil)
+		Expect(problems).To(ConsistOf(ContainSubstring("did not respond within 50ms")))
+
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+		_, problems = ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"value.converter.schema.registry.url": failing.URL,
+		}, time.Second, nil)
+		Expect(problems).To(ConsistOf(ContainSubstring("returned status 503")))
+	})
+
+	It("bounds the probe of all registries by the timeout", func() {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer slow.Close()
+		start := time.Now()
+		_, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"key.converter.schema.registry.url":   slow.URL + "," + slow.URL,
+			"value.converter.schema.registry.url": slow.URL,
+		}, 100*time.Millisecond, nil)
+		Expect(problems).To(HaveLen(2))
+		Expect(time.Since(start)).To(BeNumerically("<", 250*time.Millisecond))
+	})
+
+	It("probes only allowed hosts and does not follow redirects", func() {
+		redirect := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/latest/meta-data/", http.StatusFound))
+		defer redirect.Close()
+		probed, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"key.converter.schema.registry.url":   "http://169.254.169.254/latest/meta-data/",
+			"value.converter.schema.registry.url": redirect.URL,
+		}, time.Second, []string{"127.0.0.1", ".registry.example.com"})
+		Expect(probed).To(Equal([]string{"value.converter.schema.registry.url"}))
+		Expect(problems).To(BeEmpty())
+
+		Expect(probeAllowed("https://eu.registry.example.com:8081", []string{".registry.example.com"})).To(BeTrue())
+		Expect(probeAllowed("https://registry.example.com.evil.io", []string{".registry.example.com"})).To(BeFalse())
+	})
+
+	It("skips configs without a registry and unresolved references", func() {
+		probed, problems := ProbeSchemaRegistries(ctx, nil, map[string]string{
+			"value.converter":                   "io.confluent.connect.avro.AvroConverter",
+			"key.converter.schema.registry.url": "${env:REGISTRY_URL}",
+		}, time.Second, nil)
+		Expect(probed).To(BeEmpty())
+		Expect(problems).To(BeEmpty())
+	})
+})
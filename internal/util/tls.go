@@ -0,0 +1,58 @@
+package util
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// tlsVersions maps the accepted --tls-min-version values to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+	// Names as used by Kubernetes components, e.g. --tls-min-version of the API server.
+	"VersionTLS10": tls.VersionTLS10,
+	"VersionTLS11": tls.VersionTLS11,
+	"VersionTLS12": tls.VersionTLS12,
+	"VersionTLS13": tls.VersionTLS13,
+}
+
+// TLSConfigOption returns a function that sets the minimum TLS version and, when cipherSuites is
+// not empty, restricts the cipher suites of a tls.Config. Versions are given as "1.2" or
+// "VersionTLS12", cipher suites by their IANA names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256.
+// Go does not allow configuring TLS 1.3 cipher suites, so the list only applies to older versions.
+func TLSConfigOption(minVersion string, cipherSuites []string) (func(*tls.Config), error) {
+	version, ok := tlsVersions[minVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS version %q", minVersion)
+	}
+
+	ids := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		ids[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		ids[suite.Name] = suite.ID
+	}
+	var suites []uint16
+	for _, name := range cipherSuites {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+
+	return func(c *tls.Config) {
+		c.MinVersion = version
+		if len(suites) > 0 {
+			c.CipherSuites = suites
+		}
+	}, nil
+}
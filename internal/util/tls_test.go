@@ -0,0 +1,44 @@
+package util
+
+import (
+	"crypto/tls"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TLSConfigOption", func() {
+	It("sets the minimum TLS version", func() {
+		for version, expected := range map[string]uint16{
+			"1.2":          tls.VersionTLS12,
+			"1.3":          tls.VersionTLS13,
+			"VersionTLS13": tls.VersionTLS13,
+		} {
+			opt, err := TLSConfigOption(version, nil)
+			Expect(err).NotTo(HaveOccurred())
+			c := &tls.Config{}
+			opt(c)
+			Expect(c.MinVersion).To(Equal(expected), version)
+			Expect(c.CipherSuites).To(BeNil())
+		}
+	})
+
+	It("restricts the cipher suites", func() {
+		opt, err := TLSConfigOption("1.2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", " TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", ""})
+		Expect(err).NotTo(HaveOccurred())
+		c := &tls.Config{}
+		opt(c)
+		Expect(c.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+		Expect(c.CipherSuites).To(Equal([]uint16{
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		}))
+	})
+
+	It("rejects unknown versions and cipher suites", func() {
+		_, err := TLSConfigOption("1.4", nil)
+		Expect(err).To(MatchError(ContainSubstring("unsupported TLS version")))
+		_, err = TLSConfigOption("1.2", []string{"TLS_NOT_A_SUITE"})
+		Expect(err).To(MatchError(ContainSubstring("unsupported cipher suite \"TLS_NOT_A_SUITE\"")))
+	})
+})
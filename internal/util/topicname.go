@@ -0,0 +1,53 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxTopicNameLength is the longest topic name Kafka accepts.
+const MaxTopicNameLength = 249
+
+// minTopicSuffix is the shortest suffix Debezium appends to the topic prefix to name the topic of
+// a table: a schema or database and a table name of one character each.
+const minTopicSuffix = ".s.t"
+
+// ValidateTopicName returns an error when name is not a legal Kafka topic name: it must be 1 to
+// 249 ASCII letters, digits, '.', '_' or '-', and must not be "." or "..".
+func ValidateTopicName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("topic name must not be empty")
+	case name == "." || name == "..":
+		return fmt.Errorf("topic name must not be %q", name)
+	case len(name) > MaxTopicNameLength:
+		return fmt.Errorf("topic name is %d characters long, longer than %d", len(name), MaxTopicNameLength)
+	}
+	if i := strings.IndexFunc(name, func(c rune) bool { return !isTopicNameChar(c) }); i >= 0 {
+		return fmt.Errorf("topic name contains %q; only ASCII letters, digits, '.', '_' and '-' are allowed", name[i:i+1])
+	}
+	return nil
+}
+
+// ValidateTopicPrefix returns an error when prefix alone makes the topics Debezium derives from
+// it illegal: the prefix itself names the schema change topic, and the topics of tables append
+// at least a schema or database and a table name to it.
+func ValidateTopicPrefix(prefix string) error {
+	if err := ValidateTopicName(prefix); err != nil {
+		return err
+	}
+	if len(prefix)+len(minTopicSuffix) > MaxTopicNameLength {
+		return fmt.Errorf("topic prefix is %d characters long and leaves no room for table names within %d characters", len(prefix), MaxTopicNameLength)
+	}
+	return nil
+}
+
+// IsTopicNameLiteral reports whether s consists of topic name characters only, e.g. a table
+// include list entry that names a single table rather than a pattern.
+func IsTopicNameLiteral(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(c rune) bool { return !isTopicNameChar(c) }) < 0
+}
+
+func isTopicNameChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-'
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topic names", func() {
+	It("accepts legal topic names", func() {
+		Expect(ValidateTopicName("inventory.public.orders")).To(Succeed())
+		Expect(ValidateTopicName("__debezium-heartbeat.inventory")).To(Succeed())
+		Expect(ValidateTopicName("...")).To(Succeed())
+		Expect(ValidateTopicName(strings.Repeat("a", MaxTopicNameLength))).To(Succeed())
+	})
+
+	It("rejects illegal topic names", func() {
+		Expect(ValidateTopicName("")).NotTo(Succeed())
+		Expect(ValidateTopicName(".")).NotTo(Succeed())
+		Expect(ValidateTopicName("..")).NotTo(Succeed())
+		Expect(ValidateTopicName(strings.Repeat("a", MaxTopicNameLength+1))).To(MatchError(ContainSubstring("250 characters")))
+		Expect(ValidateTopicName("inventory orders")).To(MatchError(ContainSubstring(`" "`)))
+		Expect(ValidateTopicName("inventory/orders")).NotTo(Succeed())
+		Expect(ValidateTopicName("inventory.bestellungen-ä")).NotTo(Succeed())
+	})
+
+	It("rejects prefixes that leave no room for table names", func() {
+		Expect(ValidateTopicPrefix("inventory")).To(Succeed())
+		Expect(ValidateTopicPrefix(strings.Repeat("a", MaxTopicNameLength-4))).To(Succeed())
+		Expect(ValidateTopicPrefix(strings.Repeat("a", MaxTopicNameLength-3))).To(MatchError(ContainSubstring("no room for table names")))
+		Expect(ValidateTopicPrefix(strings.Repeat("a", MaxTopicNameLength+1))).NotTo(Succeed())
+		Expect(ValidateTopicPrefix("shop:eu")).NotTo(Succeed())
+		Expect(ValidateTopicPrefix("..")).NotTo(Succeed())
+	})
+
+	It("tells table names from patterns", func() {
+		Expect(IsTopicNameLiteral("public.orders")).To(BeTrue())
+		Expect(IsTopicNameLiteral("public.orders_.*")).To(BeFalse())
+		Expect(IsTopicNameLiteral("inventory.(orders|customers)")).To(BeFalse())
+		Expect(IsTopicNameLiteral("")).To(BeFalse())
+	})
+})
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// WebhookServiceNameEnv names the Service that fronts the webhook server.
+	WebhookServiceNameEnv = "WEBHOOK_SERVICE_NAME"
+	// PodNamespaceEnv names the namespace the operator runs in, usually set from the downward API.
+	PodNamespaceEnv = "POD_NAMESPACE"
+	// ServiceAccountNamespaceFile holds the namespace of the pod's service account.
+	ServiceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// ResolveWebhookService returns the name and namespace of the webhook Service, from which the
+// certificate common name is derived. The name is read from WEBHOOK_SERVICE_NAME. The namespace
+// is read from POD_NAMESPACE and, when unset, from namespaceFile. An error is returned when
+// either cannot be determined or is not a valid DNS label, so no certificate is issued for a
+// name the API server will not connect to.
+func ResolveWebhookService(getenv func(string) string, namespaceFile string) (string, string, error) {
+	name := strings.TrimSpace(getenv(WebhookServiceNameEnv))
+	if name == "" {
+		return "", "", fmt.Errorf("%s must be set to the name of the webhook Service", WebhookServiceNameEnv)
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid %s %q: %s", WebhookServiceNameEnv, name, strings.Join(errs, "; "))
+	}
+
+	namespace := strings.TrimSpace(getenv(PodNamespaceEnv))
+	source := PodNamespaceEnv
+	if namespace == "" {
+		data, err := os.ReadFile(namespaceFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", "", fmt.Errorf("%s is unset and %s does not exist; set %s to the operator's namespace", PodNamespaceEnv, namespaceFile, PodNamespaceEnv)
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read namespace from %s: %w", namespaceFile, err)
+		}
+		namespace = strings.TrimSpace(string(data))
+		source = namespaceFile
+		if namespace == "" {
+			return "", "", fmt.Errorf("%s is unset and %s is empty; set %s to the operator's namespace", PodNamespaceEnv, namespaceFile, PodNamespaceEnv)
+		}
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid namespace %q from %s: %s", namespace, source, strings.Join(errs, "; "))
+	}
+	return name, namespace, nil
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResolveWebhookService", func() {
+	var namespaceFile string
+
+	BeforeEach(func() {
+		namespaceFile = filepath.Join(GinkgoT().TempDir(), "namespace")
+		Expect(os.WriteFile(namespaceFile, []byte("operators\n"), 0644)).To(Succeed())
+	})
+
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	It("prefers POD_NAMESPACE over the service account file", func() {
+		name, namespace, err := ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "debezium-operator",
+			PodNamespaceEnv:       "cdc",
+		}), namespaceFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("debezium-operator"))
+		Expect(namespace).To(Equal("cdc"))
+	})
+
+	It("falls back to the service account namespace file", func() {
+		_, namespace, err := ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "debezium-operator",
+		}), namespaceFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespace).To(Equal("operators"))
+	})
+
+	It("fails when the namespace cannot be determined", func() {
+		_, _, err := ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "debezium-operator",
+		}), filepath.Join(GinkgoT().TempDir(), "missing"))
+		Expect(err).To(MatchError(ContainSubstring("POD_NAMESPACE is unset")))
+
+		Expect(os.WriteFile(namespaceFile, []byte(" \n"), 0644)).To(Succeed())
+		_, _, err = ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "debezium-operator",
+		}), namespaceFile)
+		Expect(err).To(MatchError(ContainSubstring("is empty")))
+	})
+
+	It("fails when the service name is unset", func() {
+		_, _, err := ResolveWebhookService(env(map[string]string{
+			PodNamespaceEnv: "cdc",
+		}), namespaceFile)
+		Expect(err).To(MatchError(ContainSubstring("WEBHOOK_SERVICE_NAME must be set")))
+	})
+
+	It("rejects names that are not DNS labels", func() {
+		_, _, err := ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "Debezium_Operator",
+			PodNamespaceEnv:       "cdc",
+		}), namespaceFile)
+		Expect(err).To(MatchError(ContainSubstring("invalid WEBHOOK_SERVICE_NAME")))
+
+		_, _, err = ResolveWebhookService(env(map[string]string{
+			WebhookServiceNameEnv: "debezium-operator",
+			PodNamespaceEnv:       "cdc.example",
+		}), namespaceFile)
+		Expect(err).To(MatchError(ContainSubstring("invalid namespace")))
+	})
+})
@@ -0,0 +1,37 @@
+package version
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Build information, set at build time with
+// -ldflags "-X github.com/oleksandrfrolov95/debezium-operator/internal/version.Version=...".
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+	BuildDate = "unknown"
+)
+
+// buildInfo always reports 1; the build information is carried in its labels.
+var buildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "debezium_operator_build_info",
+	Help: "Build information of the running operator. Always 1.",
+}, []string{"version", "git_commit", "build_date"})
+
+func init() {
+	metrics.Registry.MustRegister(buildInfo)
+	buildInfo.WithLabelValues(Version, GitCommit, BuildDate).Set(1)
+}
+
+// String returns the build information in a single line.
+func String() string {
+	return fmt.Sprintf("version %s, git commit %s, built %s", Version, GitCommit, BuildDate)
+}
+
+// KeysAndValues returns the build information as structured logging key/value pairs.
+func KeysAndValues() []interface{} {
+	return []interface{}{"version", Version, "gitCommit", GitCommit, "buildDate", BuildDate}
+}
@@ -0,0 +1,30 @@
+package version
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Build information", func() {
+	It("reports the version set at build time", func() {
+		original := Version
+		DeferCleanup(func() { Version = original })
+		Version = "v1.2.3"
+
+		Expect(String()).NotTo(BeEmpty())
+		Expect(String()).To(ContainSubstring("v1.2.3"))
+		Expect(KeysAndValues()).To(ContainElement("v1.2.3"))
+	})
+
+	It("exports the build info metric", func() {
+		expected := `
+# HELP debezium_operator_build_info Build information of the running operator. Always 1.
+# TYPE debezium_operator_build_info gauge
+debezium_operator_build_info{build_date="unknown",git_commit="unknown",version="dev"} 1
+`
+		Expect(testutil.CollectAndCompare(buildInfo, strings.NewReader(expected))).To(Succeed())
+	})
+})